@@ -1,16 +1,27 @@
 package main
 
 import (
-	"database/sql"
+	"context"
+	"flag"
 	"fmt"
 	"os"
+	"sort"
 	"strconv"
+	"time"
 
+	"github.com/Pigmice2733/scouting-backend/internal/archive"
+	"github.com/Pigmice2733/scouting-backend/internal/store"
 	"github.com/Pigmice2733/scouting-backend/internal/store/postgres"
-	_ "github.com/lib/pq"
 )
 
-const usageFormat = `Usage: %s [...table]
+const usageFormat = `Usage:
+  %[1]s backup [-event eventKey] [-year year] [-o file]
+  %[1]s restore file
+
+backup writes everything in the database, or just one event or season, to a
+gzipped tar archive (defaults to backup-{time}.tar.gz). restore validates an
+archive and loads it into the database. Restoring an archive more than once
+has no further effect.
 
 Environment Variables:
 * PG_USER: postgresql user
@@ -22,12 +33,32 @@ Environment Variables:
 `
 
 func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	switch os.Args[1] {
+	case "backup":
+		backup(os.Args[2:])
+	case "restore":
+		restore(os.Args[2:])
+	default:
+		usage()
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, usageFormat, os.Args[0])
+	os.Exit(2)
+}
+
+func connect() *store.Service {
 	port, err := strconv.Atoi(os.Getenv("PG_PORT"))
 	if err != nil {
 		port = 5432
 	}
 
-	options := postgres.Options{
+	s, err := postgres.NewFromOptions(postgres.Options{
 		User:             os.Getenv("PG_USER"),
 		Pass:             os.Getenv("PG_PASS"),
 		Host:             os.Getenv("PG_HOST"),
@@ -35,43 +66,103 @@ func main() {
 		DBName:           os.Getenv("PG_DB_NAME"),
 		SSLMode:          os.Getenv("PG_SSL_MODE"),
 		StatementTimeout: 5000,
+	})
+	if err != nil {
+		fmt.Printf("error connecting to postgresql database: %v\n", err)
+		os.Exit(1)
 	}
 
-	db, err := sql.Open("postgres", options.ConnectionInfo())
+	return s
+}
+
+func backup(args []string) {
+	flags := flag.NewFlagSet("backup", flag.ExitOnError)
+	flags.Usage = usage
+	eventKey := flags.String("event", "", "only back up this event")
+	year := flags.Int("year", 0, "only back up events in this season")
+	out := flags.String("o", "", "archive file to write")
+	flags.Parse(args)
+
+	if flags.NArg() != 0 {
+		usage()
+	}
+
+	if *out == "" {
+		*out = fmt.Sprintf("backup-%s.tar.gz", time.Now().UTC().Format("20060102T150405Z"))
+	}
+
+	s := connect()
+
+	a, err := archive.Backup(context.Background(), s, archive.Scope{EventKey: *eventKey, Year: *year}, postgres.SchemaVersion())
 	if err != nil {
-		fmt.Printf("error connecting to postgresql database: %v\n", err)
+		fmt.Printf("error backing up: %v\n", err)
 		os.Exit(1)
 	}
-	defer db.Close()
 
-	if len(os.Args) == 1 {
-		fmt.Printf(usageFormat, os.Args[0])
-		os.Exit(2)
+	f, err := os.OpenFile(*out, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		fmt.Printf("error creating '%s': %v\n", *out, err)
+		os.Exit(1)
 	}
 
-	for _, table := range os.Args[1:] {
-		if err := archiveQuery(db, table, table+".json"); err != nil {
-			fmt.Printf("error archiving table '%s': %v\n", "events", err)
-		}
+	if err := archive.Write(f, &a); err != nil {
+		f.Close()
+		fmt.Printf("error writing '%s': %v\n", *out, err)
+		os.Exit(1)
+	}
+
+	if err := f.Close(); err != nil {
+		fmt.Printf("error writing '%s': %v\n", *out, err)
+		os.Exit(1)
 	}
+
+	fmt.Printf("wrote %s\n", *out)
+	printCounts(func(name string) int { return a.Manifest.Files[name].Records }, a.Manifest.Files)
 }
 
-const jsonArchiveFormat = `SELECT array_to_json(array_agg(%s)) FROM %s`
+func restore(args []string) {
+	if len(args) != 1 {
+		usage()
+	}
 
-func archiveQuery(db *sql.DB, table, out string) error {
-	query := fmt.Sprintf(jsonArchiveFormat, table, table)
+	f, err := os.Open(args[0])
+	if err != nil {
+		fmt.Printf("error opening '%s': %v\n", args[0], err)
+		os.Exit(1)
+	}
 
-	var archive string
-	if err := db.QueryRow(query).Scan(&archive); err != nil {
-		return err
+	a, err := archive.Read(f)
+	f.Close()
+	if err != nil {
+		fmt.Printf("invalid archive: %v\n", err)
+		os.Exit(1)
 	}
 
-	f, err := os.OpenFile("events.json", os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0666)
+	if err := a.Manifest.Compatible(postgres.SchemaVersion()); err != nil {
+		fmt.Printf("can't restore archive: %v\n", err)
+		os.Exit(1)
+	}
+
+	s := connect()
+
+	restored, err := archive.Restore(context.Background(), s, a)
 	if err != nil {
-		return err
+		fmt.Printf("error restoring: %v\n", err)
+		os.Exit(1)
 	}
-	defer f.Close()
 
-	_, err = f.WriteString(archive)
-	return err
+	fmt.Printf("restored %s\n", args[0])
+	printCounts(func(name string) int { return restored[name] }, a.Manifest.Files)
+}
+
+func printCounts(count func(name string) int, files map[string]archive.File) {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Printf("  %-20s %d\n", name, count(name))
+	}
 }
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/Pigmice2733/scouting-backend/internal/analysis"
+	"github.com/Pigmice2733/scouting-backend/internal/bundle"
+	"github.com/Pigmice2733/scouting-backend/internal/store"
+	"github.com/Pigmice2733/scouting-backend/internal/store/postgres"
+)
+
+const usageFormat = `Usage:
+  %[1]s export -org org [-o file] eventKey
+  %[1]s import -org org file
+
+export writes the reports of an organization at an event, along with the
+event, its matches and the report schema, to a signed bundle (defaults to
+{org}-{eventKey}.bundle.tar.gz). import merges a bundle from a partner into
+the reports of an organization. The organization must trust the partner's
+bundle key (see /bundles/trusted).
+
+Environment Variables:
+* PG_USER: postgresql user
+* PG_PASS: postgresql password
+* PG_HOST: postgresql host address
+* PG_PORT: postgresql port (defaults to 5432)
+* PG_DB_NAME: postgresql db name
+* PG_SSL_MODE: postgresql ssl mode
+* SCHEMA_PATH: report schema used if the organization has none (defaults to ./report.schema)
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	switch os.Args[1] {
+	case "export":
+		exportBundle(os.Args[2:])
+	case "import":
+		importBundle(os.Args[2:])
+	default:
+		usage()
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, usageFormat, os.Args[0])
+	os.Exit(2)
+}
+
+func fail(format string, args ...interface{}) {
+	fmt.Printf(format+"\n", args...)
+	os.Exit(1)
+}
+
+func connect() *store.Service {
+	port, err := strconv.Atoi(os.Getenv("PG_PORT"))
+	if err != nil {
+		port = 5432
+	}
+
+	s, err := postgres.NewFromOptions(postgres.Options{
+		User:             os.Getenv("PG_USER"),
+		Pass:             os.Getenv("PG_PASS"),
+		Host:             os.Getenv("PG_HOST"),
+		Port:             port,
+		DBName:           os.Getenv("PG_DB_NAME"),
+		SSLMode:          os.Getenv("PG_SSL_MODE"),
+		StatementTimeout: 5000,
+	})
+	if err != nil {
+		fail("error connecting to postgresql database: %v", err)
+	}
+
+	return s
+}
+
+// getSchema gets the report schema of an organization, or the schema at
+// SCHEMA_PATH if it doesn't have one.
+func getSchema(s *store.Service, org string) (analysis.Schema, error) {
+	schema, err := s.Schema.Get(context.Background(), org)
+	if err != store.ErrNoResults {
+		return schema, err
+	}
+
+	schemaPath := "./report.schema"
+	if envSchemaPath, ok := os.LookupEnv("SCHEMA_PATH"); ok {
+		schemaPath = envSchemaPath
+	}
+
+	f, err := os.Open(schemaPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	schema = make(analysis.Schema)
+	err = json.NewDecoder(f).Decode(&schema)
+	return schema, err
+}
+
+func exportBundle(args []string) {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	flags.Usage = usage
+	org := flags.String("org", "", "organization to export reports of")
+	out := flags.String("o", "", "bundle file to write")
+	flags.Parse(args)
+
+	if flags.NArg() != 1 || *org == "" {
+		usage()
+	}
+	eventKey := flags.Arg(0)
+
+	if *out == "" {
+		*out = fmt.Sprintf("%s-%s.bundle.tar.gz", *org, eventKey)
+	}
+
+	s := connect()
+
+	schema, err := getSchema(s, *org)
+	if err != nil {
+		fail("error getting schema: %v", err)
+	}
+
+	b, err := bundle.Export(context.Background(), s, *org, eventKey, schema)
+	if err == store.ErrNoResults {
+		fail("no event '%s'", eventKey)
+	} else if err != nil {
+		fail("error exporting bundle: %v", err)
+	}
+
+	key, err := bundle.Key(context.Background(), s.BundleKey, *org)
+	if err != nil {
+		fail("error getting bundle key: %v", err)
+	}
+
+	f, err := os.OpenFile(*out, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0666)
+	if err != nil {
+		fail("error creating '%s': %v", *out, err)
+	}
+
+	if err := bundle.Write(f, &b, key); err != nil {
+		f.Close()
+		fail("error writing '%s': %v", *out, err)
+	}
+
+	if err := f.Close(); err != nil {
+		fail("error writing '%s': %v", *out, err)
+	}
+
+	fmt.Printf("wrote %d reports on %d matches to %s\n", len(b.Reports), len(b.Matches), *out)
+}
+
+func importBundle(args []string) {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	flags.Usage = usage
+	org := flags.String("org", "", "organization to import reports into")
+	flags.Parse(args)
+
+	if flags.NArg() != 1 || *org == "" {
+		usage()
+	}
+
+	f, err := os.Open(flags.Arg(0))
+	if err != nil {
+		fail("error opening '%s': %v", flags.Arg(0), err)
+	}
+
+	b, err := bundle.Read(f)
+	f.Close()
+	if err != nil {
+		fail("invalid bundle: %v", err)
+	}
+
+	if b.Org == *org {
+		fail("bundle was exported by '%s'", *org)
+	}
+
+	s := connect()
+
+	publicKey, err := bundle.Trusted(context.Background(), s.BundleKey, *org, b.Org)
+	if err == store.ErrNoResults {
+		fail("bundles from '%s' aren't trusted by '%s'", b.Org, *org)
+	} else if err != nil {
+		fail("error getting trusted bundle key: %v", err)
+	}
+
+	if err := b.Verify(publicKey); err != nil {
+		fail("can't import bundle: %v", err)
+	}
+
+	schema, err := getSchema(s, *org)
+	if err != nil {
+		fail("error getting schema: %v", err)
+	}
+
+	result, err := bundle.Import(context.Background(), s, *org, schema, b)
+	if err != nil {
+		fail("error importing bundle: %v", err)
+	}
+
+	fmt.Printf("imported %d reports from %s, %d unchanged, %d conflicts\n", result.Imported, b.Org, result.Unchanged, len(result.Conflicts))
+	for _, c := range result.Conflicts {
+		fmt.Printf("  %s %s by %s: %s\n", c.MatchKey, c.Team, c.Reporter, c.Reason)
+	}
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/Pigmice2733/scouting-backend/internal/analysis"
+	"github.com/Pigmice2733/scouting-backend/internal/export"
+	"github.com/Pigmice2733/scouting-backend/internal/store"
+	"github.com/Pigmice2733/scouting-backend/internal/store/postgres"
+)
+
+const usageFormat = `Usage: %s [flags] eventKey reports|analysis|schedule
+
+Flags:
+`
+
+const envUsage = `
+Environment Variables:
+* PG_USER: postgresql user
+* PG_PASS: postgresql password
+* PG_HOST: postgresql host address
+* PG_PORT: postgresql port (defaults to 5432)
+* PG_DB_NAME: postgresql db name
+* PG_SSL_MODE: postgresql ssl mode
+* SCHEMA_PATH: report schema used if the organization has none (defaults to ./report.schema)
+`
+
+func main() {
+	org := flag.String("org", "", "organization to export data for (required)")
+	format := flag.String("format", export.CSV, "output format, csv or xlsx")
+	out := flag.String("o", "", "output file (defaults to eventKey-table.format)")
+
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, usageFormat, os.Args[0])
+		flag.PrintDefaults()
+		fmt.Fprint(os.Stderr, envUsage)
+	}
+	flag.Parse()
+
+	if flag.NArg() != 2 || *org == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	eventKey, table := flag.Arg(0), flag.Arg(1)
+
+	if _, ok := export.ContentTypes[*format]; !ok {
+		fmt.Printf("unknown format '%s'\n", *format)
+		os.Exit(2)
+	}
+
+	port, err := strconv.Atoi(os.Getenv("PG_PORT"))
+	if err != nil {
+		port = 5432
+	}
+
+	s, err := postgres.NewFromOptions(postgres.Options{
+		User:             os.Getenv("PG_USER"),
+		Pass:             os.Getenv("PG_PASS"),
+		Host:             os.Getenv("PG_HOST"),
+		Port:             port,
+		DBName:           os.Getenv("PG_DB_NAME"),
+		SSLMode:          os.Getenv("PG_SSL_MODE"),
+		StatementTimeout: 5000,
+	})
+	if err != nil {
+		fmt.Printf("unable to connect to postgres server: %v\n", err)
+		os.Exit(1)
+	}
+
+	schema, err := getSchema(s, *org)
+	if err != nil {
+		fmt.Printf("error getting schema: %v\n", err)
+		os.Exit(1)
+	}
+
+	t, err := export.Build(context.Background(), table, *org, eventKey, schema, s)
+	if err != nil {
+		fmt.Printf("error building export: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *out == "" {
+		*out = fmt.Sprintf("%s-%s.%s", eventKey, table, *format)
+	}
+
+	f, err := os.OpenFile(*out, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0666)
+	if err != nil {
+		fmt.Printf("error creating '%s': %v\n", *out, err)
+		os.Exit(1)
+	}
+
+	if err := export.Write(f, *format, t); err != nil {
+		f.Close()
+		fmt.Printf("error writing '%s': %v\n", *out, err)
+		os.Exit(1)
+	}
+
+	if err := f.Close(); err != nil {
+		fmt.Printf("error writing '%s': %v\n", *out, err)
+		os.Exit(1)
+	}
+}
+
+// getSchema gets the report schema of an organization, or the schema at
+// SCHEMA_PATH if it doesn't have one.
+func getSchema(s *store.Service, org string) (analysis.Schema, error) {
+	schema, err := s.Schema.Get(context.Background(), org)
+	if err != store.ErrNoResults {
+		return schema, err
+	}
+
+	schemaPath := "./report.schema"
+	if envSchemaPath, ok := os.LookupEnv("SCHEMA_PATH"); ok {
+		schemaPath = envSchemaPath
+	}
+
+	f, err := os.Open(schemaPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	schema = make(analysis.Schema)
+	err = json.NewDecoder(f).Decode(&schema)
+	return schema, err
+}
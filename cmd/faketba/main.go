@@ -0,0 +1,84 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/Pigmice2733/scouting-backend/internal/tba/fake"
+)
+
+const tbaURL = "https://www.thebluealliance.com/api/v3"
+
+const usageFormat = `Usage:
+  %[1]s serve [-addr addr] [-latency duration] dir
+  %[1]s record dir path...
+
+serve runs a fake TBA API that serves the fixtures in a directory, so the
+server can be run against it by setting TBA_URL. record requests paths (like
+/events/2018) from the TBA API and saves the responses as fixtures in a
+directory.
+
+Environment Variables:
+* TBA_API_KEY: the blue alliance api key, for record
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	switch os.Args[1] {
+	case "serve":
+		serve(os.Args[2:])
+	case "record":
+		record(os.Args[2:])
+	default:
+		usage()
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, usageFormat, os.Args[0])
+	os.Exit(2)
+}
+
+func fail(format string, args ...interface{}) {
+	fmt.Printf(format+"\n", args...)
+	os.Exit(1)
+}
+
+func serve(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", ":8081", "address to listen on")
+	latency := fs.Duration("latency", 0, "time to wait before every response")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		usage()
+	}
+
+	s, err := fake.Load(fs.Arg(0))
+	if err != nil {
+		fail("error loading fixtures: %v", err)
+	}
+	s.SetLatency(*latency)
+
+	fmt.Printf("serving fixtures in %s at %s\n", fs.Arg(0), *addr)
+	if err := http.ListenAndServe(*addr, s); err != nil {
+		fail("error serving: %v", err)
+	}
+}
+
+func record(args []string) {
+	if len(args) < 2 {
+		usage()
+	}
+
+	client := &http.Client{Timeout: time.Second * 10}
+	if err := fake.Record(client, tbaURL, os.Getenv("TBA_API_KEY"), args[1:], args[0]); err != nil {
+		fail("error recording fixtures: %v", err)
+	}
+}
@@ -6,14 +6,24 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/Pigmice2733/scouting-backend/internal/jwtkeys"
 	"github.com/Pigmice2733/scouting-backend/internal/server"
 	"github.com/Pigmice2733/scouting-backend/internal/store/postgres"
+	"github.com/Pigmice2733/scouting-backend/internal/tba"
 	"github.com/Pigmice2733/scouting-backend/internal/tba/api"
+	"github.com/Pigmice2733/scouting-backend/internal/tba/client"
+	"github.com/Pigmice2733/scouting-backend/internal/tba/frc"
+	"github.com/Pigmice2733/scouting-backend/internal/tba/merge"
 )
 
-const tbaURL = "http://www.thebluealliance.com/api/v3"
+const defaultTBAURL = "http://www.thebluealliance.com/api/v3"
 
 func main() {
+	tbaURL := defaultTBAURL
+	if envTBAURL, ok := os.LookupEnv("TBA_URL"); ok {
+		tbaURL = envTBAURL
+	}
+
 	port, err := strconv.Atoi(os.Getenv("PG_PORT"))
 	if err != nil {
 		port = 5432
@@ -34,7 +44,38 @@ func main() {
 		os.Exit(1)
 	}
 
-	consumer := api.New(tbaURL, os.Getenv("TBA_API_KEY"))
+	clientOptions := client.DefaultOptions
+	if envTimeout, ok := os.LookupEnv("UPSTREAM_TIMEOUT"); ok {
+		if parsedTimeout, err := time.ParseDuration(envTimeout); err == nil {
+			clientOptions.Timeout = parsedTimeout
+		}
+	}
+	if envRetries, ok := os.LookupEnv("UPSTREAM_RETRIES"); ok {
+		if parsedRetries, err := strconv.Atoi(envRetries); err == nil {
+			clientOptions.Retries = parsedRetries
+		}
+	}
+	if envRateLimit, ok := os.LookupEnv("UPSTREAM_RATE_LIMIT"); ok {
+		if parsedRateLimit, err := strconv.ParseFloat(envRateLimit, 64); err == nil {
+			clientOptions.Rate = parsedRateLimit
+		}
+	}
+
+	var consumer tba.Consumer
+	switch source := os.Getenv("DATA_SOURCE"); source {
+	case "", "tba":
+		consumer = api.New(tbaURL, os.Getenv("TBA_API_KEY"), client.New(clientOptions))
+	case "frc":
+		consumer = frc.New(frc.URL, os.Getenv("FRC_API_USER"), os.Getenv("FRC_API_KEY"), client.New(clientOptions))
+	case "merge":
+		consumer = merge.New(
+			api.New(tbaURL, os.Getenv("TBA_API_KEY"), client.New(clientOptions)),
+			frc.New(frc.URL, os.Getenv("FRC_API_USER"), os.Getenv("FRC_API_KEY"), client.New(clientOptions)),
+		)
+	default:
+		fmt.Printf("unknown DATA_SOURCE: %s\n", source)
+		os.Exit(1)
+	}
 
 	schemaPath := "./report.schema"
 	if envSchemaPath, ok := os.LookupEnv("SCHEMA_PATH"); ok {
@@ -53,9 +94,59 @@ func main() {
 		}
 	}
 
-	server, err := server.New(
-		store, consumer, os.Stdout, year, origin, schemaPath,
-		os.Getenv("CERT_FILE"), os.Getenv("KEY_FILE"))
+	photoCacheDir := "./photo-cache"
+	if envPhotoCacheDir, ok := os.LookupEnv("PHOTO_CACHE_DIR"); ok {
+		photoCacheDir = envPhotoCacheDir
+	}
+
+	photoCacheSize := int64(100)
+	if envPhotoCacheSize, ok := os.LookupEnv("PHOTO_CACHE_SIZE"); ok {
+		if parsedPhotoCacheSize, err := strconv.ParseInt(envPhotoCacheSize, 10, 64); err == nil {
+			photoCacheSize = parsedPhotoCacheSize
+		}
+	}
+
+	jwtAlgorithm := jwtkeys.HS256
+	if envJWTAlgorithm, ok := os.LookupEnv("JWT_ALGORITHM"); ok {
+		jwtAlgorithm = envJWTAlgorithm
+	}
+
+	jwtKeyRotation := 30
+	if envJWTKeyRotation, ok := os.LookupEnv("JWT_KEY_ROTATION"); ok {
+		if parsedJWTKeyRotation, err := strconv.Atoi(envJWTKeyRotation); err == nil {
+			jwtKeyRotation = parsedJWTKeyRotation
+		}
+	}
+
+	requestTimeout := 0
+	if envRequestTimeout, ok := os.LookupEnv("REQUEST_TIMEOUT"); ok {
+		if parsedRequestTimeout, err := strconv.Atoi(envRequestTimeout); err == nil {
+			requestTimeout = parsedRequestTimeout
+		}
+	}
+
+	offline := false
+	if envOffline, ok := os.LookupEnv("OFFLINE"); ok {
+		if parsedOffline, err := strconv.ParseBool(envOffline); err == nil {
+			offline = parsedOffline
+		}
+	}
+
+	server, err := server.New(store, consumer, os.Stdout, server.Options{
+		Year:            year,
+		Origin:          origin,
+		SchemaPath:      schemaPath,
+		CertFile:        os.Getenv("CERT_FILE"),
+		KeyFile:         os.Getenv("KEY_FILE"),
+		PhotoCacheDir:   photoCacheDir,
+		PhotoCacheSize:  photoCacheSize * 1000000,
+		JWTKeysFile:     os.Getenv("JWT_KEYS_FILE"),
+		JWTAlgorithm:    jwtAlgorithm,
+		JWTKeyRotation:  time.Duration(jwtKeyRotation) * time.Hour * 24,
+		RequestTimeout:  time.Duration(requestTimeout) * time.Second,
+		Offline:         offline,
+		OfflineSchedule: os.Getenv("OFFLINE_SCHEDULE"),
+	})
 	if err != nil {
 		fmt.Printf("unable to create server: %v\n", err)
 		os.Exit(1)
@@ -10,6 +10,17 @@ var ErrUnsupportedSchemaType = fmt.Errorf("analysis: unsupported schema type")
 // Types supported are: "number", and "bool".
 type Schema map[string]string
 
+// Validate returns ErrUnsupportedSchemaType if the schema has a field with an
+// unsupported type.
+func (s Schema) Validate() error {
+	for _, v := range s {
+		if v != "number" && v != "bool" {
+			return ErrUnsupportedSchemaType
+		}
+	}
+	return nil
+}
+
 // Data provides a type for data, a map of key names to their values.
 type Data map[string]interface{}
 
@@ -98,3 +109,43 @@ func Average(schema Schema, data ...Data) (Results, error) {
 
 	return results, nil
 }
+
+// WeightedAverage averages all "number" and "bool" fields like Average, but
+// each datum counts as much as its weight. There must be a weight for every
+// datum.
+func WeightedAverage(schema Schema, data []Data, weights []float64) (Results, error) {
+	results := make(Results)
+
+	var total float64
+	for _, w := range weights {
+		total += w
+	}
+
+	for k, v := range schema {
+		if v != "number" && v != "bool" {
+			return results, ErrUnsupportedSchemaType
+		}
+
+		var sum float64
+		for i, datum := range data {
+			switch value := datum[k].(type) {
+			case int:
+				if v == "number" {
+					sum += weights[i] * float64(value)
+				}
+			case float64:
+				if v == "number" {
+					sum += weights[i] * value
+				}
+			case bool:
+				if v == "bool" {
+					sum += weights[i] * float64(btoi(value))
+				}
+			}
+		}
+
+		results[k] = sum / total
+	}
+
+	return results, nil
+}
@@ -6,6 +6,12 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func TestSchemaValidate(t *testing.T) {
+	assert.NoError(t, Schema{"climbed": "bool", "cubes": "number"}.Validate())
+	assert.NoError(t, Schema{}.Validate())
+	assert.Equal(t, ErrUnsupportedSchemaType, Schema{"climbed": "bool", "notes": "string"}.Validate())
+}
+
 func TestCompliantData(t *testing.T) {
 	testCases := []struct {
 		schema    Schema
@@ -98,3 +104,27 @@ func TestAverage(t *testing.T) {
 		assert.Equal(t, tt.results, results)
 	}
 }
+
+func TestWeightedAverage(t *testing.T) {
+	schema := Schema{"climbed": "bool", "cubes": "number"}
+	data := []Data{
+		{"climbed": true, "cubes": 6},
+		{"climbed": false, "cubes": 2.0},
+		{"cubes": 4},
+	}
+
+	results, err := WeightedAverage(schema, data, []float64{1, 1, 1})
+	assert.NoError(t, err)
+	expected, err := Average(schema, data...)
+	assert.NoError(t, err)
+	assert.InDelta(t, expected["climbed"], results["climbed"], 1e-9)
+	assert.InDelta(t, expected["cubes"], results["cubes"], 1e-9)
+
+	results, err = WeightedAverage(schema, data, []float64{2, 1, 1})
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.5, results["climbed"], 1e-9)
+	assert.InDelta(t, 4.5, results["cubes"], 1e-9)
+
+	_, err = WeightedAverage(Schema{"notes": "string"}, data, []float64{1, 1, 1})
+	assert.Equal(t, ErrUnsupportedSchemaType, err)
+}
@@ -0,0 +1,170 @@
+package analysis
+
+import "math"
+
+// Summary holds the mean and sample variance of a field across the reports
+// that have it.
+type Summary struct {
+	Mean     float64 `json:"mean"`
+	Variance float64 `json:"variance"`
+	Count    int     `json:"count"`
+}
+
+// Summarize finds the mean and variance of all "number" and "bool" fields. True
+// is considered 1, and false is considered zero. Unlike Average, reports that
+// are missing a field are left out of its summary.
+func Summarize(schema Schema, data ...Data) (map[string]Summary, error) {
+	summaries := make(map[string]Summary)
+
+	for k, v := range schema {
+		if v != "number" && v != "bool" {
+			return summaries, ErrUnsupportedSchemaType
+		}
+
+		var values []float64
+
+		for _, datum := range data {
+			val, ok := datum[k]
+			if !ok {
+				continue
+			}
+
+			switch value := val.(type) {
+			case int:
+				if v == "number" {
+					values = append(values, float64(value))
+				}
+			case float64:
+				if v == "number" {
+					values = append(values, value)
+				}
+			case bool:
+				if v == "bool" {
+					values = append(values, float64(btoi(value)))
+				}
+			}
+		}
+
+		summaries[k] = summarize(values)
+	}
+
+	return summaries, nil
+}
+
+func summarize(values []float64) Summary {
+	s := Summary{Count: len(values)}
+	if s.Count == 0 {
+		return s
+	}
+
+	for _, v := range values {
+		s.Mean += v
+	}
+	s.Mean /= float64(s.Count)
+
+	if s.Count < 2 {
+		return s
+	}
+
+	for _, v := range values {
+		s.Variance += (v - s.Mean) * (v - s.Mean)
+	}
+	s.Variance /= float64(s.Count - 1)
+
+	return s
+}
+
+// TTest holds the results of a two sided t-test.
+type TTest struct {
+	T      float64 `json:"t"`
+	DF     float64 `json:"df"`
+	PValue float64 `json:"pValue"`
+}
+
+// WelchTTest tests whether the means of two summaries differ, without assuming
+// their variances are equal. False is returned if either summary has fewer
+// than two values, since their variance isn't known.
+func WelchTTest(a, b Summary) (TTest, bool) {
+	if a.Count < 2 || b.Count < 2 {
+		return TTest{}, false
+	}
+
+	va, vb := a.Variance/float64(a.Count), b.Variance/float64(b.Count)
+	se := math.Sqrt(va + vb)
+
+	// with no variance at all any difference between the means is certain
+	if se == 0 {
+		if a.Mean == b.Mean {
+			return TTest{PValue: 1}, true
+		}
+		return TTest{T: math.Copysign(math.Inf(1), a.Mean-b.Mean), PValue: 0}, true
+	}
+
+	t := (a.Mean - b.Mean) / se
+	df := (va + vb) * (va + vb) / (va*va/float64(a.Count-1) + vb*vb/float64(b.Count-1))
+
+	return TTest{T: t, DF: df, PValue: incompleteBeta(df/(df+t*t), df/2, 0.5)}, true
+}
+
+// incompleteBeta is the regularized incomplete beta function I_x(a, b). The
+// two sided p-value of a t statistic with df degrees of freedom is
+// I_{df/(df+t^2)}(df/2, 1/2).
+func incompleteBeta(x, a, b float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	lab, _ := math.Lgamma(a + b)
+	front := math.Exp(a*math.Log(x) + b*math.Log(1-x) - la - lb + lab)
+
+	// the continued fraction converges quickly only below this point, so use
+	// the symmetry I_x(a, b) = 1 - I_{1-x}(b, a) above it
+	if x < (a+1)/(a+b+2) {
+		return front * betaContinuedFraction(x, a, b) / a
+	}
+	return 1 - front*betaContinuedFraction(1-x, b, a)/b
+}
+
+// betaContinuedFraction evaluates the continued fraction for the incomplete
+// beta function with Lentz's method.
+func betaContinuedFraction(x, a, b float64) float64 {
+	const (
+		maxIterations = 300
+		epsilon       = 3e-14
+		tiny          = 1e-300
+	)
+
+	clamp := func(v float64) float64 {
+		if math.Abs(v) < tiny {
+			return tiny
+		}
+		return v
+	}
+
+	c, d := 1.0, 1/clamp(1-(a+b)*x/(a+1))
+	h := d
+
+	for m := 1.0; m <= maxIterations; m++ {
+		even := m * (b - m) * x / ((a + 2*m - 1) * (a + 2*m))
+		d = 1 / clamp(1+even*d)
+		c = clamp(1 + even/c)
+		h *= d * c
+
+		odd := -(a + m) * (a + b + m) * x / ((a + 2*m) * (a + 2*m + 1))
+		d = 1 / clamp(1+odd*d)
+		c = clamp(1 + odd/c)
+		delta := d * c
+		h *= delta
+
+		if math.Abs(delta-1) < epsilon {
+			break
+		}
+	}
+
+	return h
+}
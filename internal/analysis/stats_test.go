@@ -0,0 +1,73 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarize(t *testing.T) {
+	schema := Schema{"climbed": "bool", "cubes": "number"}
+
+	summaries, err := Summarize(schema,
+		Data{"climbed": true, "cubes": 4},
+		Data{"climbed": false, "cubes": 6.0},
+		Data{"climbed": true},
+	)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 3, summaries["climbed"].Count)
+	assert.InDelta(t, 2.0/3, summaries["climbed"].Mean, 1e-9)
+	assert.InDelta(t, 1.0/3, summaries["climbed"].Variance, 1e-9)
+
+	assert.Equal(t, Summary{Mean: 5, Variance: 2, Count: 2}, summaries["cubes"])
+
+	summaries, err = Summarize(schema)
+	assert.NoError(t, err)
+	assert.Equal(t, Summary{}, summaries["cubes"])
+
+	_, err = Summarize(Schema{"notes": "string"}, Data{"notes": "fast"})
+	assert.Equal(t, ErrUnsupportedSchemaType, err)
+}
+
+func TestWelchTTest(t *testing.T) {
+	// the first example from the Wikipedia article on Welch's t-test
+	a := summarize([]float64{27.5, 21.0, 19.0, 23.6, 17.0, 17.9, 16.9, 20.1, 21.9, 22.6, 23.1, 19.6, 19.0, 21.7, 21.4})
+	b := summarize([]float64{27.1, 22.0, 20.8, 23.4, 23.4, 23.5, 25.8, 22.0, 24.8, 20.2, 21.9, 22.1, 22.9, 20.5, 24.4})
+
+	test, ok := WelchTTest(a, b)
+	assert.True(t, ok)
+	assert.InDelta(t, -2.46, test.T, 0.01)
+	assert.InDelta(t, 24.99, test.DF, 0.01)
+	assert.InDelta(t, 0.021, test.PValue, 0.001)
+
+	// the same sample can't be told apart from itself
+	test, ok = WelchTTest(a, a)
+	assert.True(t, ok)
+	assert.InDelta(t, 1, test.PValue, 1e-9)
+
+	_, ok = WelchTTest(Summary{Mean: 1, Count: 1}, b)
+	assert.False(t, ok)
+
+	test, ok = WelchTTest(Summary{Mean: 1, Count: 3}, Summary{Mean: 0, Count: 3})
+	assert.True(t, ok)
+	assert.True(t, math.IsInf(test.T, 1))
+	assert.Equal(t, 0.0, test.PValue)
+}
+
+func TestIncompleteBeta(t *testing.T) {
+	// two sided p-values of well known t critical values
+	for _, tc := range []struct{ t, df, p float64 }{
+		{2.228, 10, 0.05},
+		{1.96, 100000, 0.05},
+		{2.576, 100000, 0.01},
+		{0, 5, 1},
+	} {
+		assert.InDelta(t, tc.p, incompleteBeta(tc.df/(tc.df+tc.t*tc.t), tc.df/2, 0.5), 0.001)
+	}
+
+	assert.Equal(t, 0.0, incompleteBeta(0, 2, 3))
+	assert.Equal(t, 1.0, incompleteBeta(1, 2, 3))
+	assert.InDelta(t, 0.6875, incompleteBeta(0.5, 2, 3), 1e-9)
+}
@@ -0,0 +1,282 @@
+// Package archive backs up the data of a store into a versioned archive, and
+// restores archives into a store.
+//
+// An archive is a gzipped tar file with a manifest.json describing the archive,
+// and a JSON file holding an array of records for each kind of data. Everything
+// is read and written through store.Service, so archives can move data between
+// store implementations.
+package archive
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"time"
+
+	"github.com/Pigmice2733/scouting-backend/internal/analysis"
+	"github.com/Pigmice2733/scouting-backend/internal/store/event"
+	"github.com/Pigmice2733/scouting-backend/internal/store/match"
+	"github.com/Pigmice2733/scouting-backend/internal/store/organization"
+	"github.com/Pigmice2733/scouting-backend/internal/store/picklist"
+	"github.com/Pigmice2733/scouting-backend/internal/store/report"
+	"github.com/Pigmice2733/scouting-backend/internal/store/role"
+	"github.com/Pigmice2733/scouting-backend/internal/store/sharing"
+	"github.com/Pigmice2733/scouting-backend/internal/store/tag"
+	"github.com/Pigmice2733/scouting-backend/internal/store/user"
+)
+
+// FormatVersion is the version of the archive layout written by this package.
+// Archives with a newer format version can't be read.
+const FormatVersion = 1
+
+const manifestName = "manifest.json"
+
+// Scope limits which events are backed up. Events, and the matches, reports,
+// picklists, report tags and sharing agreements belonging to them, are only
+// archived if they are in the scope. Organizations, roles, users and schemas
+// are always archived, since the rest of the data depends on them.
+type Scope struct {
+	EventKey string `json:"eventKey,omitempty"`
+	Year     int    `json:"year,omitempty"`
+}
+
+// Includes returns whether an event is in a scope.
+func (s Scope) Includes(e event.BasicEvent) bool {
+	return (s.EventKey == "" || s.EventKey == e.Key) && (s.Year == 0 || s.Year == e.Date.Year())
+}
+
+// File describes one of the files of an archive.
+type File struct {
+	Records int    `json:"records"`
+	SHA256  string `json:"sha256"`
+}
+
+// Manifest describes the contents of an archive.
+type Manifest struct {
+	FormatVersion int             `json:"formatVersion"`
+	SchemaVersion int             `json:"schemaVersion"`
+	Created       time.Time       `json:"created"`
+	Scope         Scope           `json:"scope"`
+	Files         map[string]File `json:"files"`
+}
+
+// Compatible returns an error if data from an archive can't be restored into a
+// store with a certain schema version, because the archive was made by a newer
+// version of the server.
+func (m Manifest) Compatible(schemaVersion int) error {
+	if m.SchemaVersion > schemaVersion {
+		return fmt.Errorf("archive has schema version %d, newer than %d", m.SchemaVersion, schemaVersion)
+	}
+	return nil
+}
+
+// Schema is the report schema of an organization.
+type Schema struct {
+	Org    string          `json:"org"`
+	Schema analysis.Schema `json:"schema"`
+}
+
+// Tags are the report tags of an organization for a season.
+type Tags struct {
+	Org  string    `json:"org"`
+	Year int       `json:"year"`
+	Tags []tag.Tag `json:"tags"`
+}
+
+// Match is a match along with the key of its event, which match.Match leaves
+// out of its JSON.
+type Match struct {
+	EventKey string `json:"eventKey"`
+	match.Match
+}
+
+// Picklist is a picklist along with who it is shared with.
+type Picklist struct {
+	picklist.Picklist
+	Shares []picklist.Share `json:"shares"`
+}
+
+// Archive holds all of the data of an archive.
+type Archive struct {
+	Manifest          Manifest
+	Organizations     []organization.Organization
+	Roles             []role.Role
+	Users             []user.User
+	Schemas           []Schema
+	Tags              []Tags
+	Events            []event.BasicEvent
+	Matches           []Match
+	Reports           []report.Report
+	Picklists         []Picklist
+	SharingAgreements []sharing.Agreement
+}
+
+// files gets the name of each file of an archive along with a pointer to the
+// records it holds, in the order they should be restored.
+func (a *Archive) files() []struct {
+	name    string
+	records interface{}
+} {
+	return []struct {
+		name    string
+		records interface{}
+	}{
+		{"organizations.json", &a.Organizations},
+		{"roles.json", &a.Roles},
+		{"users.json", &a.Users},
+		{"schemas.json", &a.Schemas},
+		{"tags.json", &a.Tags},
+		{"events.json", &a.Events},
+		{"matches.json", &a.Matches},
+		{"reports.json", &a.Reports},
+		{"picklists.json", &a.Picklists},
+		{"sharing.json", &a.SharingAgreements},
+	}
+}
+
+// Write writes an archive, filling in the files of its manifest.
+func Write(w io.Writer, a *Archive) error {
+	a.Manifest.FormatVersion = FormatVersion
+	a.Manifest.Files = make(map[string]File)
+
+	contents := make(map[string][]byte)
+	for _, f := range a.files() {
+		content, err := json.Marshal(f.records)
+		if err != nil {
+			return fmt.Errorf("encoding %s: %v", f.name, err)
+		}
+
+		sum := sha256.Sum256(content)
+		a.Manifest.Files[f.name] = File{
+			Records: reflect.ValueOf(f.records).Elem().Len(),
+			SHA256:  hex.EncodeToString(sum[:]),
+		}
+		contents[f.name] = content
+	}
+
+	manifest, err := json.MarshalIndent(a.Manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding manifest: %v", err)
+	}
+
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	writeFile := func(name string, content []byte) error {
+		if err := tw.WriteHeader(&tar.Header{
+			Name:    name,
+			Mode:    0644,
+			Size:    int64(len(content)),
+			ModTime: a.Manifest.Created,
+		}); err != nil {
+			return err
+		}
+		_, err := tw.Write(content)
+		return err
+	}
+
+	// the manifest is first so that it can be checked before reading the rest
+	if err := writeFile(manifestName, manifest); err != nil {
+		return err
+	}
+	for _, f := range a.files() {
+		if err := writeFile(f.name, contents[f.name]); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+// Read reads an archive and validates it against its manifest. An error is
+// returned if the archive has a newer format version, is missing files or has
+// unexpected ones, or if any file doesn't match its checksum or record count.
+func Read(r io.Reader) (Archive, error) {
+	var a Archive
+
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return a, fmt.Errorf("decompressing archive: %v", err)
+	}
+	defer gr.Close()
+
+	contents := make(map[string][]byte)
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return a, fmt.Errorf("reading archive: %v", err)
+		}
+
+		if _, ok := contents[hdr.Name]; ok {
+			return a, fmt.Errorf("archive has '%s' more than once", hdr.Name)
+		}
+
+		if contents[hdr.Name], err = ioutil.ReadAll(tr); err != nil {
+			return a, fmt.Errorf("reading '%s': %v", hdr.Name, err)
+		}
+	}
+
+	manifest, ok := contents[manifestName]
+	if !ok {
+		return a, fmt.Errorf("archive has no manifest")
+	}
+	if err := json.Unmarshal(manifest, &a.Manifest); err != nil {
+		return a, fmt.Errorf("decoding manifest: %v", err)
+	}
+
+	if a.Manifest.FormatVersion < 1 || a.Manifest.FormatVersion > FormatVersion {
+		return a, fmt.Errorf("unsupported archive format version %d", a.Manifest.FormatVersion)
+	}
+
+	known := map[string]bool{manifestName: true}
+	for _, f := range a.files() {
+		known[f.name] = true
+	}
+	for name := range contents {
+		if !known[name] {
+			return a, fmt.Errorf("unexpected file '%s'", name)
+		}
+	}
+
+	for _, f := range a.files() {
+		info, ok := a.Manifest.Files[f.name]
+		if !ok {
+			return a, fmt.Errorf("manifest is missing '%s'", f.name)
+		}
+
+		content, ok := contents[f.name]
+		if !ok {
+			return a, fmt.Errorf("archive is missing '%s'", f.name)
+		}
+
+		sum := sha256.Sum256(content)
+		if hex.EncodeToString(sum[:]) != info.SHA256 {
+			return a, fmt.Errorf("'%s' doesn't match its checksum", f.name)
+		}
+
+		d := json.NewDecoder(bytes.NewReader(content))
+		d.DisallowUnknownFields()
+		if err := d.Decode(f.records); err != nil {
+			return a, fmt.Errorf("decoding '%s': %v", f.name, err)
+		}
+
+		if n := reflect.ValueOf(f.records).Elem().Len(); n != info.Records {
+			return a, fmt.Errorf("'%s' has %d records, but the manifest says %d", f.name, n, info.Records)
+		}
+	}
+
+	return a, nil
+}
@@ -0,0 +1,173 @@
+package archive
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Pigmice2733/scouting-backend/internal/analysis"
+	"github.com/Pigmice2733/scouting-backend/internal/store/event"
+	"github.com/Pigmice2733/scouting-backend/internal/store/match"
+	"github.com/Pigmice2733/scouting-backend/internal/store/organization"
+	"github.com/Pigmice2733/scouting-backend/internal/store/picklist"
+	"github.com/Pigmice2733/scouting-backend/internal/store/report"
+	"github.com/Pigmice2733/scouting-backend/internal/store/tag"
+	"github.com/Pigmice2733/scouting-backend/internal/store/user"
+	"github.com/stretchr/testify/assert"
+)
+
+func testArchive() Archive {
+	date := time.Date(2018, 3, 1, 0, 0, 0, 0, time.UTC)
+	notes := "fast"
+
+	return Archive{
+		Manifest:      Manifest{SchemaVersion: 27, Created: date, Scope: Scope{Year: 2018}},
+		Organizations: []organization.Organization{{ID: "frc2733", Name: "Pigmice", Created: date}},
+		Users:         []user.User{{Org: "frc2733", Username: "abby", HashedPassword: "hash", IsVerified: true, Roles: []string{"scout"}}},
+		Schemas:       []Schema{{Org: "frc2733", Schema: analysis.Schema{"climbed": "bool"}}},
+		Tags:          []Tags{{Org: "frc2733", Year: 2018, Tags: tag.Defaults}},
+		Events:        []event.BasicEvent{{Key: "2018orwil", Name: "Wilsonville", Date: date, EndDate: date}},
+		Matches: []Match{{EventKey: "2018orwil", Match: match.Match{
+			BasicMatch:   match.BasicMatch{Key: "2018orwil_qm1", PredictedTime: &date},
+			RedAlliance:  []string{"frc1", "frc2", "frc3"},
+			BlueAlliance: []string{"frc4", "frc5", "frc6"},
+		}}},
+		Reports: []report.Report{{Org: "frc2733", Reporter: "abby", EventKey: "2018orwil", MatchKey: "2018orwil_qm1", Team: "frc1", Notes: &notes, Tags: []string{"tipped"}, Stats: map[string]interface{}{"climbed": true}}},
+		Picklists: []Picklist{{
+			Picklist: picklist.Picklist{BasicPicklist: picklist.BasicPicklist{ID: "id", EventKey: "2018orwil", Name: "first"}, List: []picklist.Pick{{Team: "frc1", Tier: 1}}, Owner: "abby", Org: "frc2733", Version: 2},
+			Shares:   []picklist.Share{{Role: "scout", Permission: picklist.Read}},
+		}},
+	}
+}
+
+func TestWriteRead(t *testing.T) {
+	a := testArchive()
+
+	var b bytes.Buffer
+	if !assert.Nil(t, Write(&b, &a)) {
+		return
+	}
+
+	assert.Equal(t, FormatVersion, a.Manifest.FormatVersion)
+	assert.Equal(t, 1, a.Manifest.Files["reports.json"].Records)
+	assert.Equal(t, 0, a.Manifest.Files["sharing.json"].Records)
+	assert.Len(t, a.Manifest.Files, 10)
+
+	read, err := Read(&b)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	// empty lists are read back as nil
+	a.Roles = nil
+	a.SharingAgreements = nil
+
+	assert.Equal(t, a, read)
+}
+
+// rewrite writes an archive, then lets the files of the archive be changed
+// before they are written again.
+func rewrite(t *testing.T, change func(files map[string][]byte)) []byte {
+	a := testArchive()
+
+	var b bytes.Buffer
+	if !assert.Nil(t, Write(&b, &a)) {
+		t.FailNow()
+	}
+
+	gr, err := gzip.NewReader(&b)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	files := make(map[string][]byte)
+	var names []string
+	tr := tar.NewReader(gr)
+	for hdr, err := tr.Next(); err == nil; hdr, err = tr.Next() {
+		var content bytes.Buffer
+		content.ReadFrom(tr)
+		files[hdr.Name] = content.Bytes()
+		names = append(names, hdr.Name)
+	}
+
+	change(files)
+
+	var out bytes.Buffer
+	gw := gzip.NewWriter(&out)
+	tw := tar.NewWriter(gw)
+	for _, name := range append(names, "extra.json") {
+		content, ok := files[name]
+		if !ok {
+			continue
+		}
+		tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content))})
+		tw.Write(content)
+	}
+	tw.Close()
+	gw.Close()
+
+	return out.Bytes()
+}
+
+func TestReadInvalid(t *testing.T) {
+	cases := map[string]func(files map[string][]byte){
+		"checksum": func(files map[string][]byte) {
+			files["reports.json"] = []byte(`[]`)
+		},
+		"missing file": func(files map[string][]byte) {
+			delete(files, "users.json")
+		},
+		"missing manifest": func(files map[string][]byte) {
+			delete(files, "manifest.json")
+		},
+		"unexpected file": func(files map[string][]byte) {
+			files["extra.json"] = []byte(`[]`)
+		},
+		"newer format": func(files map[string][]byte) {
+			var m Manifest
+			json.Unmarshal(files["manifest.json"], &m)
+			m.FormatVersion = FormatVersion + 1
+			files["manifest.json"], _ = json.Marshal(m)
+		},
+		"record count": func(files map[string][]byte) {
+			var m Manifest
+			json.Unmarshal(files["manifest.json"], &m)
+			f := m.Files["reports.json"]
+			f.Records = 2
+			m.Files["reports.json"] = f
+			files["manifest.json"], _ = json.Marshal(m)
+		},
+	}
+
+	for name, change := range cases {
+		_, err := Read(bytes.NewReader(rewrite(t, change)))
+		assert.NotNil(t, err, name)
+	}
+
+	_, err := Read(bytes.NewReader(rewrite(t, func(map[string][]byte) {})))
+	assert.Nil(t, err)
+
+	_, err = Read(bytes.NewReader([]byte("not an archive")))
+	assert.NotNil(t, err)
+}
+
+func TestScopeIncludes(t *testing.T) {
+	e := event.BasicEvent{Key: "2018orwil", Date: time.Date(2018, 3, 1, 0, 0, 0, 0, time.UTC)}
+
+	assert.True(t, Scope{}.Includes(e))
+	assert.True(t, Scope{EventKey: "2018orwil"}.Includes(e))
+	assert.True(t, Scope{Year: 2018}.Includes(e))
+	assert.False(t, Scope{EventKey: "2018orore"}.Includes(e))
+	assert.False(t, Scope{Year: 2017}.Includes(e))
+}
+
+func TestCompatible(t *testing.T) {
+	m := Manifest{SchemaVersion: 27}
+
+	assert.Nil(t, m.Compatible(27))
+	assert.Nil(t, m.Compatible(30))
+	assert.NotNil(t, m.Compatible(26))
+}
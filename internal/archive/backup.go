@@ -0,0 +1,142 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Pigmice2733/scouting-backend/internal/store"
+)
+
+// Backup reads the data in a scope from a store into an archive. Sessions,
+// signing keys, photos and picklist revisions aren't archived.
+func Backup(ctx context.Context, s *store.Service, scope Scope, schemaVersion int) (Archive, error) {
+	a := Archive{Manifest: Manifest{
+		SchemaVersion: schemaVersion,
+		Created:       time.Now().UTC(),
+		Scope:         scope,
+	}}
+
+	var err error
+
+	if a.Organizations, err = s.Org.GetAll(ctx); err != nil {
+		return a, fmt.Errorf("getting organizations: %v", err)
+	}
+
+	if a.Roles, err = s.Role.GetRoles(ctx); err != nil {
+		return a, fmt.Errorf("getting roles: %v", err)
+	}
+
+	events, err := s.Event.GetBasicEvents(ctx)
+	if err != nil {
+		return a, fmt.Errorf("getting events: %v", err)
+	}
+
+	years := make(map[int]bool)
+	if scope.Year != 0 {
+		years[scope.Year] = true
+	}
+
+	inScope := make(map[string]bool)
+	for _, e := range events {
+		if !scope.Includes(e) {
+			continue
+		}
+
+		a.Events = append(a.Events, e)
+		inScope[e.Key] = true
+		years[e.Date.Year()] = true
+
+		bMatches, err := s.Match.GetBasicMatches(ctx, e.Key)
+		if err != nil {
+			return a, fmt.Errorf("getting matches of '%s': %v", e.Key, err)
+		}
+
+		for _, bm := range bMatches {
+			m, err := s.Match.Get(ctx, e.Key, bm.Key, s.Alliance)
+			if err != nil {
+				return a, fmt.Errorf("getting match '%s': %v", bm.Key, err)
+			}
+			a.Matches = append(a.Matches, Match{EventKey: e.Key, Match: m})
+		}
+	}
+
+	seenAgreements := make(map[string]bool)
+
+	for _, o := range a.Organizations {
+		users, err := s.User.GetUsers(ctx, o.ID)
+		if err != nil {
+			return a, fmt.Errorf("getting users of '%s': %v", o.ID, err)
+		}
+		a.Users = append(a.Users, users...)
+
+		if sch, err := s.Schema.Get(ctx, o.ID); err == nil {
+			a.Schemas = append(a.Schemas, Schema{Org: o.ID, Schema: sch})
+		} else if err != store.ErrNoResults {
+			return a, fmt.Errorf("getting schema of '%s': %v", o.ID, err)
+		}
+
+		for year := range years {
+			if tags, err := s.Tag.Get(ctx, o.ID, year); err == nil {
+				a.Tags = append(a.Tags, Tags{Org: o.ID, Year: year, Tags: tags})
+			} else if err != store.ErrNoResults {
+				return a, fmt.Errorf("getting %d tags of '%s': %v", year, o.ID, err)
+			}
+		}
+
+		for _, e := range a.Events {
+			reports, err := s.Report.GetReportsByEvent(ctx, o.ID, e.Key)
+			if err != nil {
+				return a, fmt.Errorf("getting reports of '%s' at '%s': %v", o.ID, e.Key, err)
+			}
+
+			// reports shared by partner organizations are archived with the
+			// organization that owns them
+			for _, rep := range reports {
+				if rep.Org == o.ID {
+					a.Reports = append(a.Reports, rep)
+				}
+			}
+		}
+
+		for _, u := range users {
+			bPicklists, err := s.Picklist.GetBasicPicklists(ctx, o.ID, u.Username)
+			if err != nil {
+				return a, fmt.Errorf("getting picklists of '%s': %v", u.Username, err)
+			}
+
+			for _, bp := range bPicklists {
+				if !inScope[bp.EventKey] {
+					continue
+				}
+
+				p, err := s.Picklist.Get(ctx, o.ID, bp.ID)
+				if err != nil {
+					return a, fmt.Errorf("getting picklist '%s': %v", bp.ID, err)
+				}
+
+				shares, err := s.Picklist.GetShares(ctx, o.ID, bp.ID)
+				if err != nil {
+					return a, fmt.Errorf("getting shares of picklist '%s': %v", bp.ID, err)
+				}
+
+				a.Picklists = append(a.Picklists, Picklist{Picklist: p, Shares: shares})
+			}
+		}
+
+		agreements, err := s.Sharing.GetByOrg(ctx, o.ID)
+		if err != nil {
+			return a, fmt.Errorf("getting sharing agreements of '%s': %v", o.ID, err)
+		}
+
+		// both organizations of an agreement see it, so only archive it once
+		for _, ag := range agreements {
+			if inScope[ag.EventKey] && !seenAgreements[ag.ID] {
+				seenAgreements[ag.ID] = true
+				a.SharingAgreements = append(a.SharingAgreements, ag)
+			}
+		}
+	}
+
+	return a, nil
+}
@@ -0,0 +1,49 @@
+package archive
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackupScope(t *testing.T) {
+	ctx := context.Background()
+	s := newMemStore()
+
+	if _, err := Restore(ctx, s, twoSeasonArchive()); !assert.Nil(t, err) {
+		return
+	}
+
+	for _, scope := range []Scope{{EventKey: "2019orwil"}, {Year: 2019}} {
+		a, err := Backup(ctx, s, scope, 32)
+		if !assert.Nil(t, err) {
+			continue
+		}
+		assert.Equal(t, scope, a.Manifest.Scope)
+
+		if assert.Len(t, a.Events, 1) {
+			assert.Equal(t, "2019orwil", a.Events[0].Key)
+		}
+		if assert.Len(t, a.Matches, 1) {
+			assert.Equal(t, "2019orwil_qm1", a.Matches[0].Key)
+		}
+		assert.Len(t, a.Reports, 2)
+		for _, rep := range a.Reports {
+			assert.Equal(t, "2019orwil", rep.EventKey)
+		}
+		if assert.Len(t, a.Picklists, 1) {
+			assert.Equal(t, "2019orwil", a.Picklists[0].EventKey)
+		}
+		if assert.Len(t, a.SharingAgreements, 1) {
+			assert.Equal(t, "2019orwil", a.SharingAgreements[0].EventKey)
+		}
+		if assert.Len(t, a.Tags, 1) {
+			assert.Equal(t, 2019, a.Tags[0].Year)
+		}
+
+		// organizations, users and roles aren't scoped
+		assert.Len(t, a.Organizations, 2)
+		assert.Len(t, a.Users, 2)
+	}
+}
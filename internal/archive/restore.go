@@ -0,0 +1,189 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/Pigmice2733/scouting-backend/internal/store"
+	"github.com/Pigmice2733/scouting-backend/internal/store/match"
+	"github.com/Pigmice2733/scouting-backend/internal/store/picklist"
+	"github.com/Pigmice2733/scouting-backend/internal/store/sharing"
+)
+
+// Restore loads an archive into a store. Restoring is idempotent, so restoring
+// the same archive twice leaves the store as it was after the first time.
+//
+// Organizations and users that already exist are left as they are, so
+// restoring never changes passwords or roles of existing users. Everything
+// else is upserted. Picklists are matched to existing ones by organization,
+// owner, event and name. Restored records are counted by archive file.
+func Restore(ctx context.Context, s *store.Service, a Archive) (map[string]int, error) {
+	restored := make(map[string]int)
+
+	for _, o := range a.Organizations {
+		if _, err := s.Org.Get(ctx, o.ID); err == store.ErrNoResults {
+			if err := s.Org.Create(ctx, o); err != nil {
+				return restored, fmt.Errorf("creating organization '%s': %v", o.ID, err)
+			}
+			restored["organizations.json"]++
+		} else if err != nil {
+			return restored, fmt.Errorf("getting organization '%s': %v", o.ID, err)
+		}
+	}
+
+	for _, r := range a.Roles {
+		if err := s.Role.Upsert(ctx, r); err != nil {
+			return restored, fmt.Errorf("upserting role '%s': %v", r.Name, err)
+		}
+		restored["roles.json"]++
+	}
+
+	for _, u := range a.Users {
+		if _, err := s.User.Get(ctx, u.Username); err == store.ErrNoResults {
+			if err := s.User.Create(ctx, u); err != nil {
+				return restored, fmt.Errorf("creating user '%s': %v", u.Username, err)
+			}
+			restored["users.json"]++
+		} else if err != nil {
+			return restored, fmt.Errorf("getting user '%s': %v", u.Username, err)
+		}
+	}
+
+	for _, sch := range a.Schemas {
+		if err := s.Schema.Upsert(ctx, sch.Org, sch.Schema); err != nil {
+			return restored, fmt.Errorf("upserting schema of '%s': %v", sch.Org, err)
+		}
+		restored["schemas.json"]++
+	}
+
+	for _, t := range a.Tags {
+		if err := s.Tag.Set(ctx, t.Org, t.Year, t.Tags); err != nil {
+			return restored, fmt.Errorf("setting %d tags of '%s': %v", t.Year, t.Org, err)
+		}
+		restored["tags.json"]++
+	}
+
+	if len(a.Events) > 0 {
+		if _, err := s.Event.MassUpsert(ctx, a.Events); err != nil {
+			return restored, fmt.Errorf("upserting events: %v", err)
+		}
+		restored["events.json"] += len(a.Events)
+	}
+
+	if len(a.Matches) > 0 {
+		matches := make([]match.Match, len(a.Matches))
+		for i, m := range a.Matches {
+			matches[i] = m.Match
+			matches[i].EventKey = m.EventKey
+		}
+
+		if _, err := s.Match.MassUpsert(ctx, matches); err != nil {
+			return restored, fmt.Errorf("upserting matches: %v", err)
+		}
+		restored["matches.json"] += len(matches)
+	}
+
+	for _, rep := range a.Reports {
+		if err := s.Report.Upsert(ctx, rep, s.Alliance); err != nil {
+			return restored, fmt.Errorf("upserting report on '%s' in '%s': %v", rep.Team, rep.MatchKey, err)
+		}
+		restored["reports.json"]++
+	}
+
+	for _, p := range a.Picklists {
+		changed, err := restorePicklist(ctx, s.Picklist, p)
+		if err != nil {
+			return restored, fmt.Errorf("restoring picklist '%s': %v", p.Name, err)
+		}
+		if changed {
+			restored["picklists.json"]++
+		}
+	}
+
+	for _, ag := range a.SharingAgreements {
+		changed, err := restoreAgreement(ctx, s, ag)
+		if err != nil {
+			return restored, fmt.Errorf("restoring sharing agreement between '%s' and '%s': %v", ag.Proposer, ag.Partner, err)
+		}
+		if changed {
+			restored["sharing.json"]++
+		}
+	}
+
+	return restored, nil
+}
+
+// restorePicklist inserts a picklist, or updates the existing picklist with the
+// same owner, event and name if its picks are different. Shares are always set.
+func restorePicklist(ctx context.Context, ps picklist.Service, p Picklist) (bool, error) {
+	existing, err := ps.GetByEvent(ctx, p.Org, p.Owner, p.EventKey)
+	if err != nil {
+		return false, err
+	}
+
+	id, changed := "", false
+	for _, bp := range existing {
+		if bp.Name == p.Name {
+			id = bp.ID
+			break
+		}
+	}
+
+	if id == "" {
+		if id, err = ps.Insert(ctx, p.Picklist); err != nil {
+			return false, err
+		}
+		changed = true
+	} else {
+		current, err := ps.Get(ctx, p.Org, id)
+		if err != nil {
+			return false, err
+		}
+
+		if !reflect.DeepEqual(current.List, p.List) {
+			update := p.Picklist
+			update.ID, update.Version = id, current.Version
+			if _, err := ps.Update(ctx, update, p.Owner); err != nil {
+				return false, err
+			}
+			changed = true
+		}
+	}
+
+	return changed, ps.SetShares(ctx, p.Org, id, p.Shares)
+}
+
+// restoreAgreement proposes a sharing agreement unless the organizations
+// already have one at the event, and accepts it if it was accepted.
+func restoreAgreement(ctx context.Context, s *store.Service, ag sharing.Agreement) (bool, error) {
+	existing, err := s.Sharing.GetByOrg(ctx, ag.Proposer)
+	if err != nil {
+		return false, err
+	}
+
+	for _, e := range existing {
+		if sameAgreement(e, ag) {
+			if ag.Accepted && !e.Accepted {
+				return true, s.Sharing.Accept(ctx, e.ID, e.Partner)
+			}
+			return false, nil
+		}
+	}
+
+	id, err := s.Sharing.Propose(ctx, ag)
+	if err != nil {
+		return false, err
+	}
+
+	if ag.Accepted {
+		return true, s.Sharing.Accept(ctx, id, ag.Partner)
+	}
+	return true, nil
+}
+
+// sameAgreement returns whether two agreements are between the same
+// organizations at the same event, ignoring their IDs.
+func sameAgreement(a, b sharing.Agreement) bool {
+	return a.EventKey == b.EventKey && a.Proposer == b.Proposer && a.Partner == b.Partner
+}
@@ -0,0 +1,120 @@
+package archive
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/Pigmice2733/scouting-backend/internal/analysis"
+	"github.com/Pigmice2733/scouting-backend/internal/store"
+	"github.com/Pigmice2733/scouting-backend/internal/store/event"
+	"github.com/Pigmice2733/scouting-backend/internal/store/match"
+	"github.com/Pigmice2733/scouting-backend/internal/store/organization"
+	"github.com/Pigmice2733/scouting-backend/internal/store/picklist"
+	"github.com/Pigmice2733/scouting-backend/internal/store/report"
+	"github.com/Pigmice2733/scouting-backend/internal/store/role"
+	"github.com/Pigmice2733/scouting-backend/internal/store/sharing"
+	"github.com/Pigmice2733/scouting-backend/internal/store/tag"
+	"github.com/Pigmice2733/scouting-backend/internal/store/user"
+	"github.com/stretchr/testify/assert"
+)
+
+// twoSeasonArchive is an archive of two organizations that shared reports at
+// an event in each of two seasons.
+func twoSeasonArchive() Archive {
+	a := Archive{
+		Manifest: Manifest{SchemaVersion: 32},
+		Organizations: []organization.Organization{
+			{ID: "frc1540", Name: "Flaming Chickens"},
+			{ID: "frc2733", Name: "Pigmice"},
+		},
+		Roles: []role.Role{{Name: "scout", Permissions: []string{role.ReportWrite}}},
+		Users: []user.User{
+			{Org: "frc1540", Username: "ben", HashedPassword: "hash", IsVerified: true, Roles: []string{"scout"}},
+			{Org: "frc2733", Username: "abby", HashedPassword: "hash", IsVerified: true, Roles: []string{"scout"}},
+		},
+		Schemas: []Schema{{Org: "frc2733", Schema: analysis.Schema{"climbed": "bool"}}},
+	}
+
+	for i, year := range []int{2018, 2019} {
+		date := time.Date(year, 3, 1, 0, 0, 0, 0, time.UTC)
+		e := event.BasicEvent{Key: date.Format("2006") + "orwil", Name: "Wilsonville", Date: date, EndDate: date}
+		matchKey := e.Key + "_qm1"
+		notes := "fast"
+
+		a.Tags = append(a.Tags, Tags{Org: "frc2733", Year: year, Tags: tag.Defaults})
+		a.Events = append(a.Events, e)
+		a.Matches = append(a.Matches, Match{EventKey: e.Key, Match: match.Match{
+			BasicMatch:   match.BasicMatch{Key: matchKey, EventKey: e.Key},
+			RedAlliance:  []string{"frc1", "frc2", "frc3"},
+			BlueAlliance: []string{"frc4", "frc5", "frc6"},
+		}})
+		a.Reports = append(a.Reports,
+			report.Report{Org: "frc1540", Reporter: "ben", EventKey: e.Key, MatchKey: matchKey, Team: "frc4", Stats: map[string]interface{}{}},
+			report.Report{Org: "frc2733", Reporter: "abby", EventKey: e.Key, MatchKey: matchKey, Team: "frc1", Notes: &notes, Tags: []string{"tipped"}, Stats: map[string]interface{}{"climbed": true}},
+		)
+		a.Picklists = append(a.Picklists, Picklist{
+			Picklist: picklist.Picklist{BasicPicklist: picklist.BasicPicklist{EventKey: e.Key, Name: "first"}, List: []picklist.Pick{{Team: "frc1", Tier: 1}}, Owner: "abby", Org: "frc2733", Version: 4},
+			Shares:   []picklist.Share{{Role: "scout", Permission: picklist.Read}},
+		})
+		a.SharingAgreements = append(a.SharingAgreements, sharing.Agreement{EventKey: e.Key, Proposer: "frc2733", Partner: "frc1540", Accepted: i == 0})
+	}
+
+	return a
+}
+
+// backupAll backs up everything in a store, with its tags in order so backups
+// can be compared.
+func backupAll(t *testing.T, s *store.Service) Archive {
+	a, err := Backup(context.Background(), s, Scope{}, 32)
+	assert.Nil(t, err)
+
+	a.Manifest = Manifest{}
+	sort.Slice(a.Tags, func(i, j int) bool { return a.Tags[i].Year < a.Tags[j].Year })
+	return a
+}
+
+func TestRestoreTwice(t *testing.T) {
+	ctx := context.Background()
+	s := newMemStore()
+	a := twoSeasonArchive()
+
+	restored, err := Restore(ctx, s, a)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, 2, restored["users.json"])
+	assert.Equal(t, 2, restored["picklists.json"])
+	assert.Equal(t, 2, restored["sharing.json"])
+
+	// users change their passwords and picklists are edited after restoring
+	users := s.User.(*memUsers)
+	abby := users.users["abby"]
+	abby.HashedPassword = "changed"
+	users.users["abby"] = abby
+
+	picklists := s.Picklist.(*memPicklists)
+	for id, p := range picklists.picklists {
+		p.Version = 7
+		picklists.picklists[id] = p
+	}
+
+	first := backupAll(t, s)
+
+	restored, err = Restore(ctx, s, a)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Zero(t, restored["organizations.json"])
+	assert.Zero(t, restored["users.json"])
+	assert.Zero(t, restored["picklists.json"])
+	assert.Zero(t, restored["sharing.json"])
+
+	assert.Equal(t, first, backupAll(t, s))
+	assert.Equal(t, "changed", users.users["abby"].HashedPassword)
+	for _, p := range picklists.picklists {
+		assert.Equal(t, 7, p.Version)
+	}
+	assert.Len(t, s.Sharing.(*memSharing).agreements, 2)
+}
@@ -0,0 +1,326 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/Pigmice2733/scouting-backend/internal/analysis"
+	"github.com/Pigmice2733/scouting-backend/internal/store"
+	"github.com/Pigmice2733/scouting-backend/internal/store/alliance"
+	"github.com/Pigmice2733/scouting-backend/internal/store/event"
+	"github.com/Pigmice2733/scouting-backend/internal/store/match"
+	"github.com/Pigmice2733/scouting-backend/internal/store/organization"
+	"github.com/Pigmice2733/scouting-backend/internal/store/picklist"
+	"github.com/Pigmice2733/scouting-backend/internal/store/report"
+	"github.com/Pigmice2733/scouting-backend/internal/store/role"
+	"github.com/Pigmice2733/scouting-backend/internal/store/sharing"
+	"github.com/Pigmice2733/scouting-backend/internal/store/tag"
+	"github.com/Pigmice2733/scouting-backend/internal/store/user"
+)
+
+// newMemStore returns an in-memory store with everything that is archived.
+// Each service only implements the methods Backup and Restore use.
+func newMemStore() *store.Service {
+	return &store.Service{
+		Org:      &memOrgs{orgs: make(map[string]organization.Organization)},
+		Role:     &memRoles{roles: make(map[string]role.Role)},
+		User:     &memUsers{users: make(map[string]user.User)},
+		Schema:   &memSchemas{schemas: make(map[string]analysis.Schema)},
+		Tag:      &memTags{tags: make(map[string][]tag.Tag)},
+		Event:    &memEvents{events: make(map[string]event.BasicEvent)},
+		Match:    &memMatches{matches: make(map[string]match.Match)},
+		Report:   &memReports{reports: make(map[string]report.Report)},
+		Picklist: &memPicklists{picklists: make(map[string]picklist.Picklist), shares: make(map[string][]picklist.Share)},
+		Sharing:  &memSharing{agreements: make(map[string]sharing.Agreement)},
+	}
+}
+
+type memOrgs struct {
+	organization.Service
+	orgs map[string]organization.Organization
+}
+
+func (s *memOrgs) Create(ctx context.Context, o organization.Organization) error {
+	if _, ok := s.orgs[o.ID]; ok {
+		return store.ErrConflict
+	}
+	s.orgs[o.ID] = o
+	return nil
+}
+
+func (s *memOrgs) Get(ctx context.Context, id string) (organization.Organization, error) {
+	o, ok := s.orgs[id]
+	if !ok {
+		return o, store.ErrNoResults
+	}
+	return o, nil
+}
+
+func (s *memOrgs) GetAll(ctx context.Context) ([]organization.Organization, error) {
+	var orgs []organization.Organization
+	for _, o := range s.orgs {
+		orgs = append(orgs, o)
+	}
+	sort.Slice(orgs, func(i, j int) bool { return orgs[i].ID < orgs[j].ID })
+	return orgs, nil
+}
+
+type memRoles struct {
+	role.Service
+	roles map[string]role.Role
+}
+
+func (s *memRoles) GetRoles(ctx context.Context) ([]role.Role, error) {
+	var roles []role.Role
+	for _, r := range s.roles {
+		roles = append(roles, r)
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i].Name < roles[j].Name })
+	return roles, nil
+}
+
+func (s *memRoles) Upsert(ctx context.Context, r role.Role) error {
+	s.roles[r.Name] = r
+	return nil
+}
+
+type memUsers struct {
+	user.Service
+	users map[string]user.User
+}
+
+func (s *memUsers) Create(ctx context.Context, u user.User) error {
+	if _, ok := s.users[u.Username]; ok {
+		return store.ErrConflict
+	}
+	s.users[u.Username] = u
+	return nil
+}
+
+func (s *memUsers) Get(ctx context.Context, username string) (user.User, error) {
+	u, ok := s.users[username]
+	if !ok {
+		return u, store.ErrNoResults
+	}
+	return u, nil
+}
+
+func (s *memUsers) GetUsers(ctx context.Context, org string) ([]user.User, error) {
+	var users []user.User
+	for _, u := range s.users {
+		if u.Org == org {
+			users = append(users, u)
+		}
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Username < users[j].Username })
+	return users, nil
+}
+
+type memSchemas struct {
+	schemas map[string]analysis.Schema
+}
+
+func (s *memSchemas) Get(ctx context.Context, org string) (analysis.Schema, error) {
+	sch, ok := s.schemas[org]
+	if !ok {
+		return nil, store.ErrNoResults
+	}
+	return sch, nil
+}
+
+func (s *memSchemas) Upsert(ctx context.Context, org string, sch analysis.Schema) error {
+	s.schemas[org] = sch
+	return nil
+}
+
+type memTags struct {
+	tags map[string][]tag.Tag
+}
+
+func (s *memTags) Get(ctx context.Context, org string, year int) ([]tag.Tag, error) {
+	tags, ok := s.tags[fmt.Sprintf("%s/%d", org, year)]
+	if !ok {
+		return nil, store.ErrNoResults
+	}
+	return tags, nil
+}
+
+func (s *memTags) Set(ctx context.Context, org string, year int, tags []tag.Tag) error {
+	s.tags[fmt.Sprintf("%s/%d", org, year)] = tags
+	return nil
+}
+
+type memEvents struct {
+	event.Service
+	events map[string]event.BasicEvent
+}
+
+func (s *memEvents) GetBasicEvents(ctx context.Context) ([]event.BasicEvent, error) {
+	var events []event.BasicEvent
+	for _, e := range s.events {
+		events = append(events, e)
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].Key < events[j].Key })
+	return events, nil
+}
+
+func (s *memEvents) MassUpsert(ctx context.Context, events []event.BasicEvent) (int, error) {
+	for _, e := range events {
+		s.events[e.Key] = e
+	}
+	return len(events), nil
+}
+
+type memMatches struct {
+	match.Service
+	matches map[string]match.Match
+}
+
+func (s *memMatches) GetBasicMatches(ctx context.Context, eventKey string) ([]match.BasicMatch, error) {
+	var bMatches []match.BasicMatch
+	for _, m := range s.matches {
+		if m.EventKey == eventKey {
+			bMatches = append(bMatches, m.BasicMatch)
+		}
+	}
+	sort.Slice(bMatches, func(i, j int) bool { return bMatches[i].Key < bMatches[j].Key })
+	return bMatches, nil
+}
+
+func (s *memMatches) Get(ctx context.Context, eventKey, matchKey string, as alliance.Service) (match.Match, error) {
+	m, ok := s.matches[matchKey]
+	if !ok || m.EventKey != eventKey {
+		return m, store.ErrNoResults
+	}
+	return m, nil
+}
+
+func (s *memMatches) MassUpsert(ctx context.Context, matches []match.Match) (match.Changes, error) {
+	for _, m := range matches {
+		s.matches[m.Key] = m
+	}
+	return match.Changes{Matches: len(matches)}, nil
+}
+
+// memReports stores one report per reporter, team and match of an
+// organization.
+type memReports struct {
+	report.Service
+	reports map[string]report.Report
+}
+
+func (s *memReports) Upsert(ctx context.Context, rep report.Report, as alliance.Service) error {
+	s.reports[rep.Org+"/"+rep.MatchKey+"/"+rep.Team+"/"+rep.Reporter] = rep
+	return nil
+}
+
+func (s *memReports) GetReportsByEvent(ctx context.Context, org, eventKey string) ([]report.Report, error) {
+	var reports []report.Report
+	for _, rep := range s.reports {
+		if rep.Org == org && rep.EventKey == eventKey {
+			reports = append(reports, rep)
+		}
+	}
+	sort.Slice(reports, func(i, j int) bool { return reports[i].MatchKey+reports[i].Team < reports[j].MatchKey+reports[j].Team })
+	return reports, nil
+}
+
+type memPicklists struct {
+	picklist.Service
+	picklists map[string]picklist.Picklist
+	shares    map[string][]picklist.Share
+}
+
+func (s *memPicklists) GetBasicPicklists(ctx context.Context, org, username string) ([]picklist.BasicPicklist, error) {
+	var bPicklists []picklist.BasicPicklist
+	for _, p := range s.picklists {
+		if p.Org == org && p.Owner == username {
+			bPicklists = append(bPicklists, p.BasicPicklist)
+		}
+	}
+	sort.Slice(bPicklists, func(i, j int) bool { return bPicklists[i].ID < bPicklists[j].ID })
+	return bPicklists, nil
+}
+
+func (s *memPicklists) GetByEvent(ctx context.Context, org, username, eventKey string) ([]picklist.BasicPicklist, error) {
+	bPicklists, err := s.GetBasicPicklists(ctx, org, username)
+
+	var byEvent []picklist.BasicPicklist
+	for _, bp := range bPicklists {
+		if bp.EventKey == eventKey {
+			byEvent = append(byEvent, bp)
+		}
+	}
+	return byEvent, err
+}
+
+func (s *memPicklists) Get(ctx context.Context, org, id string) (picklist.Picklist, error) {
+	p, ok := s.picklists[id]
+	if !ok || p.Org != org {
+		return p, store.ErrNoResults
+	}
+	return p, nil
+}
+
+func (s *memPicklists) Insert(ctx context.Context, p picklist.Picklist) (string, error) {
+	p.ID = fmt.Sprintf("picklist%d", len(s.picklists)+1)
+	p.Version = 1
+	s.picklists[p.ID] = p
+	return p.ID, nil
+}
+
+func (s *memPicklists) Update(ctx context.Context, p picklist.Picklist, editor string) (int, error) {
+	stored, ok := s.picklists[p.ID]
+	if !ok || stored.Org != p.Org {
+		return 0, store.ErrNoResults
+	} else if p.Version != 0 && p.Version != stored.Version {
+		return 0, store.ErrConflict
+	}
+
+	p.Owner, p.Version = stored.Owner, stored.Version+1
+	s.picklists[p.ID] = p
+	return p.Version, nil
+}
+
+func (s *memPicklists) GetShares(ctx context.Context, org, id string) ([]picklist.Share, error) {
+	return s.shares[id], nil
+}
+
+func (s *memPicklists) SetShares(ctx context.Context, org, id string, shares []picklist.Share) error {
+	s.shares[id] = shares
+	return nil
+}
+
+type memSharing struct {
+	sharing.Service
+	agreements map[string]sharing.Agreement
+}
+
+func (s *memSharing) Propose(ctx context.Context, a sharing.Agreement) (string, error) {
+	a.ID = fmt.Sprintf("agreement%d", len(s.agreements)+1)
+	a.Accepted = false
+	s.agreements[a.ID] = a
+	return a.ID, nil
+}
+
+func (s *memSharing) Accept(ctx context.Context, id, partner string) error {
+	a, ok := s.agreements[id]
+	if !ok || a.Partner != partner {
+		return store.ErrNoResults
+	}
+	a.Accepted = true
+	s.agreements[id] = a
+	return nil
+}
+
+func (s *memSharing) GetByOrg(ctx context.Context, org string) ([]sharing.Agreement, error) {
+	var agreements []sharing.Agreement
+	for _, a := range s.agreements {
+		if a.Proposer == org || a.Partner == org {
+			agreements = append(agreements, a)
+		}
+	}
+	sort.Slice(agreements, func(i, j int) bool { return agreements[i].ID < agreements[j].ID })
+	return agreements, nil
+}
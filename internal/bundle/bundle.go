@@ -0,0 +1,164 @@
+// Package bundle exports the scouting data of one event into a signed,
+// compressed bundle that can be given to partner teams, and imports bundles
+// from partners.
+//
+// A bundle is a gzipped tar file with bundle.json, holding the data, and
+// bundle.sig, the Ed25519 signature of bundle.json by the organization that
+// exported it.
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/Pigmice2733/scouting-backend/internal/analysis"
+	"github.com/Pigmice2733/scouting-backend/internal/archive"
+	"github.com/Pigmice2733/scouting-backend/internal/store/event"
+	"github.com/Pigmice2733/scouting-backend/internal/store/report"
+	"golang.org/x/crypto/ed25519"
+)
+
+// FormatVersion is the version of the bundle layout written by this package.
+const FormatVersion = 1
+
+const (
+	dataName      = "bundle.json"
+	signatureName = "bundle.sig"
+)
+
+// Limits on the decompressed size of a bundle that is read, so a small bundle
+// can't decompress into more than fits in memory. They are variables so tests
+// can lower them.
+var (
+	maxDataSize   int64 = 32 << 20
+	maxBundleSize       = maxDataSize + ed25519.SignatureSize
+)
+
+// Bundle holds the scouting data an organization collected at an event, along
+// with the report schema the data follows.
+type Bundle struct {
+	FormatVersion int               `json:"formatVersion"`
+	Org           string            `json:"org"`
+	PublicKey     ed25519.PublicKey `json:"publicKey"`
+	Created       time.Time         `json:"created"`
+	Event         event.BasicEvent  `json:"event"`
+	Schema        analysis.Schema   `json:"schema"`
+	Matches       []archive.Match   `json:"matches"`
+	Reports       []report.Report   `json:"reports"`
+
+	data      []byte
+	signature []byte
+}
+
+// Write signs and writes a bundle. The public key of the signing key is
+// stored in the bundle so that partners can see which key to trust.
+func Write(w io.Writer, b *Bundle, key ed25519.PrivateKey) error {
+	b.FormatVersion = FormatVersion
+	b.PublicKey = key.Public().(ed25519.PublicKey)
+
+	data, err := json.Marshal(b)
+	if err != nil {
+		return fmt.Errorf("encoding bundle: %v", err)
+	}
+	signature := ed25519.Sign(key, data)
+
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	for _, f := range []struct {
+		name    string
+		content []byte
+	}{{dataName, data}, {signatureName, signature}} {
+		if err := tw.WriteHeader(&tar.Header{Name: f.name, Mode: 0644, Size: int64(len(f.content)), ModTime: b.Created}); err != nil {
+			return err
+		}
+		if _, err := tw.Write(f.content); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+// Read reads a bundle. The signature isn't checked until Verify is called.
+func Read(r io.Reader) (Bundle, error) {
+	var b Bundle
+
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return b, fmt.Errorf("decompressing bundle: %v", err)
+	}
+	defer gr.Close()
+
+	var total int64
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return b, fmt.Errorf("reading bundle: %v", err)
+		}
+
+		var limit int64
+		switch {
+		case hdr.Name == dataName && b.data == nil:
+			limit = maxDataSize
+		case hdr.Name == signatureName && b.signature == nil:
+			limit = ed25519.SignatureSize
+		default:
+			return b, fmt.Errorf("unexpected file '%s'", hdr.Name)
+		}
+
+		if total += hdr.Size; hdr.Size > limit || total > maxBundleSize {
+			return b, fmt.Errorf("'%s' is too large", hdr.Name)
+		}
+
+		content, err := ioutil.ReadAll(io.LimitReader(tr, limit+1))
+		if err != nil {
+			return b, fmt.Errorf("reading '%s': %v", hdr.Name, err)
+		} else if int64(len(content)) > limit {
+			return b, fmt.Errorf("'%s' is too large", hdr.Name)
+		}
+
+		if hdr.Name == dataName {
+			b.data = content
+		} else {
+			b.signature = content
+		}
+	}
+
+	if b.data == nil || b.signature == nil {
+		return b, fmt.Errorf("bundle is missing its data or signature")
+	}
+
+	if err := json.Unmarshal(b.data, &b); err != nil {
+		return b, fmt.Errorf("decoding bundle: %v", err)
+	}
+
+	if b.FormatVersion < 1 || b.FormatVersion > FormatVersion {
+		return b, fmt.Errorf("unsupported bundle format version %d", b.FormatVersion)
+	}
+
+	return b, nil
+}
+
+// Verify returns an error unless a bundle that was read was signed with the
+// private key of a public key.
+func (b Bundle) Verify(publicKey ed25519.PublicKey) error {
+	if len(publicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("invalid public key")
+	}
+	if !ed25519.Verify(publicKey, b.data, b.signature) {
+		return fmt.Errorf("bundle signature is invalid")
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+	"time"
+
+	"github.com/Pigmice2733/scouting-backend/internal/analysis"
+	"github.com/Pigmice2733/scouting-backend/internal/store/event"
+	"github.com/Pigmice2733/scouting-backend/internal/store/report"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ed25519"
+)
+
+func testKey(seed byte) ed25519.PrivateKey {
+	return ed25519.NewKeyFromSeed(bytes.Repeat([]byte{seed}, ed25519.SeedSize))
+}
+
+func testBundle() Bundle {
+	date := time.Date(2018, 3, 1, 0, 0, 0, 0, time.UTC)
+	return Bundle{
+		Org:     "frc1540",
+		Created: date,
+		Event:   event.BasicEvent{Key: "2018orwil", Name: "Wilsonville", Date: date, EndDate: date},
+		Schema:  analysis.Schema{"climbed": "bool"},
+		Reports: []report.Report{{Org: "frc1540", Reporter: "abby", EventKey: "2018orwil", MatchKey: "2018orwil_qm1", Team: "frc2733", Tags: []string{}, Stats: map[string]interface{}{"climbed": true}}},
+	}
+}
+
+func TestWriteReadVerify(t *testing.T) {
+	key := testKey(1)
+	b := testBundle()
+
+	var buf bytes.Buffer
+	if !assert.Nil(t, Write(&buf, &b, key)) {
+		return
+	}
+
+	read, err := Read(&buf)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Nil(t, read.Verify(key.Public().(ed25519.PublicKey)))
+	assert.NotNil(t, read.Verify(testKey(2).Public().(ed25519.PublicKey)))
+	assert.NotNil(t, read.Verify(nil))
+
+	assert.Equal(t, FormatVersion, read.FormatVersion)
+	assert.Equal(t, key.Public(), read.PublicKey)
+	assert.Equal(t, b.Event, read.Event)
+	assert.Equal(t, b.Schema, read.Schema)
+	assert.Equal(t, b.Reports, read.Reports)
+}
+
+// writeFiles writes a bundle made of arbitrary files.
+func writeFiles(files ...[2][]byte) *bytes.Buffer {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	for _, f := range files {
+		tw.WriteHeader(&tar.Header{Name: string(f[0]), Mode: 0644, Size: int64(len(f[1]))})
+		tw.Write(f[1])
+	}
+	tw.Close()
+	gw.Close()
+	return &buf
+}
+
+func TestTampered(t *testing.T) {
+	key := testKey(1)
+	b := testBundle()
+
+	var buf bytes.Buffer
+	assert.Nil(t, Write(&buf, &b, key))
+	read, err := Read(&buf)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	tampered := bytes.Replace(read.data, []byte(`"climbed":true`), []byte(`"climbed":false`), 1)
+	assert.NotEqual(t, read.data, tampered)
+
+	read, err = Read(writeFiles([2][]byte{[]byte(dataName), tampered}, [2][]byte{[]byte(signatureName), read.signature}))
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, false, read.Reports[0].Stats["climbed"])
+	assert.NotNil(t, read.Verify(key.Public().(ed25519.PublicKey)))
+}
+
+func TestReadInvalid(t *testing.T) {
+	data := []byte(`{"formatVersion":1}`)
+
+	_, err := Read(writeFiles([2][]byte{[]byte(dataName), data}))
+	assert.NotNil(t, err, "missing signature")
+
+	_, err = Read(writeFiles([2][]byte{[]byte(dataName), data}, [2][]byte{[]byte(signatureName), nil}, [2][]byte{[]byte("pit.json"), nil}))
+	assert.NotNil(t, err, "unexpected file")
+
+	_, err = Read(writeFiles([2][]byte{[]byte(dataName), []byte(`{"formatVersion":2}`)}, [2][]byte{[]byte(signatureName), nil}))
+	assert.NotNil(t, err, "newer format")
+
+	_, err = Read(bytes.NewReader([]byte("not a bundle")))
+	assert.NotNil(t, err)
+}
+
+func TestReadTooLarge(t *testing.T) {
+	defer func(data, bundle int64) { maxDataSize, maxBundleSize = data, bundle }(maxDataSize, maxBundleSize)
+	maxDataSize, maxBundleSize = 1024, 1024+ed25519.SignatureSize
+
+	key := testKey(1)
+	b := testBundle()
+
+	var buf bytes.Buffer
+	assert.Nil(t, Write(&buf, &b, key))
+	_, err := Read(&buf)
+	assert.Nil(t, err, "bundle within the limits")
+
+	// a few bytes that decompress into more than the limit
+	padding := bytes.Repeat([]byte(" "), 4096)
+	bomb := writeFiles([2][]byte{[]byte(dataName), append([]byte(`{"formatVersion":1}`), padding...)}, [2][]byte{[]byte(signatureName), nil})
+	assert.True(t, bomb.Len() < 1024)
+	_, err = Read(bomb)
+	assert.NotNil(t, err, "data too large")
+
+	_, err = Read(writeFiles([2][]byte{[]byte(dataName), []byte(`{"formatVersion":1}`)}, [2][]byte{[]byte(signatureName), padding}))
+	assert.NotNil(t, err, "signature too large")
+}
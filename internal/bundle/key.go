@@ -0,0 +1,51 @@
+package bundle
+
+import (
+	"context"
+	"crypto/rand"
+
+	"github.com/Pigmice2733/scouting-backend/internal/store"
+	"github.com/Pigmice2733/scouting-backend/internal/store/bundlekey"
+	"golang.org/x/crypto/ed25519"
+)
+
+// Key gets the key an organization signs bundles with, generating one the
+// first time it is needed.
+func Key(ctx context.Context, ks bundlekey.Service, org string) (ed25519.PrivateKey, error) {
+	key, err := ks.Get(ctx, org)
+	if err != store.ErrNoResults {
+		return key, err
+	}
+
+	_, key, err = ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	// another request may have generated a key first, in which case that key
+	// is used instead
+	if err := ks.Create(ctx, org, key); err == store.ErrConflict {
+		return ks.Get(ctx, org)
+	} else if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// Trusted gets the public key an organization trusts bundles from a partner
+// with, or store.ErrNoResults if it doesn't trust the partner.
+func Trusted(ctx context.Context, ks bundlekey.Service, org, partner string) (ed25519.PublicKey, error) {
+	trusted, err := ks.GetTrusted(ctx, org)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range trusted {
+		if t.Org == partner {
+			return t.PublicKey, nil
+		}
+	}
+
+	return nil, store.ErrNoResults
+}
@@ -0,0 +1,300 @@
+package bundle
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/Pigmice2733/scouting-backend/internal/analysis"
+	"github.com/Pigmice2733/scouting-backend/internal/archive"
+	"github.com/Pigmice2733/scouting-backend/internal/store"
+	"github.com/Pigmice2733/scouting-backend/internal/store/event"
+	"github.com/Pigmice2733/scouting-backend/internal/store/match"
+	"github.com/Pigmice2733/scouting-backend/internal/store/report"
+	"github.com/Pigmice2733/scouting-backend/internal/store/tag"
+	"github.com/Pigmice2733/scouting-backend/internal/store/user"
+)
+
+// namespaceSeparator separates the organization and username of reporters
+// whose reports were imported from a partner.
+const namespaceSeparator = ":"
+
+// Namespace gets the reporter that reports by a reporter of a partner
+// organization are imported under, like "frc1540:abby".
+func Namespace(org, reporter string) string {
+	return org + namespaceSeparator + reporter
+}
+
+// imported returns whether a reporter is the namespaced reporter of a partner.
+func imported(reporter string) bool {
+	return strings.Contains(reporter, namespaceSeparator)
+}
+
+// Reasons a report from a bundle wasn't imported.
+const (
+	ReasonSchema  = "schema"
+	ReasonTags    = "tags"
+	ReasonOwn     = "own-report"
+	ReasonPartner = "other-partner"
+	ReasonUser    = "reporter"
+)
+
+// Conflict is a report from a bundle that wasn't imported.
+type Conflict struct {
+	MatchKey string `json:"matchKey"`
+	Team     string `json:"team"`
+	Reporter string `json:"reporter"`
+	Reason   string `json:"reason"`
+}
+
+// Result counts what importing a bundle did.
+type Result struct {
+	Imported  int        `json:"imported"`
+	Unchanged int        `json:"unchanged"`
+	Conflicts []Conflict `json:"conflicts"`
+}
+
+// Export builds a bundle of an organization's data at an event. Only reports
+// by the organization's own scouts are included, so data imported from other
+// partners isn't passed on.
+func Export(ctx context.Context, s *store.Service, org, eventKey string, schema analysis.Schema) (Bundle, error) {
+	b := Bundle{Org: org, Created: time.Now().UTC(), Schema: schema, Matches: []archive.Match{}, Reports: []report.Report{}}
+
+	events, err := s.Event.GetBasicEvents(ctx)
+	if err != nil {
+		return b, fmt.Errorf("getting events: %v", err)
+	}
+
+	found := false
+	for _, e := range events {
+		if e.Key == eventKey {
+			b.Event, found = e, true
+			break
+		}
+	}
+	if !found {
+		return b, store.ErrNoResults
+	}
+
+	bMatches, err := s.Match.GetBasicMatches(ctx, eventKey)
+	if err != nil {
+		return b, fmt.Errorf("getting matches: %v", err)
+	}
+
+	for _, bm := range bMatches {
+		m, err := s.Match.Get(ctx, eventKey, bm.Key, s.Alliance)
+		if err != nil {
+			return b, fmt.Errorf("getting match '%s': %v", bm.Key, err)
+		}
+		b.Matches = append(b.Matches, archive.Match{EventKey: eventKey, Match: m})
+	}
+
+	reports, err := s.Report.GetReportsByEvent(ctx, org, eventKey)
+	if err != nil {
+		return b, fmt.Errorf("getting reports: %v", err)
+	}
+
+	for _, rep := range reports {
+		if rep.Org == org && !imported(rep.Reporter) {
+			b.Reports = append(b.Reports, rep)
+		}
+	}
+
+	return b, nil
+}
+
+// action is what importing does with a report from a bundle.
+type action int
+
+const (
+	insert action = iota
+	unchanged
+	conflict
+)
+
+// merge decides what to do with a report from a bundle, given the report the
+// importing organization already has on the same team in the same match, if
+// any. Reports can only replace reports imported from the same reporter
+// before, and must only use the importing organization's tags.
+func merge(existing *report.Report, incoming report.Report, schema analysis.Schema, tags []tag.Tag) (action, string) {
+	if !analysis.CompliantData(schema, incoming.Stats) {
+		return conflict, ReasonSchema
+	}
+
+	if tag.Validate(tags, incoming.Tags) != nil {
+		return conflict, ReasonTags
+	}
+
+	if existing == nil {
+		return insert, ""
+	}
+
+	if existing.Reporter != incoming.Reporter {
+		if imported(existing.Reporter) {
+			return conflict, ReasonPartner
+		}
+		return conflict, ReasonOwn
+	}
+
+	same := reflect.DeepEqual(existing.Stats, incoming.Stats) &&
+		reflect.DeepEqual(existing.Notes, incoming.Notes) &&
+		len(existing.Tags) == len(incoming.Tags) && (len(existing.Tags) == 0 || reflect.DeepEqual(existing.Tags, incoming.Tags))
+	if same {
+		return unchanged, ""
+	}
+
+	return insert, ""
+}
+
+// Import merges the reports of a partner's bundle into an organization's
+// reports. The bundle must already be verified.
+//
+// Reports are imported under namespaced reporters (see Namespace), which are
+// created as unverified users without a password. Reports that don't follow
+// the organization's schema, that use tags the organization doesn't have for
+// the season, or that are on a team in a match the organization already has a
+// report on from someone else, aren't imported and are returned as conflicts. The event and any missing matches are added, so importing
+// works without a connection to TBA. Importing the same bundle again changes
+// nothing. Everything is imported in one transaction, so a bundle that fails to
+// import changes nothing either.
+func Import(ctx context.Context, s *store.Service, org string, schema analysis.Schema, b Bundle) (Result, error) {
+	var result Result
+	err := s.Transact(ctx, func(s *store.Service) error {
+		var err error
+		result, err = importBundle(ctx, s, org, schema, b)
+		return err
+	})
+	return result, err
+}
+
+func importBundle(ctx context.Context, s *store.Service, org string, schema analysis.Schema, b Bundle) (Result, error) {
+	result := Result{Conflicts: []Conflict{}}
+
+	if err := importSchedule(ctx, s, b); err != nil {
+		return result, err
+	}
+
+	tags, err := s.Tag.Get(ctx, org, b.Event.Date.Year())
+	if err == store.ErrNoResults {
+		tags = tag.Defaults
+	} else if err != nil {
+		return result, fmt.Errorf("getting tags: %v", err)
+	}
+
+	// the reports the organization already has at the event, by match and team
+	reports, err := s.Report.GetReportsByEvent(ctx, org, b.Event.Key)
+	if err != nil {
+		return result, fmt.Errorf("getting reports: %v", err)
+	}
+
+	existing := make(map[[2]string]report.Report)
+	for _, rep := range reports {
+		if rep.Org == org {
+			existing[[2]string{rep.MatchKey, rep.Team}] = rep
+		}
+	}
+
+	reporters := make(map[string]bool)
+
+	for _, rep := range b.Reports {
+		rep.Org = org
+		rep.EventKey = b.Event.Key
+		rep.Reporter = Namespace(b.Org, rep.Reporter)
+
+		var current *report.Report
+		if e, ok := existing[[2]string{rep.MatchKey, rep.Team}]; ok {
+			current = &e
+		}
+
+		act, reason := merge(current, rep, schema, tags)
+		if act == insert {
+			if ok, err := ensureReporter(ctx, s.User, org, rep.Reporter, reporters); err != nil {
+				return result, err
+			} else if !ok {
+				act, reason = conflict, ReasonUser
+			}
+		}
+
+		switch act {
+		case unchanged:
+			result.Unchanged++
+		case conflict:
+			result.Conflicts = append(result.Conflicts, Conflict{MatchKey: rep.MatchKey, Team: rep.Team, Reporter: rep.Reporter, Reason: reason})
+		case insert:
+			if err := s.Report.Upsert(ctx, rep, s.Alliance); err != nil {
+				return result, fmt.Errorf("upserting report on '%s' in '%s': %v", rep.Team, rep.MatchKey, err)
+			}
+			result.Imported++
+		}
+	}
+
+	return result, nil
+}
+
+// importSchedule adds the event of a bundle and any of its matches that are
+// missing. Existing events and matches are left alone, since they are kept up
+// to date from TBA.
+func importSchedule(ctx context.Context, s *store.Service, b Bundle) error {
+	events, err := s.Event.GetBasicEvents(ctx)
+	if err != nil {
+		return fmt.Errorf("getting events: %v", err)
+	}
+
+	found := false
+	for _, e := range events {
+		if e.Key == b.Event.Key {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		if _, err := s.Event.MassUpsert(ctx, []event.BasicEvent{b.Event}); err != nil {
+			return fmt.Errorf("upserting event: %v", err)
+		}
+	}
+
+	var missing []match.Match
+	for _, m := range b.Matches {
+		if _, err := s.Match.Get(ctx, b.Event.Key, m.Key, s.Alliance); err == store.ErrNoResults {
+			mm := m.Match
+			mm.EventKey = b.Event.Key
+			missing = append(missing, mm)
+		} else if err != nil {
+			return fmt.Errorf("getting match '%s': %v", m.Key, err)
+		}
+	}
+
+	if len(missing) > 0 {
+		if _, err := s.Match.MassUpsert(ctx, missing); err != nil {
+			return fmt.Errorf("upserting matches: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// ensureReporter creates the user for a namespaced reporter if it doesn't
+// exist. It returns false if the username is taken by a user of another
+// organization.
+func ensureReporter(ctx context.Context, us user.Service, org, reporter string, checked map[string]bool) (bool, error) {
+	if checked[reporter] {
+		return true, nil
+	}
+
+	u, err := us.Get(ctx, reporter)
+	if err == store.ErrNoResults {
+		if err := us.Create(ctx, user.User{Org: org, Username: reporter, Roles: []string{}}); err != nil {
+			return false, fmt.Errorf("creating reporter '%s': %v", reporter, err)
+		}
+	} else if err != nil {
+		return false, fmt.Errorf("getting reporter '%s': %v", reporter, err)
+	} else if u.Org != org {
+		return false, nil
+	}
+
+	checked[reporter] = true
+	return true, nil
+}
@@ -0,0 +1,75 @@
+package bundle
+
+import (
+	"testing"
+
+	"github.com/Pigmice2733/scouting-backend/internal/analysis"
+	"github.com/Pigmice2733/scouting-backend/internal/store/report"
+	"github.com/Pigmice2733/scouting-backend/internal/store/tag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamespace(t *testing.T) {
+	assert.Equal(t, "frc1540:abby", Namespace("frc1540", "abby"))
+	assert.True(t, imported(Namespace("frc1540", "abby")))
+	assert.False(t, imported("abby"))
+}
+
+func TestMerge(t *testing.T) {
+	schema := analysis.Schema{"climbed": "bool"}
+	notes := "fast"
+	otherNotes := "slow"
+
+	incoming := report.Report{Reporter: "frc1540:abby", Notes: &notes, Stats: map[string]interface{}{"climbed": true}}
+
+	cases := []struct {
+		name     string
+		existing *report.Report
+		incoming report.Report
+		action   action
+		reason   string
+	}{
+		{"new", nil, incoming, insert, ""},
+		{
+			"schema", nil,
+			report.Report{Reporter: "frc1540:abby", Stats: map[string]interface{}{"climbed": 3.0}},
+			conflict, ReasonSchema,
+		},
+		{
+			"unknown tag", nil,
+			report.Report{Reporter: "frc1540:abby", Tags: []string{"tipped", "launched"}, Stats: map[string]interface{}{"climbed": true}},
+			conflict, ReasonTags,
+		},
+		{
+			"known tags", nil,
+			report.Report{Reporter: "frc1540:abby", Tags: []string{"tipped"}, Stats: map[string]interface{}{"climbed": true}},
+			insert, "",
+		},
+		{
+			"own report",
+			&report.Report{Reporter: "ben", Stats: map[string]interface{}{"climbed": false}},
+			incoming, conflict, ReasonOwn,
+		},
+		{
+			"other partner",
+			&report.Report{Reporter: "frc254:cam", Stats: map[string]interface{}{"climbed": false}},
+			incoming, conflict, ReasonPartner,
+		},
+		{
+			"reimported",
+			&report.Report{Reporter: "frc1540:abby", Notes: &notes, Tags: []string{}, Stats: map[string]interface{}{"climbed": true}},
+			incoming, unchanged, "",
+		},
+		{
+			"updated",
+			&report.Report{Reporter: "frc1540:abby", Notes: &otherNotes, Stats: map[string]interface{}{"climbed": true}},
+			incoming, insert, "",
+		},
+	}
+
+	for _, c := range cases {
+		act, reason := merge(c.existing, c.incoming, schema, tag.Defaults)
+		assert.Equal(t, c.action, act, c.name)
+		assert.Equal(t, c.reason, reason, c.name)
+	}
+}
@@ -0,0 +1,289 @@
+package consensus
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Supported aggregation methods.
+const (
+	Borda  = "borda"
+	Kemeny = "kemeny"
+)
+
+// ErrUnsupportedMethod is returned when an aggregation method other than Borda
+// or Kemeny is given.
+var ErrUnsupportedMethod = fmt.Errorf("consensus: unsupported method")
+
+// maxExactKemeny is the most teams an exact Kemeny ranking is found for. The
+// exact search takes 2^n steps, so larger lists are ranked with a local search
+// instead.
+const maxExactKemeny = 16
+
+// Ranking is the consensus rank of a team, along with how the individual lists
+// ranked it. Teams missing from a list are counted as ranked just below the
+// last team in it. A high rank variance means the lists disagree on the team.
+type Ranking struct {
+	Team         string  `json:"team"`
+	Rank         int     `json:"rank"`
+	Score        float64 `json:"score"`
+	MeanRank     float64 `json:"meanRank"`
+	RankVariance float64 `json:"rankVariance"`
+	Ranks        []int   `json:"ranks"`
+}
+
+// Aggregate merges several ranked lists of teams into a single consensus
+// ranking with the given method.
+//
+// With Borda, a team gets as many points from a list as the number of teams at
+// or below it in that list, and teams are ranked by their total points.
+//
+// With Kemeny, teams are ordered to disagree with as few of the lists' pairwise
+// preferences as possible. A team's score is the number of times a list ranked
+// it above another team.
+func Aggregate(method string, lists [][]string) ([]Ranking, error) {
+	teams, ranks := rankTeams(lists)
+
+	var order []string
+	scores := make(map[string]float64)
+
+	switch method {
+	case Borda:
+		for _, list := range lists {
+			for i, team := range list {
+				scores[team] += float64(len(list) - i)
+			}
+		}
+
+		order = append(order, teams...)
+		sort.SliceStable(order, func(i, j int) bool {
+			return scores[order[i]] > scores[order[j]]
+		})
+	case Kemeny:
+		prefs := preferences(teams, ranks)
+		for i, team := range teams {
+			for j := range teams {
+				scores[team] += float64(prefs[i][j])
+			}
+		}
+
+		var indexes []int
+		if len(teams) <= maxExactKemeny {
+			indexes = exactKemeny(prefs)
+		} else {
+			indexes = localKemeny(prefs, bordaOrder(teams, lists))
+		}
+
+		for _, i := range indexes {
+			order = append(order, teams[i])
+		}
+	default:
+		return nil, ErrUnsupportedMethod
+	}
+
+	rankings := make([]Ranking, 0, len(order))
+	for i, team := range order {
+		mean, variance := meanVariance(ranks[team])
+		rankings = append(rankings, Ranking{
+			Team:         team,
+			Rank:         i + 1,
+			Score:        scores[team],
+			MeanRank:     mean,
+			RankVariance: variance,
+			Ranks:        ranks[team],
+		})
+	}
+
+	return rankings, nil
+}
+
+// rankTeams finds every team in the lists, sorted by mean rank and then by
+// key so that ties are broken the same way every time, and the rank of each
+// team in each list.
+func rankTeams(lists [][]string) ([]string, map[string][]int) {
+	ranks := make(map[string][]int)
+	var teams []string
+
+	for _, list := range lists {
+		for _, team := range list {
+			if _, ok := ranks[team]; !ok {
+				ranks[team] = nil
+				teams = append(teams, team)
+			}
+		}
+	}
+
+	for _, list := range lists {
+		positions := make(map[string]int, len(list))
+		for i, team := range list {
+			if _, ok := positions[team]; !ok {
+				positions[team] = i + 1
+			}
+		}
+
+		for _, team := range teams {
+			rank, ok := positions[team]
+			if !ok {
+				rank = len(list) + 1
+			}
+			ranks[team] = append(ranks[team], rank)
+		}
+	}
+
+	sort.Slice(teams, func(i, j int) bool {
+		mi, _ := meanVariance(ranks[teams[i]])
+		mj, _ := meanVariance(ranks[teams[j]])
+		if mi != mj {
+			return mi < mj
+		}
+		return teams[i] < teams[j]
+	})
+
+	return teams, ranks
+}
+
+func meanVariance(ranks []int) (float64, float64) {
+	if len(ranks) == 0 {
+		return 0, 0
+	}
+
+	var sum float64
+	for _, r := range ranks {
+		sum += float64(r)
+	}
+	mean := sum / float64(len(ranks))
+
+	var squares float64
+	for _, r := range ranks {
+		squares += (float64(r) - mean) * (float64(r) - mean)
+	}
+
+	return mean, squares / float64(len(ranks))
+}
+
+// preferences counts, for every pair of teams, how many lists rank the first
+// team above the second.
+func preferences(teams []string, ranks map[string][]int) [][]int {
+	prefs := make([][]int, len(teams))
+	for i, a := range teams {
+		prefs[i] = make([]int, len(teams))
+		for j, b := range teams {
+			for l := range ranks[a] {
+				if ranks[a][l] < ranks[b][l] {
+					prefs[i][j]++
+				}
+			}
+		}
+	}
+	return prefs
+}
+
+// bordaOrder returns the indexes of teams in Borda order, as a starting point
+// for the local Kemeny search.
+func bordaOrder(teams []string, lists [][]string) []int {
+	scores := make(map[string]int)
+	for _, list := range lists {
+		for i, team := range list {
+			scores[team] += len(list) - i
+		}
+	}
+
+	order := make([]int, len(teams))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return scores[teams[order[i]]] > scores[teams[order[j]]]
+	})
+
+	return order
+}
+
+// exactKemeny finds the order of teams with the fewest pairwise disagreements
+// by building the best order for every subset of teams placed first.
+func exactKemeny(prefs [][]int) []int {
+	n := len(prefs)
+	if n == 0 {
+		return nil
+	}
+
+	size := 1 << uint(n)
+	cost := make([]int, size)
+	last := make([]int, size)
+	for mask := 1; mask < size; mask++ {
+		cost[mask] = -1
+	}
+
+	for mask := 0; mask < size; mask++ {
+		if cost[mask] < 0 {
+			continue
+		}
+
+		for t := 0; t < n; t++ {
+			if mask&(1<<uint(t)) != 0 {
+				continue
+			}
+
+			// placing t after every team in mask disagrees with each list that
+			// ranked t above one of them
+			added := 0
+			for a := 0; a < n; a++ {
+				if mask&(1<<uint(a)) != 0 {
+					added += prefs[t][a]
+				}
+			}
+
+			next := mask | 1<<uint(t)
+			if cost[next] < 0 || cost[mask]+added < cost[next] {
+				cost[next] = cost[mask] + added
+				last[next] = t
+			}
+		}
+	}
+
+	order := make([]int, n)
+	for mask, i := size-1, n-1; i >= 0; i-- {
+		order[i] = last[mask]
+		mask &^= 1 << uint(last[mask])
+	}
+
+	return order
+}
+
+// localKemeny improves an order by moving single teams to the position that
+// removes the most pairwise disagreements, until no move helps.
+func localKemeny(prefs [][]int, order []int) []int {
+	for improved := true; improved; {
+		improved = false
+
+		for i := range order {
+			t := order[i]
+
+			// the change in disagreements from moving t to each other position,
+			// found by swapping it past one neighbour at a time
+			bestDelta, bestPos := 0, i
+			delta := 0
+			for j := i - 1; j >= 0; j-- {
+				delta += prefs[order[j]][t] - prefs[t][order[j]]
+				if delta < bestDelta {
+					bestDelta, bestPos = delta, j
+				}
+			}
+			delta = 0
+			for j := i + 1; j < len(order); j++ {
+				delta += prefs[t][order[j]] - prefs[order[j]][t]
+				if delta < bestDelta {
+					bestDelta, bestPos = delta, j
+				}
+			}
+
+			if bestPos != i {
+				order = append(order[:i], order[i+1:]...)
+				order = append(order[:bestPos], append([]int{t}, order[bestPos:]...)...)
+				improved = true
+			}
+		}
+	}
+
+	return order
+}
@@ -0,0 +1,98 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func teams(rankings []Ranking) []string {
+	var t []string
+	for _, r := range rankings {
+		t = append(t, r.Team)
+	}
+	return t
+}
+
+func TestAggregateBorda(t *testing.T) {
+	rankings, err := Aggregate(Borda, [][]string{
+		{"frc2733", "frc254", "frc118"},
+		{"frc254", "frc2733", "frc118"},
+		{"frc2733", "frc118", "frc254"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"frc2733", "frc254", "frc118"}, teams(rankings))
+
+	assert.Equal(t, 8.0, rankings[0].Score)
+	assert.Equal(t, 6.0, rankings[1].Score)
+	assert.Equal(t, 4.0, rankings[2].Score)
+
+	for i, r := range rankings {
+		assert.Equal(t, i+1, r.Rank)
+	}
+}
+
+func TestAggregateKemeny(t *testing.T) {
+	// borda ranks frc1 above frc3 because of the two lists that rank it first,
+	// but most lists prefer frc3 to it
+	lists := [][]string{
+		{"frc1", "frc2", "frc3", "frc4"},
+		{"frc2", "frc3", "frc1", "frc4"},
+		{"frc2", "frc3", "frc1", "frc4"},
+		{"frc1", "frc4", "frc2", "frc3"},
+		{"frc3", "frc2", "frc1", "frc4"},
+	}
+
+	rankings, err := Aggregate(Kemeny, lists)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"frc2", "frc3", "frc1", "frc4"}, teams(rankings))
+	assert.Equal(t, 11.0, rankings[0].Score)
+
+	borda, err := Aggregate(Borda, lists)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"frc2", "frc1", "frc3", "frc4"}, teams(borda))
+}
+
+func TestAggregateKemenyLocal(t *testing.T) {
+	var list []string
+	for _, team := range "abcdefghijklmnopqrstuvwxyz" {
+		list = append(list, string(team))
+	}
+
+	reversed := make([]string, len(list))
+	for i, team := range list {
+		reversed[len(list)-1-i] = team
+	}
+
+	rankings, err := Aggregate(Kemeny, [][]string{list, list, reversed})
+	assert.NoError(t, err)
+	assert.Equal(t, list, teams(rankings))
+}
+
+func TestAggregateVariance(t *testing.T) {
+	rankings, err := Aggregate(Borda, [][]string{
+		{"frc2733", "frc254"},
+		{"frc254", "frc2733"},
+		{"frc2733"},
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "frc2733", rankings[0].Team)
+	assert.Equal(t, []int{1, 2, 1}, rankings[0].Ranks)
+	assert.InDelta(t, 4.0/3, rankings[0].MeanRank, 0.0001)
+	assert.InDelta(t, 2.0/9, rankings[0].RankVariance, 0.0001)
+
+	// frc254 is missing from the last list, so it is counted as ranked just
+	// below frc2733 there
+	assert.Equal(t, "frc254", rankings[1].Team)
+	assert.Equal(t, []int{2, 1, 2}, rankings[1].Ranks)
+}
+
+func TestAggregateUnsupported(t *testing.T) {
+	_, err := Aggregate("plurality", [][]string{{"frc2733"}})
+	assert.Equal(t, ErrUnsupportedMethod, err)
+
+	rankings, err := Aggregate(Kemeny, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, rankings)
+}
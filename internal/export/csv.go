@@ -0,0 +1,67 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/Pigmice2733/scouting-backend/internal/respond"
+)
+
+// WriteCSV writes a table as CSV.
+func WriteCSV(w io.Writer, t Table) error {
+	cw := csv.NewWriter(w)
+
+	header := make([]string, len(t.Header))
+	for i, h := range t.Header {
+		header[i] = csvCell(h)
+	}
+
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, row := range t.Rows {
+		record := make([]string, len(row))
+		for i, cell := range row {
+			record[i] = csvCell(cell)
+		}
+
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvCell formats a cell for CSV, escaping text so it isn't run as a formula.
+func csvCell(cell interface{}) string {
+	text := formatCell(cell)
+
+	switch cell.(type) {
+	case nil, float64, int, bool:
+		return text
+	}
+
+	return respond.CSVText(text)
+}
+
+func formatCell(cell interface{}) string {
+	switch v := cell.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case int:
+		return strconv.Itoa(v)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
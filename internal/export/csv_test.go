@@ -0,0 +1,41 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteCSV(t *testing.T) {
+	var b bytes.Buffer
+
+	err := WriteCSV(&b, Table{
+		Header: []string{"team", "reports", "climbed", "notes"},
+		Rows: [][]interface{}{
+			{"frc254", 4, 0.75, "fast, \"good\" climber"},
+			{"frc1678", 2, true, nil},
+		},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "team,reports,climbed,notes\nfrc254,4,0.75,\"fast, \"\"good\"\" climber\"\nfrc1678,2,true,\n", b.String())
+}
+
+func TestWriteCSVFormulas(t *testing.T) {
+	var b bytes.Buffer
+
+	err := WriteCSV(&b, Table{
+		Header: []string{"team", "=notes"},
+		Rows: [][]interface{}{
+			{"frc254", "=HYPERLINK(\"http://example.com\")"},
+			{"frc1678", "+1 climb"},
+			{"frc2733", "-"},
+			{"frc4488", "@SUM(A1)"},
+			{-3, "fast - and good"},
+		},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "team,'=notes\nfrc254,\"'=HYPERLINK(\"\"http://example.com\"\")\"\nfrc1678,'+1 climb\nfrc2733,'-\nfrc4488,'@SUM(A1)\n-3,fast - and good\n", b.String())
+}
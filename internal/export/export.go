@@ -0,0 +1,211 @@
+// Package export builds tables of event data for spreadsheets, and writes
+// them as CSV or XLSX.
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Pigmice2733/scouting-backend/internal/analysis"
+	"github.com/Pigmice2733/scouting-backend/internal/server/logic"
+	"github.com/Pigmice2733/scouting-backend/internal/store"
+	"github.com/Pigmice2733/scouting-backend/internal/store/match"
+	"github.com/Pigmice2733/scouting-backend/internal/store/report"
+)
+
+// Formats tables can be written as.
+const (
+	CSV  = "csv"
+	XLSX = "xlsx"
+)
+
+// Tables of event data that can be exported.
+const (
+	ReportsTable  = "reports"
+	AnalysisTable = "analysis"
+	ScheduleTable = "schedule"
+)
+
+// ContentTypes are the MIME types of each format.
+var ContentTypes = map[string]string{
+	CSV:  "text/csv",
+	XLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// Table is a header and rows of cells. Cells can be strings, numbers, bools,
+// or nil for empty cells.
+type Table struct {
+	Header []string
+	Rows   [][]interface{}
+}
+
+// Write writes a table in a format.
+func Write(w io.Writer, format string, t Table) error {
+	switch format {
+	case CSV:
+		return WriteCSV(w, t)
+	case XLSX:
+		return WriteXLSX(w, t)
+	default:
+		return fmt.Errorf("unknown format '%s'", format)
+	}
+}
+
+// Build builds a table of data from an event that an organization can see.
+func Build(ctx context.Context, table, org, eventKey string, schema analysis.Schema, s *store.Service) (Table, error) {
+	switch table {
+	case ReportsTable:
+		reports, err := s.Report.GetReportsByEvent(ctx, org, eventKey)
+		if err != nil {
+			return Table{}, fmt.Errorf("getting reports: %v", err)
+		}
+		return Reports(schema, reports), nil
+	case AnalysisTable:
+		analyses, err := logic.EventAnalysis(ctx, org, eventKey, schema, s.Report)
+		if err != nil {
+			return Table{}, fmt.Errorf("analyzing event: %v", err)
+		}
+		return Analysis(schema, analyses), nil
+	case ScheduleTable:
+		basicMatches, err := s.Match.GetBasicMatches(ctx, eventKey)
+		if err != nil {
+			return Table{}, fmt.Errorf("getting matches: %v", err)
+		}
+
+		matches := make([]match.Match, 0, len(basicMatches))
+		for _, bm := range basicMatches {
+			m, err := s.Match.Get(ctx, eventKey, bm.Key, s.Alliance)
+			if err != nil {
+				return Table{}, fmt.Errorf("getting match '%s': %v", bm.Key, err)
+			}
+			matches = append(matches, m)
+		}
+		return Schedule(matches), nil
+	default:
+		return Table{}, fmt.Errorf("unknown table '%s'", table)
+	}
+}
+
+// fields gets the fields of a schema in a stable order.
+func fields(schema analysis.Schema) []string {
+	names := make([]string, 0, len(schema))
+	for name := range schema {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Reports builds a table with a row for each report, and a column for each
+// field of the schema.
+func Reports(schema analysis.Schema, reports []report.Report) Table {
+	names := fields(schema)
+
+	t := Table{Header: append([]string{"match", "team", "reporter", "org"}, names...)}
+	t.Header = append(t.Header, "tags", "notes")
+
+	for _, rep := range reports {
+		row := []interface{}{rep.MatchKey, rep.Team, rep.Reporter, rep.Org}
+		for _, name := range names {
+			row = append(row, rep.Stats[name])
+		}
+
+		var notes interface{}
+		if rep.Notes != nil {
+			notes = *rep.Notes
+		}
+		row = append(row, strings.Join(rep.Tags, ", "), notes)
+
+		t.Rows = append(t.Rows, row)
+	}
+
+	return t
+}
+
+// Analysis builds a table with a row for each team's analysis, a column for
+// each field of the schema, and a column for the rate of each tag used.
+func Analysis(schema analysis.Schema, analyses []logic.TeamAnalysis) Table {
+	names := fields(schema)
+
+	tagSet := make(map[string]bool)
+	for _, ta := range analyses {
+		for t := range ta.Reliability {
+			tagSet[t] = true
+		}
+	}
+	tags := make([]string, 0, len(tagSet))
+	for t := range tagSet {
+		tags = append(tags, t)
+	}
+	sort.Strings(tags)
+
+	t := Table{Header: append([]string{"team", "reports"}, names...)}
+	for _, tag := range tags {
+		t.Header = append(t.Header, tag+" rate")
+	}
+
+	for _, ta := range analyses {
+		row := []interface{}{ta.Team, ta.Reports}
+		for _, name := range names {
+			row = append(row, ta.Stats[name])
+		}
+		for _, tag := range tags {
+			row = append(row, ta.Reliability[tag])
+		}
+
+		t.Rows = append(t.Rows, row)
+	}
+
+	return t
+}
+
+// Schedule builds a table with a row for each match, and a column for each
+// team on the alliances.
+func Schedule(matches []match.Match) Table {
+	size := 0
+	for _, m := range matches {
+		if len(m.RedAlliance) > size {
+			size = len(m.RedAlliance)
+		}
+		if len(m.BlueAlliance) > size {
+			size = len(m.BlueAlliance)
+		}
+	}
+
+	t := Table{Header: []string{"match", "predicted time", "actual time"}}
+	for _, color := range []string{"red", "blue"} {
+		for i := 1; i <= size; i++ {
+			t.Header = append(t.Header, fmt.Sprintf("%s %d", color, i))
+		}
+	}
+	t.Header = append(t.Header, "red score", "blue score")
+
+	for _, m := range matches {
+		row := []interface{}{m.Key, formatTime(m.PredictedTime), formatTime(m.ActualTime)}
+		for _, alliance := range [][]string{m.RedAlliance, m.BlueAlliance} {
+			for i := 0; i < size; i++ {
+				if i < len(alliance) {
+					row = append(row, alliance[i])
+				} else {
+					row = append(row, nil)
+				}
+			}
+		}
+		row = append(row, m.RedScore, m.BlueScore)
+
+		t.Rows = append(t.Rows, row)
+	}
+
+	return t
+}
+
+func formatTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,66 @@
+package export
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pigmice2733/scouting-backend/internal/analysis"
+	"github.com/Pigmice2733/scouting-backend/internal/server/logic"
+	"github.com/Pigmice2733/scouting-backend/internal/store/match"
+	"github.com/Pigmice2733/scouting-backend/internal/store/report"
+	"github.com/stretchr/testify/assert"
+)
+
+var schema = analysis.Schema{"climbed": "bool", "cubes": "number"}
+
+func TestReports(t *testing.T) {
+	notes := "fast"
+	table := Reports(schema, []report.Report{
+		{Org: "frc2733", Reporter: "abby", MatchKey: "2018orwil_qm1", Team: "frc254", Notes: &notes, Tags: []string{"tipped", "defended"}, Stats: map[string]interface{}{"climbed": true, "cubes": 4.0}},
+		{Org: "frc2733", Reporter: "ben", MatchKey: "2018orwil_qm2", Team: "frc1678", Stats: map[string]interface{}{"climbed": false}},
+	})
+
+	assert.Equal(t, []string{"match", "team", "reporter", "org", "climbed", "cubes", "tags", "notes"}, table.Header)
+	assert.Equal(t, [][]interface{}{
+		{"2018orwil_qm1", "frc254", "abby", "frc2733", true, 4.0, "tipped, defended", "fast"},
+		{"2018orwil_qm2", "frc1678", "ben", "frc2733", false, nil, "", nil},
+	}, table.Rows)
+}
+
+func TestAnalysis(t *testing.T) {
+	table := Analysis(schema, []logic.TeamAnalysis{
+		{Team: "frc254", Reports: 4, Stats: analysis.Results{"climbed": 0.75, "cubes": 5}, Reliability: map[string]float64{"tipped": 0.25}},
+		{Team: "frc1678", Reports: 2, Stats: analysis.Results{"climbed": 1, "cubes": 3}, Reliability: map[string]float64{"broke-down": 0.5}},
+	})
+
+	assert.Equal(t, []string{"team", "reports", "climbed", "cubes", "broke-down rate", "tipped rate"}, table.Header)
+	assert.Equal(t, [][]interface{}{
+		{"frc254", 4, 0.75, 5.0, 0.0, 0.25},
+		{"frc1678", 2, 1.0, 3.0, 0.5, 0.0},
+	}, table.Rows)
+}
+
+func TestSchedule(t *testing.T) {
+	predicted := time.Date(2018, 3, 1, 9, 30, 0, 0, time.FixedZone("PST", -8*60*60))
+
+	table := Schedule([]match.Match{
+		{
+			BasicMatch:   match.BasicMatch{Key: "2018orwil_qm1", PredictedTime: &predicted},
+			RedAlliance:  []string{"frc1", "frc2", "frc3"},
+			BlueAlliance: []string{"frc4", "frc5", "frc6"},
+			RedScore:     100,
+			BlueScore:    90,
+		},
+		{
+			BasicMatch:   match.BasicMatch{Key: "2018orwil_qm2"},
+			RedAlliance:  []string{"frc7", "frc8"},
+			BlueAlliance: []string{"frc9", "frc10", "frc11"},
+		},
+	})
+
+	assert.Equal(t, []string{"match", "predicted time", "actual time", "red 1", "red 2", "red 3", "blue 1", "blue 2", "blue 3", "red score", "blue score"}, table.Header)
+	assert.Equal(t, [][]interface{}{
+		{"2018orwil_qm1", "2018-03-01T17:30:00Z", nil, "frc1", "frc2", "frc3", "frc4", "frc5", "frc6", 100, 90},
+		{"2018orwil_qm2", nil, nil, "frc7", "frc8", nil, "frc9", "frc10", "frc11", 0, 0},
+	}, table.Rows)
+}
@@ -0,0 +1,59 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestColumnName(t *testing.T) {
+	cases := map[int]string{0: "A", 1: "B", 25: "Z", 26: "AA", 27: "AB", 51: "AZ", 52: "BA", 701: "ZZ", 702: "AAA"}
+	for i, name := range cases {
+		assert.Equal(t, name, columnName(i), "column %d", i)
+	}
+}
+
+func TestWriteXLSX(t *testing.T) {
+	var b bytes.Buffer
+
+	err := WriteXLSX(&b, Table{
+		Header: []string{"team", "reports", "climbed", "notes"},
+		Rows: [][]interface{}{
+			{"frc254", 4, 0.75, "<fast>"},
+			{"frc1678", 2, true, nil},
+			{"frc2990", 1, false, "=SUM(B2:B3)"},
+		},
+	})
+	assert.Nil(t, err)
+
+	zr, err := zip.NewReader(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	files := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if !assert.Nil(t, err) {
+			return
+		}
+		content, err := ioutil.ReadAll(rc)
+		rc.Close()
+		assert.Nil(t, err)
+		files[f.Name] = string(content)
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/worksheets/sheet1.xml"} {
+		assert.Contains(t, files, name)
+	}
+
+	sheet := files["xl/worksheets/sheet1.xml"]
+	assert.Contains(t, sheet, `<row r="1"><c r="A1" t="inlineStr"><is><t xml:space="preserve">team</t></is></c>`)
+	assert.Contains(t, sheet, `<c r="B2"><v>4</v></c><c r="C2"><v>0.75</v></c><c r="D2" t="inlineStr"><is><t xml:space="preserve">&lt;fast&gt;</t></is></c>`)
+	assert.Contains(t, sheet, `<c r="C3" t="b"><v>1</v></c></row>`)
+	assert.Contains(t, sheet, `<c r="D4" t="inlineStr"><is><t xml:space="preserve">=SUM(B2:B3)</t></is></c></row>`)
+	assert.NotContains(t, sheet, "<f>")
+}
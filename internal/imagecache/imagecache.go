@@ -0,0 +1,226 @@
+package imagecache
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const metaSuffix = ".json"
+
+// ErrTooLarge is returned when an image is larger than the maximum size of the
+// cache.
+var ErrTooLarge = fmt.Errorf("imagecache: image larger than cache")
+
+// Entry holds information about a cached image.
+type Entry struct {
+	Key         string    `json:"key"`
+	ContentType string    `json:"contentType"`
+	ETag        string    `json:"etag"`
+	Size        int64     `json:"size"`
+	Modified    time.Time `json:"modified"`
+}
+
+// Cache is an on-disk image cache bounded by the total size of the images in
+// it. When the cache is full the least recently used images are evicted.
+type Cache struct {
+	dir     string
+	maxSize int64
+
+	mu      sync.Mutex
+	size    int64
+	lru     *list.List
+	entries map[string]*list.Element
+}
+
+// New creates a new cache in the given directory, loading any images that were
+// previously cached there.
+func New(dir string, maxSize int64) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	c := &Cache{
+		dir:     dir,
+		maxSize: maxSize,
+		lru:     list.New(),
+		entries: make(map[string]*list.Element),
+	}
+
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// load reads the metadata of all previously cached images, most recently
+// modified first, and evicts any that don't fit.
+func (c *Cache) load() error {
+	metaPaths, err := filepath.Glob(filepath.Join(c.dir, "*"+metaSuffix))
+	if err != nil {
+		return err
+	}
+
+	var loaded []Entry
+	for _, metaPath := range metaPaths {
+		f, err := os.Open(metaPath)
+		if err != nil {
+			return err
+		}
+
+		var e Entry
+		err = json.NewDecoder(f).Decode(&e)
+		f.Close()
+
+		if err != nil || fileName(e.Key)+metaSuffix != filepath.Base(metaPath) {
+			os.Remove(metaPath)
+			os.Remove(strings.TrimSuffix(metaPath, metaSuffix))
+			continue
+		}
+
+		if fi, err := os.Stat(c.path(e.Key)); err != nil || fi.Size() != e.Size {
+			os.Remove(metaPath)
+			os.Remove(c.path(e.Key))
+			continue
+		}
+
+		loaded = append(loaded, e)
+	}
+
+	sort.Slice(loaded, func(i, j int) bool { return loaded[i].Modified.After(loaded[j].Modified) })
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, e := range loaded {
+		c.entries[e.Key] = c.lru.PushBack(e)
+		c.size += e.Size
+	}
+	c.evict()
+
+	return nil
+}
+
+// Get returns the entry for a cached image and marks it as recently used.
+func (c *Cache) Get(key string) (Entry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return Entry{}, false
+	}
+
+	c.lru.MoveToFront(el)
+	return el.Value.(Entry), true
+}
+
+// Open opens the cached image for an entry.
+func (c *Cache) Open(e Entry) (*os.File, error) {
+	return os.Open(c.path(e.Key))
+}
+
+// Put stores an image in the cache, evicting the least recently used images if
+// there is not enough room for it.
+func (c *Cache) Put(key, contentType string, r io.Reader) (Entry, error) {
+	tmp, err := ioutil.TempFile(c.dir, "tmp-")
+	if err != nil {
+		return Entry{}, err
+	}
+	defer os.Remove(tmp.Name())
+
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(tmp, h), io.LimitReader(r, c.maxSize+1))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return Entry{}, err
+	}
+
+	if n > c.maxSize {
+		return Entry{}, ErrTooLarge
+	}
+
+	e := Entry{
+		Key:         key,
+		ContentType: contentType,
+		ETag:        `"` + hex.EncodeToString(h.Sum(nil)) + `"`,
+		Size:        n,
+		Modified:    time.Now(),
+	}
+
+	meta, err := json.Marshal(e)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := os.Rename(tmp.Name(), c.path(key)); err != nil {
+		return Entry{}, err
+	}
+
+	if err := ioutil.WriteFile(c.path(key)+metaSuffix, meta, 0644); err != nil {
+		os.Remove(c.path(key))
+		return Entry{}, err
+	}
+
+	if el, ok := c.entries[key]; ok {
+		c.size -= el.Value.(Entry).Size
+		c.lru.Remove(el)
+	}
+
+	c.entries[key] = c.lru.PushFront(e)
+	c.size += e.Size
+	c.evict()
+
+	return e, nil
+}
+
+// Size returns the total size of all cached images.
+func (c *Cache) Size() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.size
+}
+
+// evict removes the least recently used images until the cache is within its
+// maximum size. The caller must hold c.mu.
+func (c *Cache) evict() {
+	for c.size > c.maxSize {
+		el := c.lru.Back()
+		if el == nil {
+			return
+		}
+
+		e := c.lru.Remove(el).(Entry)
+		delete(c.entries, e.Key)
+		c.size -= e.Size
+
+		os.Remove(c.path(e.Key))
+		os.Remove(c.path(e.Key) + metaSuffix)
+	}
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, fileName(key))
+}
+
+func fileName(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
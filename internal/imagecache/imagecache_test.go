@@ -0,0 +1,84 @@
+package imagecache
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPutGet(t *testing.T) {
+	dir, err := ioutil.TempDir("", "imagecache")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer os.RemoveAll(dir)
+
+	c, err := New(dir, 10)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	e, err := c.Put("a", "image/png", strings.NewReader("aaaa"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), e.Size)
+	assert.Equal(t, "image/png", e.ContentType)
+
+	got, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, e.ETag, got.ETag)
+
+	f, err := c.Open(got)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer f.Close()
+
+	data, err := ioutil.ReadAll(f)
+	assert.NoError(t, err)
+	assert.Equal(t, "aaaa", string(data))
+
+	_, err = c.Put("big", "image/png", strings.NewReader("01234567890"))
+	assert.Equal(t, ErrTooLarge, err)
+}
+
+func TestEviction(t *testing.T) {
+	dir, err := ioutil.TempDir("", "imagecache")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer os.RemoveAll(dir)
+
+	c, err := New(dir, 10)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	for _, key := range []string{"a", "b"} {
+		_, err := c.Put(key, "image/jpeg", strings.NewReader("xxxx"))
+		assert.NoError(t, err)
+	}
+
+	_, ok := c.Get("a") // a is now more recently used than b
+	assert.True(t, ok)
+
+	_, err = c.Put("c", "image/jpeg", strings.NewReader("xxxx"))
+	assert.NoError(t, err)
+
+	_, ok = c.Get("b")
+	assert.False(t, ok)
+	_, ok = c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, int64(8), c.Size())
+
+	reloaded, err := New(dir, 10)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	_, ok = reloaded.Get("c")
+	assert.True(t, ok)
+	assert.Equal(t, int64(8), reloaded.Size())
+}
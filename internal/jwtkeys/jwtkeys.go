@@ -0,0 +1,332 @@
+package jwtkeys
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Pigmice2733/scouting-backend/internal/store/signingkey"
+	jwt "github.com/dgrijalva/jwt-go"
+	"golang.org/x/crypto/ed25519"
+)
+
+// Supported signing algorithms.
+const (
+	HS256 = "HS256"
+	RS256 = "RS256"
+	EdDSA = "EdDSA"
+)
+
+// minReloadInterval limits how often tokens with unknown key IDs can cause the
+// keys to be reloaded.
+const minReloadInterval = time.Minute
+
+var (
+	// ErrUnknownKey is returned when a token was signed with a key that is not
+	// in the set, or that was retired too long ago.
+	ErrUnknownKey = fmt.Errorf("jwtkeys: unknown key")
+	// ErrNoSigningKey is returned when there are no active keys to sign tokens
+	// with.
+	ErrNoSigningKey = fmt.Errorf("jwtkeys: no active signing key")
+	// ErrUnsupportedAlgorithm is returned for keys with an algorithm other than
+	// HS256, RS256 or EdDSA.
+	ErrUnsupportedAlgorithm = fmt.Errorf("jwtkeys: unsupported algorithm")
+)
+
+// A Loader loads all signing keys, including retired ones.
+type Loader func() ([]signingkey.Key, error)
+
+type key struct {
+	signingkey.Key
+	method    jwt.SigningMethod
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+// Set is a set of keys for signing and verifying jwts. Tokens are signed with
+// the newest active key, and can be verified with any key that is active or was
+// retired less than a grace period ago.
+type Set struct {
+	load  Loader
+	grace time.Duration
+
+	mu       sync.RWMutex
+	keys     map[string]key
+	signing  *key
+	lastLoad time.Time
+}
+
+// New creates a new key set, loading the keys with the given loader.
+func New(load Loader, grace time.Duration) (*Set, error) {
+	s := &Set{load: load, grace: grace}
+	return s, s.Reload()
+}
+
+// Reload loads the keys of the set again.
+func (s *Set) Reload() error {
+	loaded, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	keys := make(map[string]key)
+	var signing *key
+
+	for _, sk := range loaded {
+		if sk.Retired != nil && time.Since(*sk.Retired) > s.grace {
+			continue
+		}
+
+		k, err := parse(sk)
+		if err != nil {
+			return fmt.Errorf("parsing key '%s': %v", sk.ID, err)
+		}
+		keys[k.ID] = k
+
+		if k.Retired == nil && (signing == nil || k.Created.After(signing.Created)) {
+			signing = &k
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.keys = keys
+	s.signing = signing
+	s.lastLoad = time.Now()
+
+	return nil
+}
+
+// Sign signs the claims with the newest active key, setting the kid header of
+// the token.
+func (s *Set) Sign(claims jwt.Claims) (string, error) {
+	s.mu.RLock()
+	signing := s.signing
+	s.mu.RUnlock()
+
+	if signing == nil {
+		return "", ErrNoSigningKey
+	}
+
+	token := jwt.NewWithClaims(signing.method, claims)
+	token.Header["kid"] = signing.ID
+
+	return token.SignedString(signing.signKey)
+}
+
+// Keyfunc finds the key to verify a token with by its kid header. It can be
+// passed to jwt.Parse. If the key is not known the keys are reloaded, since it
+// may have been added by another server.
+func (s *Set) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	k, ok := s.lookup(kid)
+	if !ok && s.canReload() {
+		if err := s.Reload(); err != nil {
+			return nil, err
+		}
+		k, ok = s.lookup(kid)
+	}
+
+	if !ok || (k.Retired != nil && time.Since(*k.Retired) > s.grace) {
+		return nil, ErrUnknownKey
+	}
+
+	if token.Method.Alg() != k.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+
+	return k.verifyKey, nil
+}
+
+func (s *Set) lookup(kid string) (key, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	k, ok := s.keys[kid]
+	return k, ok
+}
+
+func (s *Set) canReload() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return time.Since(s.lastLoad) > minReloadInterval
+}
+
+// JWK is a JSON web key holding the public part of a signing key.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	ID        string `json:"kid"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+}
+
+// JWKS is a JSON web key set.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public keys of all asymmetric keys in the set, so that other
+// services can verify tokens. HS256 keys are secret and are never included.
+func (s *Set) JWKS() JWKS {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	jwks := JWKS{Keys: []JWK{}}
+
+	for _, k := range s.keys {
+		jwk := JWK{Use: "sig", Algorithm: k.Algorithm, ID: k.ID}
+
+		switch verifyKey := k.verifyKey.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(verifyKey.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(bigEndian(verifyKey.E))
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(verifyKey)
+		default:
+			continue
+		}
+
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+
+	return jwks
+}
+
+// Generate creates a new key with a random ID for an algorithm.
+func Generate(algorithm string) (signingkey.Key, error) {
+	k := signingkey.Key{Algorithm: algorithm, Created: time.Now()}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return k, err
+	}
+	k.ID = base64.RawURLEncoding.EncodeToString(id)
+
+	switch algorithm {
+	case HS256:
+		k.PrivateKey = make([]byte, 64)
+		if _, err := rand.Read(k.PrivateKey); err != nil {
+			return k, err
+		}
+	case RS256:
+		privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return k, err
+		}
+		k.PrivateKey = x509.MarshalPKCS1PrivateKey(privateKey)
+	case EdDSA:
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return k, err
+		}
+		k.PrivateKey = privateKey
+	default:
+		return k, ErrUnsupportedAlgorithm
+	}
+
+	return k, nil
+}
+
+// FileLoader returns a loader that reads keys from a JSON file holding an array
+// of keys with base64 encoded private keys. RS256 private keys may be PKCS #1 or
+// PKCS #8 DER, or PEM.
+func FileLoader(path string) Loader {
+	return func() ([]signingkey.Key, error) {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		var keys []signingkey.Key
+		err = json.NewDecoder(f).Decode(&keys)
+		return keys, err
+	}
+}
+
+func parse(sk signingkey.Key) (key, error) {
+	k := key{Key: sk}
+
+	if sk.ID == "" {
+		return k, fmt.Errorf("missing key id")
+	}
+
+	switch sk.Algorithm {
+	case HS256:
+		if len(sk.PrivateKey) < 32 {
+			return k, fmt.Errorf("HS256 keys must be at least 32 bytes")
+		}
+		k.method = jwt.SigningMethodHS256
+		k.signKey, k.verifyKey = sk.PrivateKey, sk.PrivateKey
+	case RS256:
+		privateKey, err := parseRSAPrivateKey(sk.PrivateKey)
+		if err != nil {
+			return k, err
+		}
+		k.method = jwt.SigningMethodRS256
+		k.signKey, k.verifyKey = privateKey, &privateKey.PublicKey
+	case EdDSA:
+		var privateKey ed25519.PrivateKey
+		switch len(sk.PrivateKey) {
+		case ed25519.SeedSize:
+			privateKey = ed25519.NewKeyFromSeed(sk.PrivateKey)
+		case ed25519.PrivateKeySize:
+			privateKey = ed25519.PrivateKey(sk.PrivateKey)
+		default:
+			return k, fmt.Errorf("invalid ed25519 key size: %d", len(sk.PrivateKey))
+		}
+		k.method = SigningMethodEdDSA
+		k.signKey, k.verifyKey = privateKey, privateKey.Public().(ed25519.PublicKey)
+	default:
+		return k, ErrUnsupportedAlgorithm
+	}
+
+	return k, nil
+}
+
+func parseRSAPrivateKey(der []byte) (*rsa.PrivateKey, error) {
+	if block, _ := pem.Decode(der); block != nil {
+		der = block.Bytes
+	}
+
+	if privateKey, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return privateKey, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("not an RSA private key")
+	}
+
+	return privateKey, nil
+}
+
+func bigEndian(n int) []byte {
+	var b []byte
+	for ; n > 0; n >>= 8 {
+		b = append([]byte{byte(n)}, b...)
+	}
+	return b
+}
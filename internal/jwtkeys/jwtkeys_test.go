@@ -0,0 +1,180 @@
+package jwtkeys
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pigmice2733/scouting-backend/internal/store/signingkey"
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func staticLoader(keys ...signingkey.Key) Loader {
+	return func() ([]signingkey.Key, error) {
+		return keys, nil
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	for _, alg := range []string{HS256, RS256, EdDSA} {
+		k, err := Generate(alg)
+		if !assert.NoError(t, err, alg) {
+			continue
+		}
+
+		s, err := New(staticLoader(k), time.Hour)
+		if !assert.NoError(t, err, alg) {
+			continue
+		}
+
+		ss, err := s.Sign(jwt.MapClaims{"sub": "frank"})
+		if !assert.NoError(t, err, alg) {
+			continue
+		}
+
+		token, err := jwt.Parse(ss, s.Keyfunc)
+		if !assert.NoError(t, err, alg) {
+			continue
+		}
+		assert.True(t, token.Valid, alg)
+		assert.Equal(t, k.ID, token.Header["kid"], alg)
+		assert.Equal(t, alg, token.Method.Alg(), alg)
+	}
+}
+
+func TestSigningKeyIsNewestActive(t *testing.T) {
+	retired := time.Now()
+
+	oldest, _ := Generate(EdDSA)
+	oldest.Created = retired.Add(-time.Hour * 2)
+	newest, _ := Generate(EdDSA)
+	newest.Created = retired.Add(-time.Hour)
+	retiredKey, _ := Generate(EdDSA)
+	retiredKey.Retired = &retired
+
+	s, err := New(staticLoader(oldest, retiredKey, newest), time.Hour)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	ss, err := s.Sign(jwt.MapClaims{})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	token, _ := jwt.Parse(ss, s.Keyfunc)
+	assert.Equal(t, newest.ID, token.Header["kid"])
+}
+
+func TestRetiredKeys(t *testing.T) {
+	k, _ := Generate(HS256)
+
+	s, err := New(staticLoader(k), time.Hour)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	ss, err := s.Sign(jwt.MapClaims{})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	recent := time.Now().Add(-time.Minute)
+	k.Retired = &recent
+	s.load = staticLoader(k)
+	assert.NoError(t, s.Reload())
+
+	_, err = jwt.Parse(ss, s.Keyfunc)
+	assert.NoError(t, err, "keys within the grace period should still verify")
+
+	_, err = s.Sign(jwt.MapClaims{})
+	assert.Equal(t, ErrNoSigningKey, err)
+
+	old := time.Now().Add(-time.Hour * 2)
+	k.Retired = &old
+	s.load = staticLoader(k)
+	assert.NoError(t, s.Reload())
+
+	_, err = jwt.Parse(ss, s.Keyfunc)
+	assert.Error(t, err)
+}
+
+func TestUnknownKeyReloads(t *testing.T) {
+	a, _ := Generate(EdDSA)
+	b, _ := Generate(EdDSA)
+	b.Created = a.Created.Add(time.Second)
+
+	other, err := New(staticLoader(b), time.Hour)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	ss, err := other.Sign(jwt.MapClaims{})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	s, err := New(staticLoader(a), time.Hour)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	s.load = staticLoader(a, b)
+	_, err = jwt.Parse(ss, s.Keyfunc)
+	assert.Error(t, err, "reloads should be rate limited")
+
+	s.lastLoad = time.Now().Add(-minReloadInterval * 2)
+	_, err = jwt.Parse(ss, s.Keyfunc)
+	assert.NoError(t, err)
+}
+
+func TestAlgorithmMismatch(t *testing.T) {
+	k, _ := Generate(RS256)
+
+	s, err := New(staticLoader(k), time.Hour)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	// a token claiming HS256 for an RS256 key must not verify
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{})
+	token.Header["kid"] = k.ID
+	ss, err := token.SignedString([]byte("public key"))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	_, err = jwt.Parse(ss, s.Keyfunc)
+	assert.Error(t, err)
+}
+
+func TestJWKS(t *testing.T) {
+	hs, _ := Generate(HS256)
+	rs, _ := Generate(RS256)
+	ed, _ := Generate(EdDSA)
+
+	s, err := New(staticLoader(hs, rs, ed), time.Hour)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	jwks := s.JWKS()
+	if !assert.Len(t, jwks.Keys, 2) {
+		t.FailNow()
+	}
+
+	for _, jwk := range jwks.Keys {
+		switch jwk.ID {
+		case rs.ID:
+			assert.Equal(t, "RSA", jwk.KeyType)
+			assert.Equal(t, "AQAB", jwk.E)
+			assert.NotEmpty(t, jwk.N)
+		case ed.ID:
+			assert.Equal(t, "OKP", jwk.KeyType)
+			assert.Equal(t, "Ed25519", jwk.Curve)
+			assert.Len(t, jwk.X, 43)
+		default:
+			t.Errorf("unexpected key in jwks: %s", jwk.ID)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package respond
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// writeCSV writes a payload decoded from JSON as CSV. An array of objects is
+// written as a row per object, and anything else as a single row. Nested
+// objects are flattened into columns named with dotted paths, and arrays of
+// values are joined with semicolons.
+func writeCSV(w *bytes.Buffer, v interface{}) error {
+	items, ok := v.([]interface{})
+	if !ok {
+		items = []interface{}{v}
+	}
+
+	rows := make([]map[string]string, len(items))
+	columnSet := make(map[string]bool)
+
+	for i, item := range items {
+		rows[i] = make(map[string]string)
+		flatten(rows[i], "", item)
+
+		for column := range rows[i] {
+			columnSet[column] = true
+		}
+	}
+
+	columns := make([]string, 0, len(columnSet))
+	for column := range columnSet {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = CSVText(column)
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		record := make([]string, len(columns))
+		for i, column := range columns {
+			record[i] = row[column]
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func flatten(row map[string]string, prefix string, v interface{}) {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for _, k := range sortedKeys(value) {
+			key := k
+			if prefix != "" {
+				key = prefix + "." + k
+			}
+			flatten(row, key, value[k])
+		}
+	case []interface{}:
+		parts := make([]string, len(value))
+		for i, item := range value {
+			parts[i] = cell(item)
+		}
+		row[columnName(prefix)] = strings.Join(parts, ";")
+	default:
+		row[columnName(prefix)] = cell(value)
+	}
+}
+
+// columnName names the column of a value that isn't in an object.
+func columnName(prefix string) string {
+	if prefix == "" {
+		return "value"
+	}
+	return prefix
+}
+
+func cell(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return CSVText(value)
+	case json.Number:
+		return value.String()
+	case bool:
+		return fmt.Sprint(value)
+	default:
+		b, _ := json.Marshal(value)
+		return string(b)
+	}
+}
+
+// CSVText escapes text for a CSV cell. Spreadsheets evaluate text starting
+// with =, +, - or @ as a formula, so text that does is prefixed with a ' to
+// keep notes and names from running as formulas when the CSV is opened.
+// Numbers shouldn't be escaped, so that negative numbers stay numbers.
+func CSVText(text string) string {
+	if text != "" && strings.ContainsRune("=+-@\t\r", rune(text[0])) {
+		return "'" + text
+	}
+	return text
+}
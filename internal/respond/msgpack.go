@@ -0,0 +1,100 @@
+package respond
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// writeMsgpack writes a payload decoded from JSON as MessagePack. Numbers are
+// written as integers when they are whole, and as 64 bit floats otherwise.
+// Map keys are written in order.
+func writeMsgpack(w *bytes.Buffer, v interface{}) error {
+	switch value := v.(type) {
+	case nil:
+		w.WriteByte(0xc0)
+	case bool:
+		if value {
+			w.WriteByte(0xc3)
+		} else {
+			w.WriteByte(0xc2)
+		}
+	case json.Number:
+		if i, err := value.Int64(); err == nil {
+			writeMsgpackInt(w, i)
+			return nil
+		}
+		f, err := value.Float64()
+		if err != nil {
+			return err
+		}
+		w.WriteByte(0xcb)
+		binary.Write(w, binary.BigEndian, math.Float64bits(f))
+	case string:
+		writeMsgpackHeader(w, len(value), 0xa0, 31, 0xd9, 0xda, 0xdb)
+		w.WriteString(value)
+	case []interface{}:
+		writeMsgpackHeader(w, len(value), 0x90, 15, 0, 0xdc, 0xdd)
+		for _, item := range value {
+			if err := writeMsgpack(w, item); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		writeMsgpackHeader(w, len(value), 0x80, 15, 0, 0xde, 0xdf)
+		for _, k := range sortedKeys(value) {
+			if err := writeMsgpack(w, k); err != nil {
+				return err
+			}
+			if err := writeMsgpack(w, value[k]); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("can't encode %T as msgpack", v)
+	}
+
+	return nil
+}
+
+func writeMsgpackInt(w *bytes.Buffer, i int64) {
+	switch {
+	case i >= 0 && i <= 127:
+		w.WriteByte(byte(i))
+	case i < 0 && i >= -32:
+		w.WriteByte(byte(int8(i)))
+	case i >= math.MinInt8 && i <= math.MaxInt8:
+		w.WriteByte(0xd0)
+		w.WriteByte(byte(int8(i)))
+	case i >= math.MinInt16 && i <= math.MaxInt16:
+		w.WriteByte(0xd1)
+		binary.Write(w, binary.BigEndian, int16(i))
+	case i >= math.MinInt32 && i <= math.MaxInt32:
+		w.WriteByte(0xd2)
+		binary.Write(w, binary.BigEndian, int32(i))
+	default:
+		w.WriteByte(0xd3)
+		binary.Write(w, binary.BigEndian, i)
+	}
+}
+
+// writeMsgpackHeader writes the type and length of a string, array or map,
+// using the fix format if the length fits in it. Arrays and maps have no 8 bit
+// length format, which is passed as 0.
+func writeMsgpackHeader(w *bytes.Buffer, n int, fix byte, fixMax int, b8, b16, b32 byte) {
+	switch {
+	case n <= fixMax:
+		w.WriteByte(fix | byte(n))
+	case b8 != 0 && n <= math.MaxUint8:
+		w.WriteByte(b8)
+		w.WriteByte(byte(n))
+	case n <= math.MaxUint16:
+		w.WriteByte(b16)
+		binary.Write(w, binary.BigEndian, uint16(n))
+	default:
+		w.WriteByte(b32)
+		binary.Write(w, binary.BigEndian, uint32(n))
+	}
+}
@@ -0,0 +1,51 @@
+package respond
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteMsgpack(t *testing.T) {
+	cases := []struct {
+		value   interface{}
+		encoded []byte
+	}{
+		{nil, []byte{0xc0}},
+		{false, []byte{0xc2}},
+		{json.Number("0"), []byte{0x00}},
+		{json.Number("127"), []byte{0x7f}},
+		{json.Number("-32"), []byte{0xe0}},
+		{json.Number("-33"), []byte{0xd0, 0xdf}},
+		{json.Number("200"), []byte{0xd1, 0x00, 0xc8}},
+		{json.Number("70000"), []byte{0xd2, 0x00, 0x01, 0x11, 0x70}},
+		{json.Number("5000000000"), []byte{0xd3, 0x00, 0x00, 0x00, 0x01, 0x2a, 0x05, 0xf2, 0x00}},
+		{json.Number("1.5"), []byte{0xcb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0}},
+		{"", []byte{0xa0}},
+		{[]interface{}{json.Number("1"), "a"}, []byte{0x92, 0x01, 0xa1, 'a'}},
+		{map[string]interface{}{}, []byte{0x80}},
+	}
+
+	for _, c := range cases {
+		var b bytes.Buffer
+		assert.Nil(t, writeMsgpack(&b, c.value))
+		assert.Equal(t, c.encoded, b.Bytes(), "%v", c.value)
+	}
+}
+
+func TestWriteMsgpackLengths(t *testing.T) {
+	var b bytes.Buffer
+	assert.Nil(t, writeMsgpack(&b, strings.Repeat("a", 32)))
+	assert.Equal(t, []byte{0xd9, 32}, b.Bytes()[:2])
+
+	b.Reset()
+	assert.Nil(t, writeMsgpack(&b, strings.Repeat("a", 256)))
+	assert.Equal(t, []byte{0xda, 0x01, 0x00}, b.Bytes()[:3])
+
+	b.Reset()
+	assert.Nil(t, writeMsgpack(&b, make([]interface{}, 16)))
+	assert.Equal(t, []byte{0xdc, 0x00, 0x10}, b.Bytes()[:3])
+}
@@ -0,0 +1,157 @@
+package respond
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Content types that responses can be negotiated to.
+const (
+	JSONContentType    = "application/json"
+	CSVContentType     = "text/csv"
+	MsgpackContentType = "application/msgpack"
+)
+
+// encoders write a payload that has been decoded from JSON into generic values.
+var encoders = map[string]func(w *bytes.Buffer, v interface{}) error{
+	JSONContentType: func(w *bytes.Buffer, v interface{}) error {
+		return json.NewEncoder(w).Encode(v)
+	},
+	CSVContentType:     writeCSV,
+	MsgpackContentType: writeMsgpack,
+}
+
+// aliases are other names clients use for the content types.
+var aliases = map[string]string{
+	"application/x-msgpack": MsgpackContentType,
+	"text/json":             JSONContentType,
+}
+
+// order is the preference of the server when the client likes several content
+// types equally.
+var order = []string{JSONContentType, MsgpackContentType, CSVContentType}
+
+// Negotiate responds with a payload in the format the Accept header of the
+// request prefers, out of JSON, CSV and MessagePack. JSON is used if there's
+// no Accept header. If the client accepts none of the formats, a 406 problem
+// is sent instead.
+func Negotiate(w http.ResponseWriter, r *http.Request, payload interface{}) {
+	w.Header().Add("Vary", "Accept")
+
+	contentType, ok := Accepted(r.Header.Get("Accept"))
+	if !ok {
+		ErrorCode(w, http.StatusNotAcceptable, StatusCode(http.StatusNotAcceptable), "supported content types are "+strings.Join(order, ", "))
+		return
+	}
+
+	if contentType == JSONContentType {
+		JSON(w, payload)
+		return
+	}
+
+	// other formats are encoded from the JSON representation of the payload,
+	// so that they use the same field names
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	var generic interface{}
+	d := json.NewDecoder(bytes.NewReader(raw))
+	d.UseNumber()
+	if err := d.Decode(&generic); err != nil {
+		Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := encoders[contentType](&buf, generic); err != nil {
+		Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Write(buf.Bytes())
+}
+
+type acceptRange struct {
+	mediaType string
+	q         float64
+}
+
+// Accepted gets the content type a client prefers out of the supported ones,
+// from the value of an Accept header.
+func Accepted(accept string) (string, bool) {
+	if strings.TrimSpace(accept) == "" {
+		return JSONContentType, true
+	}
+
+	var ranges []acceptRange
+	for _, part := range strings.Split(accept, ",") {
+		params := strings.Split(part, ";")
+
+		ar := acceptRange{mediaType: strings.ToLower(strings.TrimSpace(params[0])), q: 1}
+		if alias, ok := aliases[ar.mediaType]; ok {
+			ar.mediaType = alias
+		}
+
+		for _, param := range params[1:] {
+			kv := strings.SplitN(strings.TrimSpace(param), "=", 2)
+			if len(kv) == 2 && strings.ToLower(kv[0]) == "q" {
+				if q, err := strconv.ParseFloat(kv[1], 64); err == nil {
+					ar.q = q
+				}
+			}
+		}
+
+		ranges = append(ranges, ar)
+	}
+
+	best, bestQ := "", 0.0
+	for _, contentType := range order {
+		// the most specific range matching a content type decides its quality
+		q, specificity := 0.0, -1
+		for _, ar := range ranges {
+			s := matches(ar.mediaType, contentType)
+			if s > specificity {
+				q, specificity = ar.q, s
+			}
+		}
+
+		if specificity >= 0 && q > bestQ {
+			best, bestQ = contentType, q
+		}
+	}
+
+	return best, best != ""
+}
+
+// matches returns how specifically a media range matches a content type: 2
+// for an exact match, 1 for type/*, 0 for */*, or -1 if it doesn't match.
+func matches(mediaRange, contentType string) int {
+	switch {
+	case mediaRange == contentType:
+		return 2
+	case mediaRange == "*/*":
+		return 0
+	case strings.HasSuffix(mediaRange, "/*") && strings.HasPrefix(contentType, strings.TrimSuffix(mediaRange, "*")):
+		return 1
+	default:
+		return -1
+	}
+}
+
+// sortedKeys gets the keys of a map in order.
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,106 @@
+package respond
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccepted(t *testing.T) {
+	cases := []struct {
+		accept      string
+		contentType string
+		ok          bool
+	}{
+		{"", JSONContentType, true},
+		{"*/*", JSONContentType, true},
+		{"application/json", JSONContentType, true},
+		{"text/csv", CSVContentType, true},
+		{"text/*", CSVContentType, true},
+		{"application/msgpack", MsgpackContentType, true},
+		{"application/x-msgpack", MsgpackContentType, true},
+		{"application/*", JSONContentType, true},
+		{"text/csv;q=0.5, application/msgpack;q=0.8", MsgpackContentType, true},
+		{"application/json;q=0.1, */*;q=0.5", MsgpackContentType, true},
+		{"*/*, application/json;q=0", MsgpackContentType, true},
+		{"text/html, application/xml", "", false},
+		{"application/json;q=0", "", false},
+	}
+
+	for _, c := range cases {
+		contentType, ok := Accepted(c.accept)
+		assert.Equal(t, c.ok, ok, c.accept)
+		assert.Equal(t, c.contentType, contentType, c.accept)
+	}
+}
+
+type payload struct {
+	Team  string             `json:"team"`
+	Stats map[string]float64 `json:"stats"`
+	Tags  []string           `json:"tags"`
+	Notes *string            `json:"notes"`
+}
+
+var payloads = []payload{
+	{Team: "frc2733", Stats: map[string]float64{"cubes": 4.5, "climbed": 1}, Tags: []string{"tipped", "defended"}},
+	{Team: "frc254", Stats: map[string]float64{"cubes": 10}},
+}
+
+func negotiate(accept string, v interface{}) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/", nil)
+	if accept != "" {
+		r.Header.Set("Accept", accept)
+	}
+	Negotiate(w, r, v)
+	return w
+}
+
+func TestNegotiateJSON(t *testing.T) {
+	w := negotiate("", payloads[1])
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, JSONContentType, w.Header().Get("Content-Type"))
+	assert.Equal(t, "Accept", w.Header().Get("Vary"))
+	assert.Equal(t, `{"team":"frc254","stats":{"cubes":10},"tags":null,"notes":null}`+"\n", w.Body.String())
+}
+
+func TestNegotiateCSV(t *testing.T) {
+	w := negotiate("text/csv", payloads)
+
+	assert.Equal(t, CSVContentType, w.Header().Get("Content-Type"))
+	assert.Equal(t, "notes,stats.climbed,stats.cubes,tags,team\n,1,4.5,tipped;defended,frc2733\n,,10,,frc254\n", w.Body.String())
+
+	w = negotiate("text/csv", []string{"frc2733", "frc254"})
+	assert.Equal(t, "value\nfrc2733\nfrc254\n", w.Body.String())
+}
+
+func TestNegotiateCSVFormulas(t *testing.T) {
+	notes := `=HYPERLINK("http://example.com")`
+	w := negotiate("text/csv", []payload{
+		{Team: "+frc2733", Stats: map[string]float64{"-cubes": -2}, Tags: []string{"@tipped", "=defended"}, Notes: &notes},
+	})
+
+	assert.Equal(t, "notes,stats.-cubes,tags,team\n\"'=HYPERLINK(\"\"http://example.com\"\")\",-2,'@tipped;'=defended,'+frc2733\n", w.Body.String())
+}
+
+func TestNegotiateMsgpack(t *testing.T) {
+	w := negotiate("application/msgpack", map[string]interface{}{"team": "frc254", "reports": 3, "ok": true})
+
+	assert.Equal(t, MsgpackContentType, w.Header().Get("Content-Type"))
+	assert.Equal(t, []byte{
+		0x83,
+		0xa2, 'o', 'k', 0xc3,
+		0xa7, 'r', 'e', 'p', 'o', 'r', 't', 's', 0x03,
+		0xa4, 't', 'e', 'a', 'm', 0xa6, 'f', 'r', 'c', '2', '5', '4',
+	}, w.Body.Bytes())
+}
+
+func TestNegotiateNotAcceptable(t *testing.T) {
+	w := negotiate("text/html", payloads)
+
+	assert.Equal(t, http.StatusNotAcceptable, w.Code)
+	assert.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+}
@@ -0,0 +1,56 @@
+package respond
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// ProblemContentType is the content type of problem details (RFC 7807).
+const ProblemContentType = "application/problem+json"
+
+// Problem holds the details of an error response as described by RFC 7807,
+// with a machine-readable code as an extension member.
+type Problem struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Code   string `json:"code"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// StatusCode gets the default machine-readable code of an HTTP status, which
+// is its status text in lowercase with dashes, like "not-found".
+func StatusCode(status int) string {
+	text := http.StatusText(status)
+	if text == "" {
+		return "unknown-error"
+	}
+	return strings.Replace(strings.ToLower(strings.Replace(text, "'", "", -1)), " ", "-", -1)
+}
+
+// Error responds with the problem details of an HTTP status, using the status'
+// default code.
+func Error(w http.ResponseWriter, status int) {
+	ErrorCode(w, status, StatusCode(status), "")
+}
+
+// ErrorCode responds with the problem details of an HTTP status, with a more
+// specific code and an explanation for the client.
+func ErrorCode(w http.ResponseWriter, status int, code, detail string) {
+	WriteProblem(w, Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Code:   code,
+		Detail: detail,
+	})
+}
+
+// WriteProblem responds with problem details.
+func WriteProblem(w http.ResponseWriter, p Problem) {
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+	json.NewEncoder(w).Encode(p)
+}
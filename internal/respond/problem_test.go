@@ -0,0 +1,33 @@
+package respond
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusCode(t *testing.T) {
+	assert.Equal(t, "not-found", StatusCode(http.StatusNotFound))
+	assert.Equal(t, "internal-server-error", StatusCode(http.StatusInternalServerError))
+	assert.Equal(t, "im-a-teapot", StatusCode(http.StatusTeapot))
+	assert.Equal(t, "unknown-error", StatusCode(599))
+}
+
+func TestError(t *testing.T) {
+	w := httptest.NewRecorder()
+	Error(w, http.StatusForbidden)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+	assert.Equal(t, `{"type":"about:blank","title":"Forbidden","status":403,"code":"forbidden"}`+"\n", w.Body.String())
+}
+
+func TestErrorCode(t *testing.T) {
+	w := httptest.NewRecorder()
+	ErrorCode(w, http.StatusConflict, "picklist-version-conflict", "picklist was changed since version 3")
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, `{"type":"about:blank","title":"Conflict","status":409,"code":"picklist-version-conflict","detail":"picklist was changed since version 3"}`+"\n", w.Body.String())
+}
@@ -0,0 +1,209 @@
+package schedule
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Pigmice2733/scouting-backend/internal/archive"
+	"github.com/Pigmice2733/scouting-backend/internal/store/match"
+)
+
+// fmsTimeLayouts are the layouts start times in FMS schedules are parsed with.
+var fmsTimeLayouts = []string{
+	"Mon 1/2/2006 3:04 PM",
+	"1/2/2006 3:04 PM",
+	"1/2/2006 15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	time.RFC3339,
+}
+
+var (
+	fmsQualification = regexp.MustCompile(`^(?:qualification\s+|qm\s*)?(\d+)$`)
+	fmsPlayoff       = regexp.MustCompile(`^(quarterfinal|semifinal)\s+(\d+)(?:\s*[-.]\s*|\s+match\s+)(\d+)$`)
+	fmsFinal         = regexp.MustCompile(`^final\s+(?:match\s+)?(\d+)$`)
+)
+
+// fmsColumns are the columns every FMS schedule must have.
+var fmsColumns = []string{"match", "red 1", "red 2", "red 3", "blue 1", "blue 2", "blue 3"}
+
+// ParseFMS parses a schedule exported from the FMS as CSV into the matches of
+// an event. The first row is a header naming the columns, which are matched
+// case-insensitively: "Match" (or "Description"), "Red 1" to "Red 3" and
+// "Blue 1" to "Blue 3" are required, and "Start Time" (or "Time") is optional.
+// Start times are in the local time of the venue, given by loc. Practice
+// matches are skipped, since they aren't scouted.
+func ParseFMS(r io.Reader, eventKey string, loc *time.Location) ([]archive.Match, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("schedule is empty")
+	} else if err != nil {
+		return nil, fmt.Errorf("reading header: %v", err)
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch name {
+		case "description":
+			name = "match"
+		case "time":
+			name = "start time"
+		}
+		columns[name] = i
+	}
+
+	for _, name := range fmsColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("schedule has no '%s' column", name)
+		}
+	}
+
+	var matches []archive.Match
+	seen := make(map[string]bool)
+
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("reading line %d: %v", line, err)
+		}
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		description := field("match")
+		if description == "" {
+			continue
+		}
+
+		suffix, ok, err := FMSMatchKey(description)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		} else if !ok {
+			continue
+		}
+
+		m := archive.Match{EventKey: eventKey, Match: match.Match{
+			BasicMatch: match.BasicMatch{Key: eventKey + "_" + suffix, EventKey: eventKey, Manual: true},
+		}}
+
+		if seen[m.Key] {
+			return nil, fmt.Errorf("line %d: match '%s' is listed more than once", line, m.Key)
+		}
+		seen[m.Key] = true
+
+		for _, color := range []string{"red", "blue"} {
+			var alliance []string
+			for i := 1; i <= allianceSize; i++ {
+				team, err := fmsTeam(field(fmt.Sprintf("%s %d", color, i)))
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %v", line, err)
+				}
+				if team != "" {
+					alliance = append(alliance, team)
+				}
+			}
+
+			if color == "red" {
+				m.RedAlliance = alliance
+			} else {
+				m.BlueAlliance = alliance
+			}
+		}
+
+		if err := ValidateAlliances(m.RedAlliance, m.BlueAlliance); err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+
+		if startTime := field("start time"); startTime != "" {
+			t, err := parseFMSTime(startTime, loc)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", line, err)
+			}
+			m.PredictedTime = &t
+		}
+
+		matches = append(matches, m)
+	}
+
+	return matches, nil
+}
+
+// FMSMatchKey gets the part of a TBA match key after the event key from the
+// description of an FMS match, like "Qualification 12" or "Semifinal 2-1". It
+// returns false for practice matches.
+func FMSMatchKey(description string) (string, bool, error) {
+	d := strings.ToLower(strings.Join(strings.Fields(description), " "))
+
+	if strings.HasPrefix(d, "practice") {
+		return "", false, nil
+	}
+
+	if m := fmsQualification.FindStringSubmatch(d); m != nil {
+		return "qm" + trimZeros(m[1]), true, nil
+	}
+
+	if m := fmsPlayoff.FindStringSubmatch(d); m != nil {
+		level := "qf"
+		if m[1] == "semifinal" {
+			level = "sf"
+		}
+		return level + trimZeros(m[2]) + "m" + trimZeros(m[3]), true, nil
+	}
+
+	if m := fmsFinal.FindStringSubmatch(d); m != nil {
+		return "f1m" + trimZeros(m[1]), true, nil
+	}
+
+	return "", false, fmt.Errorf("unknown match '%s'", description)
+}
+
+// fmsTeam gets the TBA key of a team from an FMS team number. Surrogate teams
+// are marked with a trailing '*', which is dropped.
+func fmsTeam(number string) (string, error) {
+	number = strings.TrimSuffix(number, "*")
+	if number == "" {
+		return "", nil
+	}
+
+	n, err := strconv.Atoi(number)
+	if err != nil || n <= 0 {
+		return "", fmt.Errorf("invalid team number '%s'", number)
+	}
+
+	return "frc" + strconv.Itoa(n), nil
+}
+
+func parseFMSTime(value string, loc *time.Location) (time.Time, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	for _, layout := range fmsTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid start time '%s'", value)
+}
+
+func trimZeros(number string) string {
+	n, _ := strconv.Atoi(number)
+	return strconv.Itoa(n)
+}
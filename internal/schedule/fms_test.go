@@ -0,0 +1,94 @@
+package schedule
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFMSMatchKey(t *testing.T) {
+	cases := map[string]string{
+		"Qualification 12":    "qm12",
+		"qualification  007":  "qm7",
+		"3":                   "qm3",
+		"QM 4":                "qm4",
+		"Quarterfinal 2-1":    "qf2m1",
+		"Quarterfinal 4 - 3":  "qf4m3",
+		"Semifinal 2.1":       "sf2m1",
+		"Semifinal 1 Match 2": "sf1m2",
+		"Final 3":             "f1m3",
+		"Final Match 1":       "f1m1",
+	}
+
+	for description, expected := range cases {
+		key, ok, err := FMSMatchKey(description)
+		assert.Nil(t, err, description)
+		assert.True(t, ok, description)
+		assert.Equal(t, expected, key, description)
+	}
+
+	_, ok, err := FMSMatchKey("Practice 1")
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	_, _, err = FMSMatchKey("Einstein 1")
+	assert.NotNil(t, err)
+}
+
+func TestParseFMS(t *testing.T) {
+	loc := time.FixedZone("PST", -8*60*60)
+
+	matches, err := ParseFMS(strings.NewReader(`Start Time,Description,Red 1,Red 2,Red 3,Blue 1,Blue 2,Blue 3
+Fri 3/2/2018 8:00 AM,Practice 1,1,2,3,4,5,6
+Fri 3/2/2018 9:00 AM,Qualification 1,2733,1540*,,4488,"1425",360
+Sat 3/3/2018 1:30 PM,Semifinal 1-2,1,2,3,4,5,6
+,,,,,,,
+`), "2018orwil", loc)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	if !assert.Len(t, matches, 2) {
+		return
+	}
+
+	assert.Equal(t, "2018orwil_qm1", matches[0].Key)
+	assert.Equal(t, "2018orwil", matches[0].EventKey)
+	assert.True(t, matches[0].Manual)
+	assert.Equal(t, []string{"frc2733", "frc1540"}, matches[0].RedAlliance)
+	assert.Equal(t, []string{"frc4488", "frc1425", "frc360"}, matches[0].BlueAlliance)
+	if assert.NotNil(t, matches[0].PredictedTime) {
+		assert.True(t, time.Date(2018, 3, 2, 17, 0, 0, 0, time.UTC).Equal(*matches[0].PredictedTime))
+	}
+
+	assert.Equal(t, "2018orwil_sf1m2", matches[1].Key)
+}
+
+func TestParseFMSNoTimes(t *testing.T) {
+	matches, err := ParseFMS(strings.NewReader("match,red 1,red 2,red 3,blue 1,blue 2,blue 3\n1,1,2,3,4,5,6\n"), "2018orwil", nil)
+	if assert.Nil(t, err) && assert.Len(t, matches, 1) {
+		assert.Nil(t, matches[0].PredictedTime)
+	}
+}
+
+func TestParseFMSInvalid(t *testing.T) {
+	header := "Time,Match,Red 1,Red 2,Red 3,Blue 1,Blue 2,Blue 3\n"
+
+	cases := map[string]string{
+		"empty":           "",
+		"missing column":  "Match,Red 1,Red 2,Red 3,Blue 1,Blue 2\n",
+		"unknown match":   header + ",Einstein 1,1,2,3,4,5,6\n",
+		"bad team":        header + ",1,frc1,2,3,4,5,6\n",
+		"duplicate team":  header + ",1,1,2,3,1,5,6\n",
+		"duplicate match": header + ",1,1,2,3,4,5,6\n,Qualification 1,1,2,3,4,5,6\n",
+		"bad time":        header + "noon,1,1,2,3,4,5,6\n",
+		"bad quotes":      header + ",\"1,1,2,3,4,5,6\n",
+	}
+
+	for name, input := range cases {
+		_, err := ParseFMS(strings.NewReader(input), "2018orwil", time.UTC)
+		assert.NotNil(t, err, name)
+	}
+}
@@ -0,0 +1,156 @@
+// Package schedule loads event schedules into a store without the TBA API, so
+// the server can run at venues with no internet connection, and at events that
+// aren't on TBA.
+//
+// A schedule is read from either a JSON file holding events and their matches,
+// or an event bundle exported by another server. The matches of one event can
+// also be parsed from a schedule exported by the FMS.
+package schedule
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/Pigmice2733/scouting-backend/internal/archive"
+	"github.com/Pigmice2733/scouting-backend/internal/bundle"
+	"github.com/Pigmice2733/scouting-backend/internal/store"
+	"github.com/Pigmice2733/scouting-backend/internal/store/event"
+	"github.com/Pigmice2733/scouting-backend/internal/store/match"
+)
+
+// allianceSize is the most teams an alliance can have.
+const allianceSize = 3
+
+// Schedule holds events and their matches.
+type Schedule struct {
+	Events  []event.BasicEvent `json:"events"`
+	Matches []archive.Match    `json:"matches"`
+}
+
+// Validate returns an error if a schedule has events without keys, matches
+// for events that aren't in the schedule, or matches with invalid alliances.
+func (s Schedule) Validate() error {
+	events := make(map[string]bool)
+	for _, e := range s.Events {
+		if e.Key == "" {
+			return fmt.Errorf("event has no key")
+		}
+		if events[e.Key] {
+			return fmt.Errorf("event '%s' is listed more than once", e.Key)
+		}
+		events[e.Key] = true
+	}
+
+	matches := make(map[string]bool)
+	for _, m := range s.Matches {
+		if m.Key == "" {
+			return fmt.Errorf("match in event '%s' has no key", m.EventKey)
+		}
+		if !events[m.EventKey] {
+			return fmt.Errorf("match '%s' is for event '%s', which isn't in the schedule", m.Key, m.EventKey)
+		}
+		if matches[m.Key] {
+			return fmt.Errorf("match '%s' is listed more than once", m.Key)
+		}
+		matches[m.Key] = true
+
+		if err := ValidateAlliances(m.RedAlliance, m.BlueAlliance); err != nil {
+			return fmt.Errorf("match '%s': %v", m.Key, err)
+		}
+	}
+
+	return nil
+}
+
+// ValidateAlliances returns an error if an alliance has too many teams, or a
+// team is in a match more than once.
+func ValidateAlliances(red, blue []string) error {
+	if len(red) > allianceSize || len(blue) > allianceSize {
+		return fmt.Errorf("alliances can't have more than %d teams", allianceSize)
+	}
+
+	seen := make(map[string]bool)
+	for _, team := range append(append([]string{}, red...), blue...) {
+		if team == "" {
+			return fmt.Errorf("alliance has an empty team")
+		}
+		if seen[team] {
+			return fmt.Errorf("team '%s' is in the match more than once", team)
+		}
+		seen[team] = true
+	}
+
+	return nil
+}
+
+// Read reads a schedule from a JSON schedule file or an event bundle. Bundles
+// are recognized by their gzip header. Bundle signatures aren't checked, since
+// the file is given by whoever runs the server.
+func Read(r io.Reader) (Schedule, error) {
+	br := bufio.NewReader(r)
+
+	magic, _ := br.Peek(2)
+	if len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		b, err := bundle.Read(br)
+		if err != nil {
+			return Schedule{}, err
+		}
+		s := FromBundle(b)
+		return s, s.Validate()
+	}
+
+	var s Schedule
+	dec := json.NewDecoder(br)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&s); err != nil {
+		return s, fmt.Errorf("decoding schedule: %v", err)
+	}
+
+	return s, s.Validate()
+}
+
+// FromBundle gets the schedule of the event in a bundle.
+func FromBundle(b bundle.Bundle) Schedule {
+	s := Schedule{Events: []event.BasicEvent{b.Event}}
+	for _, m := range b.Matches {
+		m.EventKey = b.Event.Key
+		s.Matches = append(s.Matches, m)
+	}
+	return s
+}
+
+// Load upserts the events and matches of a schedule into a store, marked as
+// manual so TBA data doesn't overwrite them. It returns the number of events and
+// matches loaded.
+func Load(ctx context.Context, s *store.Service, sch Schedule) (int, int, error) {
+	var events []event.BasicEvent
+	for _, e := range sch.Events {
+		e.Manual = true
+		events = append(events, e)
+	}
+
+	if len(events) > 0 {
+		if _, err := s.Event.MassUpsert(ctx, events); err != nil {
+			return 0, 0, fmt.Errorf("upserting events: %v", err)
+		}
+	}
+
+	var matches []match.Match
+	for _, m := range sch.Matches {
+		mm := m.Match
+		mm.EventKey = m.EventKey
+		mm.Manual = true
+		matches = append(matches, mm)
+	}
+
+	if len(matches) > 0 {
+		if _, err := s.Match.MassUpsert(ctx, matches); err != nil {
+			return len(events), 0, fmt.Errorf("upserting matches: %v", err)
+		}
+	}
+
+	return len(events), len(matches), nil
+}
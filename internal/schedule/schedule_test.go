@@ -0,0 +1,92 @@
+package schedule
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Pigmice2733/scouting-backend/internal/archive"
+	"github.com/Pigmice2733/scouting-backend/internal/bundle"
+	"github.com/Pigmice2733/scouting-backend/internal/store/event"
+	"github.com/Pigmice2733/scouting-backend/internal/store/match"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ed25519"
+)
+
+func TestReadJSON(t *testing.T) {
+	s, err := Read(strings.NewReader(`{
+		"events": [{"key": "2018orwil", "name": "Wilsonville", "date": "2018-03-01T00:00:00Z", "endDate": "2018-03-03T00:00:00Z"}],
+		"matches": [{"eventKey": "2018orwil", "key": "2018orwil_qm1", "redAlliance": ["frc1", "frc2", "frc3"], "blueAlliance": ["frc4", "frc5", "frc6"]}]
+	}`))
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, []event.BasicEvent{{
+		Key:     "2018orwil",
+		Name:    "Wilsonville",
+		Date:    time.Date(2018, 3, 1, 0, 0, 0, 0, time.UTC),
+		EndDate: time.Date(2018, 3, 3, 0, 0, 0, 0, time.UTC),
+	}}, s.Events)
+	assert.Equal(t, []archive.Match{{EventKey: "2018orwil", Match: match.Match{
+		BasicMatch:   match.BasicMatch{Key: "2018orwil_qm1"},
+		RedAlliance:  []string{"frc1", "frc2", "frc3"},
+		BlueAlliance: []string{"frc4", "frc5", "frc6"},
+	}}}, s.Matches)
+}
+
+func TestReadBundle(t *testing.T) {
+	date := time.Date(2018, 3, 1, 0, 0, 0, 0, time.UTC)
+	b := bundle.Bundle{
+		Org:   "frc1540",
+		Event: event.BasicEvent{Key: "2018orwil", Name: "Wilsonville", Date: date, EndDate: date},
+		Matches: []archive.Match{{Match: match.Match{
+			BasicMatch:  match.BasicMatch{Key: "2018orwil_qm1"},
+			RedAlliance: []string{"frc1"},
+		}}},
+	}
+
+	var buf bytes.Buffer
+	if !assert.Nil(t, bundle.Write(&buf, &b, ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize)))) {
+		return
+	}
+
+	s, err := Read(&buf)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, []event.BasicEvent{b.Event}, s.Events)
+	if assert.Len(t, s.Matches, 1) {
+		assert.Equal(t, "2018orwil", s.Matches[0].EventKey)
+		assert.Equal(t, "2018orwil_qm1", s.Matches[0].Key)
+	}
+}
+
+func TestReadInvalid(t *testing.T) {
+	cases := map[string]string{
+		"not json":        `{`,
+		"unknown field":   `{"events": [], "teams": []}`,
+		"no event key":    `{"events": [{"name": "Wilsonville"}]}`,
+		"duplicate event": `{"events": [{"key": "2018orwil"}, {"key": "2018orwil"}]}`,
+		"unknown event":   `{"events": [{"key": "2018orwil"}], "matches": [{"eventKey": "2018orore", "key": "2018orore_qm1"}]}`,
+		"no match key":    `{"events": [{"key": "2018orwil"}], "matches": [{"eventKey": "2018orwil"}]}`,
+		"duplicate match": `{"events": [{"key": "2018orwil"}], "matches": [{"eventKey": "2018orwil", "key": "2018orwil_qm1"}, {"eventKey": "2018orwil", "key": "2018orwil_qm1"}]}`,
+		"big alliance":    `{"events": [{"key": "2018orwil"}], "matches": [{"eventKey": "2018orwil", "key": "2018orwil_qm1", "redAlliance": ["frc1", "frc2", "frc3", "frc4"]}]}`,
+		"bad bundle":      "\x1f\x8bnot a bundle",
+	}
+
+	for name, input := range cases {
+		_, err := Read(strings.NewReader(input))
+		assert.NotNil(t, err, name)
+	}
+}
+
+func TestValidateAlliances(t *testing.T) {
+	assert.Nil(t, ValidateAlliances([]string{"frc1", "frc2"}, []string{"frc3"}))
+	assert.Nil(t, ValidateAlliances(nil, nil))
+	assert.NotNil(t, ValidateAlliances([]string{"frc1"}, []string{"frc1"}))
+	assert.NotNil(t, ValidateAlliances([]string{""}, nil))
+	assert.NotNil(t, ValidateAlliances(nil, []string{"frc1", "frc2", "frc3", "frc4"}))
+}
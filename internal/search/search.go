@@ -0,0 +1,188 @@
+// Package search is a simple tokenizer for full-text search over scouting
+// notes, for stores without full-text indexes. It is deliberately much simpler
+// than Postgres' english text search, but drops the same stop words, so a
+// query has words to search for in one exactly when it does in the other.
+// Words are lowercased and have a few common suffixes removed.
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// stopWords are the words Postgres' english text search leaves out, since
+// they're in almost every note.
+var stopWords = make(map[string]bool)
+
+func init() {
+	for _, w := range strings.Fields(`
+		i me my myself we our ours ourselves you your yours yourself yourselves
+		he him his himself she her hers herself it its itself they them their
+		theirs themselves what which who whom this that these those am is are
+		was were be been being have has had having do does did doing a an the
+		and but if or because as until while of at by for with about against
+		between into through during before after above below to from up down
+		in out on off over under again further then once here there when where
+		why how all any both each few more most other some such no nor not only
+		own same so than too very s t can will just don should now
+	`) {
+		stopWords[w] = true
+	}
+}
+
+// Tokenize splits text into normalized words, leaving out stop words.
+func Tokenize(text string) []string {
+	var tokens []string
+	for _, word := range words(text) {
+		if token, ok := normalize(word.text); ok {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+// normalize lowercases and stems a word, or returns false for stop words.
+func normalize(word string) (string, bool) {
+	word = strings.ToLower(word)
+	if stopWords[strings.TrimSuffix(word, "'s")] {
+		return "", false
+	}
+	return stem(word), true
+}
+
+// Match returns whether text contains every word in the query.
+func Match(query, text string) bool {
+	terms := Tokenize(query)
+	if len(terms) == 0 {
+		return false
+	}
+
+	have := make(map[string]bool)
+	for _, token := range Tokenize(text) {
+		have[token] = true
+	}
+
+	for _, term := range terms {
+		if !have[term] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Highlight makes an HTML snippet of text, like the snippets of Postgres'
+// search: the text is escaped, every word that matches a word in the query is
+// surrounded with <mark> tags, and the text is trimmed to at most maxWords
+// words around the first match. Trimmed text is marked with "...".
+func Highlight(query, text string, maxWords int) string {
+	terms := make(map[string]bool)
+	for _, term := range Tokenize(query) {
+		terms[term] = true
+	}
+
+	matches := func(w word) bool {
+		token, ok := normalize(w.text)
+		return ok && terms[token]
+	}
+
+	ws := words(text)
+	first := -1
+	for i, w := range ws {
+		if matches(w) {
+			first = i
+			break
+		}
+	}
+
+	from, to := 0, len(ws)
+	if maxWords > 0 && len(ws) > maxWords {
+		if first > maxWords/2 {
+			from = first - maxWords/2
+		}
+		to = from + maxWords
+		if to > len(ws) {
+			to, from = len(ws), len(ws)-maxWords
+		}
+	}
+
+	var b strings.Builder
+	if from > 0 {
+		b.WriteString("...")
+	}
+
+	begin := 0
+	if from > 0 {
+		begin = ws[from].start
+	}
+	end := len(text)
+	if to < len(ws) {
+		end = ws[to-1].end
+	}
+
+	pos := begin
+	for _, w := range ws[from:to] {
+		b.WriteString(html.EscapeString(text[pos:w.start]))
+		if matches(w) {
+			b.WriteString("<mark>" + html.EscapeString(w.text) + "</mark>")
+		} else {
+			b.WriteString(html.EscapeString(w.text))
+		}
+		pos = w.end
+	}
+	b.WriteString(html.EscapeString(text[pos:end]))
+
+	if to < len(ws) {
+		b.WriteString("...")
+	}
+
+	return b.String()
+}
+
+type word struct {
+	text       string
+	start, end int
+}
+
+// words splits text on anything that isn't a letter, digit or apostrophe.
+func words(text string) []word {
+	var ws []word
+
+	start := -1
+	for i, r := range text {
+		inWord := unicode.IsLetter(r) || unicode.IsDigit(r) || r == '\''
+		if inWord && start < 0 {
+			start = i
+		} else if !inWord && start >= 0 {
+			ws = append(ws, word{text: text[start:i], start: start, end: i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		ws = append(ws, word{text: text[start:], start: start, end: len(text)})
+	}
+
+	return ws
+}
+
+// stem removes a few common english suffixes, so that "tipped", "tipping" and
+// "tips" all match "tip".
+func stem(token string) string {
+	token = strings.TrimSuffix(token, "'s")
+	token = strings.Replace(token, "'", "", -1)
+
+	for _, suffix := range []string{"ing", "ed", "es", "s"} {
+		if strings.HasSuffix(token, suffix) && len(token)-len(suffix) >= 3 {
+			token = token[:len(token)-len(suffix)]
+
+			// tipped -> tipp -> tip
+			if n := len(token); suffix != "s" && token[n-1] == token[n-2] && !strings.ContainsRune("aeiouls", rune(token[n-1])) {
+				token = token[:n-1]
+			}
+			break
+		}
+	}
+
+	return token
+}
@@ -0,0 +1,68 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenize(t *testing.T) {
+	testCases := []struct {
+		text   string
+		tokens []string
+	}{
+		{"", nil},
+		{"Tipped over!", []string{"tip"}},
+		{"tipping, tips; TIPPED", []string{"tip", "tip", "tip"}},
+		{"robot's brownouts in qm12", []string{"robot", "brownout", "qm12"}},
+		{"climbed   fast", []string{"climb", "fast"}},
+		{"was bus", []string{"bus"}},
+		{"passes balls", []string{"pass", "ball"}},
+		{"The", nil},
+		{"it's all of them", nil},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.tokens, Tokenize(tc.text), tc.text)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	assert.True(t, Match("tipped over", "They tipped over in the last match"))
+	assert.True(t, Match("tipped over", "Robot tips over when it climbs"))
+	assert.True(t, Match("Brownout", "lots of brownouts"))
+	assert.True(t, Match("tipped over", "tipped the scale"))
+	assert.False(t, Match("brownout climb", "brownout on the ramp"))
+	assert.False(t, Match("", "anything"))
+	assert.False(t, Match("!!", "anything"))
+	assert.False(t, Match("the", "the robot"))
+}
+
+func TestHighlight(t *testing.T) {
+	assert.Equal(t,
+		"They <mark>tipped</mark> over, twice.",
+		Highlight("tipped over", "They tipped over, twice.", 0),
+	)
+
+	assert.Equal(t,
+		"...c d <mark>brownout</mark> f g...",
+		Highlight("brownouts", "a b c d brownout f g h i", 5),
+	)
+
+	assert.Equal(t,
+		"<mark>brownout</mark> b c...",
+		Highlight("brownout", "brownout b c d e", 3),
+	)
+
+	assert.Equal(t,
+		"...c d <mark>brownout</mark>",
+		Highlight("brownout", "a b c d brownout", 3),
+	)
+
+	assert.Equal(t, "no matches here", Highlight("brownout", "no matches here", 0))
+
+	assert.Equal(t,
+		"&lt;script&gt;<mark>brownout</mark>&lt;/script&gt; &amp; <mark>brownouts</mark>",
+		Highlight("brownout", "<script>brownout</script> & brownouts", 0),
+	)
+}
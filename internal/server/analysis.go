@@ -1,8 +1,12 @@
 package server
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/Pigmice2733/scouting-backend/internal/analysis"
 	"github.com/Pigmice2733/scouting-backend/internal/respond"
@@ -11,51 +15,209 @@ import (
 )
 
 func (s *Server) schemaHandler(w http.ResponseWriter, r *http.Request) {
-	respond.JSON(w, s.schema)
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	schema, err := s.getSchema(r.Context(), org)
+	if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("getting schema: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	respond.Negotiate(w, r, schema)
+}
+
+func (s *Server) updateSchemaHandler(w http.ResponseWriter, r *http.Request) {
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	var schema analysis.Schema
+	if err := json.NewDecoder(r.Body).Decode(&schema); err != nil || schema == nil {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	if err := schema.Validate(); err != nil {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	if err := s.store.Schema.Upsert(r.Context(), org, schema); err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("upserting schema: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
 }
 
 func (s *Server) eventAnalysisHandler(w http.ResponseWriter, r *http.Request) {
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	schema, err := s.getSchema(r.Context(), org)
+	if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("getting schema: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
 	eventKey := mux.Vars(r)["eventKey"]
 
-	resp, err := logic.EventAnalysis(eventKey, s.schema, s.store.Report)
+	resp, err := logic.EventAnalysis(r.Context(), org, eventKey, schema, s.store.Report)
 	if err != nil {
 		s.logger.LogRequestError(r, fmt.Errorf("analyzing event: %v", err))
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		respond.Error(w, http.StatusInternalServerError)
 		return
 	}
 
-	respond.JSON(w, resp)
+	respond.Negotiate(w, r, resp)
 }
 
 func (s *Server) teamAnalysisHandler(w http.ResponseWriter, r *http.Request) {
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	schema, err := s.getSchema(r.Context(), org)
+	if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("getting schema: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
 	vars := mux.Vars(r)
 	eventKey, team := vars["eventKey"], vars["team"]
 
-	resp, err := logic.Analyze(eventKey, []string{team}, s.schema, s.store.Report)
+	resp, err := logic.Analyze(r.Context(), org, eventKey, []string{team}, schema, s.store.Report)
 	if err != nil {
 		s.logger.LogRequestError(r, fmt.Errorf("analyzing event: %v", err))
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		respond.Error(w, http.StatusInternalServerError)
 		return
 	}
 
 	if len(resp) == 0 {
-		respond.JSON(w, analysis.Results{})
+		respond.Negotiate(w, r, analysis.Results{})
 		return
 	}
 
-	respond.JSON(w, resp[0])
+	respond.Negotiate(w, r, resp[0])
 }
 
 func (s *Server) allianceAnalysisHandler(w http.ResponseWriter, r *http.Request) {
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	schema, err := s.getSchema(r.Context(), org)
+	if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("getting schema: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
 	vars := mux.Vars(r)
 	eventKey, matchKey, color := vars["eventKey"], vars["matchKey"], vars["color"]
 
-	resp, err := logic.AllianceAnalysis(eventKey, matchKey, color, s.schema, s.store.Report, s.store.Alliance)
+	resp, err := logic.AllianceAnalysis(r.Context(), org, eventKey, matchKey, color, schema, s.store.Report, s.store.Alliance)
 	if err != nil {
 		s.logger.LogRequestError(r, fmt.Errorf("analyzing event: %v", err))
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	respond.Negotiate(w, r, resp)
+}
+
+// maxComparedTeams limits how many teams can be compared at once, since every
+// pair of teams is compared on every field.
+const maxComparedTeams = 6
+
+func (s *Server) compareHandler(w http.ResponseWriter, r *http.Request) {
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	teams := strings.Split(r.URL.Query().Get("teams"), ",")
+	eventKeys := strings.Split(r.URL.Query().Get("events"), ",")
+
+	if len(teams) < 2 || len(teams) > maxComparedTeams || r.URL.Query().Get("events") == "" {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	schema, err := s.getSchema(r.Context(), org)
+	if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("getting schema: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	resp, err := logic.Compare(r.Context(), org, eventKeys, teams, schema, s.store.Report, s.store.Alliance)
+	if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("comparing teams: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	respond.Negotiate(w, r, resp)
+}
+
+func (s *Server) seasonAnalysisHandler(w http.ResponseWriter, r *http.Request) {
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	year := s.year
+	if yearStr := r.URL.Query().Get("year"); yearStr != "" {
+		var err error
+		if year, err = strconv.Atoi(yearStr); err != nil {
+			respond.Error(w, http.StatusBadRequest)
+			return
+		}
+	}
+
+	halfLife := logic.DefaultHalfLife
+	if halfLifeStr := r.URL.Query().Get("halfLife"); halfLifeStr != "" {
+		days, err := strconv.ParseFloat(halfLifeStr, 64)
+		if err != nil || days < 0 {
+			respond.Error(w, http.StatusBadRequest)
+			return
+		}
+		halfLife = time.Duration(days * float64(24*time.Hour))
+	}
+
+	schema, err := s.getSchema(r.Context(), org)
+	if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("getting schema: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	team := mux.Vars(r)["team"]
+
+	resp, err := logic.AnalyzeSeason(r.Context(), org, team, year, halfLife, schema, s.store.Report, s.store.Event)
+	if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("analyzing season: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
 		return
 	}
 
-	respond.JSON(w, resp)
+	respond.Negotiate(w, r, resp)
 }
@@ -0,0 +1,182 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/Pigmice2733/scouting-backend/internal/bundle"
+	"github.com/Pigmice2733/scouting-backend/internal/respond"
+	"github.com/Pigmice2733/scouting-backend/internal/store"
+	"github.com/Pigmice2733/scouting-backend/internal/store/bundlekey"
+	"github.com/gorilla/mux"
+	"golang.org/x/crypto/ed25519"
+)
+
+func (s *Server) exportBundleHandler(w http.ResponseWriter, r *http.Request) {
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	eventKey := mux.Vars(r)["eventKey"]
+
+	schema, err := s.getSchema(r.Context(), org)
+	if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("getting schema: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	b, err := bundle.Export(r.Context(), s.store, org, eventKey, schema)
+	if err == store.ErrNoResults {
+		respond.Error(w, http.StatusNotFound)
+		return
+	} else if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("exporting bundle: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	key, err := bundle.Key(r.Context(), s.store.BundleKey, org)
+	if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("getting bundle key: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-%s.bundle.tar.gz"`, org, eventKey))
+
+	if err := bundle.Write(w, &b, key); err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("writing bundle: %v", err))
+	}
+}
+
+func (s *Server) importBundleHandler(w http.ResponseWriter, r *http.Request) {
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	b, err := bundle.Read(r.Body)
+	if err != nil {
+		respond.ErrorCode(w, http.StatusBadRequest, codeInvalidBundle, err.Error())
+		return
+	}
+
+	if b.Org == org {
+		respond.ErrorCode(w, http.StatusBadRequest, codeInvalidBundle, "bundle was exported by this organization")
+		return
+	}
+
+	publicKey, err := bundle.Trusted(r.Context(), s.store.BundleKey, org, b.Org)
+	if err == store.ErrNoResults {
+		respond.ErrorCode(w, http.StatusForbidden, codeUntrustedBundle, fmt.Sprintf("bundles from '%s' aren't trusted", b.Org))
+		return
+	} else if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("getting trusted bundle key: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	if err := b.Verify(publicKey); err != nil {
+		respond.ErrorCode(w, http.StatusForbidden, codeUntrustedBundle, err.Error())
+		return
+	}
+
+	schema, err := s.getSchema(r.Context(), org)
+	if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("getting schema: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	result, err := bundle.Import(r.Context(), s.store, org, schema, b)
+	if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("importing bundle: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	respond.Negotiate(w, r, result)
+}
+
+func (s *Server) bundleKeyHandler(w http.ResponseWriter, r *http.Request) {
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	key, err := bundle.Key(r.Context(), s.store.BundleKey, org)
+	if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("getting bundle key: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	respond.Negotiate(w, r, bundlekey.Trusted{Org: org, PublicKey: key.Public().(ed25519.PublicKey)})
+}
+
+func (s *Server) trustedBundleKeysHandler(w http.ResponseWriter, r *http.Request) {
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	trusted, err := s.store.BundleKey.GetTrusted(r.Context(), org)
+	if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("getting trusted bundle keys: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	respond.Negotiate(w, r, trusted)
+}
+
+func (s *Server) trustBundleKeyHandler(w http.ResponseWriter, r *http.Request) {
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	var t bundlekey.Trusted
+	if err := json.NewDecoder(r.Body).Decode(&t); err != nil || len(t.PublicKey) != ed25519.PublicKeySize {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	t.Org = mux.Vars(r)["org"]
+	if !orgIDRegex.MatchString(t.Org) || t.Org == org {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	if err := s.store.BundleKey.SetTrusted(r.Context(), org, t); err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("setting trusted bundle key: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+}
+
+func (s *Server) distrustBundleKeyHandler(w http.ResponseWriter, r *http.Request) {
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	if err := s.store.BundleKey.DeleteTrusted(r.Context(), org, mux.Vars(r)["org"]); err == store.ErrNoResults {
+		respond.Error(w, http.StatusNotFound)
+		return
+	} else if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("deleting trusted bundle key: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,101 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Pigmice2733/scouting-backend/internal/store/event"
+	"github.com/Pigmice2733/scouting-backend/internal/store/match"
+	"github.com/Pigmice2733/scouting-backend/internal/tba/mock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+type changesResponse struct {
+	Cursor  int64 `json:"cursor"`
+	Changes []struct {
+		ID       int64  `json:"id"`
+		MatchKey string `json:"matchKey"`
+		Kind     string `json:"kind"`
+	} `json:"changes"`
+}
+
+func getChanges(t *testing.T, s *Server, query string) changesResponse {
+	router := mux.NewRouter()
+	router.HandleFunc("/events/{eventKey}/changes", s.changesHandler)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/events/orwil/changes"+query, nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp changesResponse
+	assert.Nil(t, json.NewDecoder(w.Body).Decode(&resp))
+	return resp
+}
+
+func TestPollMatchesChanges(t *testing.T) {
+	ctx := context.Background()
+
+	qm1 := testMatch("orwil_qm1", []string{"frc2733", "frc1540", "frc4488"}, []string{"frc1425", "frc360", "frc1318"})
+	tbaMatches := map[string][]match.Match{"orwil": {qm1}}
+
+	s, _, _ := newTestServer(mock.DB{Matches: tbaMatches})
+
+	s.pollMatches(ctx, "orwil")
+
+	first := getChanges(t, s, "")
+	if assert.Len(t, first.Changes, 1) {
+		assert.Equal(t, match.ChangeAdded, first.Changes[0].Kind)
+		assert.Equal(t, first.Changes[0].ID, first.Cursor)
+	}
+
+	// polling the same matches again records nothing
+	s.pollMatches(ctx, "orwil")
+	assert.Empty(t, getChanges(t, s, "?since=1").Changes)
+
+	scored := qm1
+	scored.RedScore, scored.BlueScore = 120, 98
+	tbaMatches["orwil"] = []match.Match{scored}
+	s.pollMatches(ctx, "orwil")
+
+	second := getChanges(t, s, "?since=1")
+	if assert.Len(t, second.Changes, 1) {
+		assert.Equal(t, "orwil_qm1", second.Changes[0].MatchKey)
+		assert.Equal(t, match.ChangeScore, second.Changes[0].Kind)
+		assert.True(t, second.Cursor > first.Cursor)
+	}
+}
+
+func TestManualMatchChanges(t *testing.T) {
+	ctx := context.Background()
+
+	qm1 := testMatch("orwil_qm1", []string{"frc2733", "frc1540", "frc4488"}, []string{"frc1425", "frc360", "frc1318"})
+	s, events, _ := newTestServer(mock.DB{Matches: map[string][]match.Match{"orwil": {qm1}}})
+	events.events["orwil"] = event.BasicEvent{Key: "orwil", Name: "Wilsonville"}
+
+	s.pollMatches(ctx, "orwil")
+
+	router := mux.NewRouter()
+	router.HandleFunc("/events/{eventKey}/matches/{matchKey}", s.upsertMatchHandler)
+
+	// a team is swapped by hand
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("PUT", "/events/orwil/matches/orwil_qm1",
+		strings.NewReader(`{"redScore": -1, "blueScore": -1, "redAlliance": ["frc2733", "frc1540", "frc2990"], "blueAlliance": ["frc1425", "frc360", "frc1318"]}`)))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	manual := getChanges(t, s, "?since=1")
+	if assert.Len(t, manual.Changes, 1) {
+		assert.Equal(t, "orwil_qm1", manual.Changes[0].MatchKey)
+		assert.Equal(t, match.ChangeAlliances, manual.Changes[0].Kind)
+	}
+
+	// polling doesn't overwrite the manual match, so records nothing
+	s.pollMatches(ctx, "orwil")
+	assert.Empty(t, getChanges(t, s, "?since="+strconv.FormatInt(manual.Cursor, 10)).Changes)
+}
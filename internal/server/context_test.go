@@ -0,0 +1,72 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Pigmice2733/scouting-backend/internal/store/event"
+	"github.com/Pigmice2733/scouting-backend/internal/tba/mock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+// slowEvents is an event store that takes a while to upsert events, unless the
+// context is done first.
+type slowEvents struct {
+	*memEvents
+	delay time.Duration
+}
+
+func (s slowEvents) MassUpsert(ctx context.Context, bEvents []event.BasicEvent) (int, error) {
+	select {
+	case <-time.After(s.delay):
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+	return s.memEvents.MassUpsert(ctx, bEvents)
+}
+
+func upsertEventRequest(ctx context.Context) *http.Request {
+	body := `{"name": "Wilsonville", "date": "2018-03-02T00:00:00Z", "endDate": "2018-03-04T00:00:00Z"}`
+	return httptest.NewRequest("PUT", "/events/2018orwil", strings.NewReader(body)).WithContext(ctx)
+}
+
+func TestCancelledRequest(t *testing.T) {
+	s, events, _ := newTestServer(mock.DB{})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/events/{eventKey}", s.upsertEventHandler)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, upsertEventRequest(ctx))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Empty(t, events.events)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, upsertEventRequest(context.Background()))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, events.events, "2018orwil")
+}
+
+func TestRequestTimeout(t *testing.T) {
+	s, events, _ := newTestServer(mock.DB{})
+	s.store.Event = slowEvents{memEvents: events, delay: time.Second}
+
+	router := mux.NewRouter()
+	router.HandleFunc("/events/{eventKey}", s.upsertEventHandler)
+
+	start := time.Now()
+	w := httptest.NewRecorder()
+	timeout(router, time.Millisecond*20).ServeHTTP(w, upsertEventRequest(context.Background()))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.True(t, time.Since(start) < time.Second)
+	assert.Empty(t, events.events)
+}
@@ -3,60 +3,129 @@ package server
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/Pigmice2733/scouting-backend/internal/store/event"
 
 	"github.com/Pigmice2733/scouting-backend/internal/respond"
 	"github.com/Pigmice2733/scouting-backend/internal/store"
+	"github.com/Pigmice2733/scouting-backend/internal/tba"
 	"github.com/gorilla/mux"
 )
 
 func (s *Server) eventsHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Cache-Control", "max-age=86400") // 24 hour max age
 
-	bEvents, err := s.store.Event.GetBasicEvents()
+	bEvents, err := s.store.Event.GetBasicEvents(r.Context())
 	if err == store.ErrNoResults {
 		bEvents = []event.BasicEvent{}
 	} else if err != nil {
 		s.logger.LogRequestError(r, fmt.Errorf("getting basic events: %v", err))
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		respond.Error(w, http.StatusInternalServerError)
 		return
 	}
 
-	respond.JSON(w, bEvents)
+	respond.Negotiate(w, r, bEvents)
 }
 
 func (s *Server) eventHandler(w http.ResponseWriter, r *http.Request) {
 	eventKey := mux.Vars(r)["eventKey"]
 
-	event, err := s.store.Event.Get(eventKey, s.store.Match)
+	event, err := s.store.Event.Get(r.Context(), eventKey, s.store.Match)
 	if err != nil {
 		if err == store.ErrNoResults {
-			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			respond.Error(w, http.StatusNotFound)
 		} else {
 			s.logger.LogRequestError(r, fmt.Errorf("getting event: %v", err))
-			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			respond.Error(w, http.StatusInternalServerError)
 		}
 		return
 	}
 
-	respond.JSON(w, event)
+	respond.Negotiate(w, r, event)
 }
 
 func (s *Server) matchHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	eventKey, matchKey := vars["eventKey"], vars["matchKey"]
 
-	match, err := s.store.Match.Get(eventKey, matchKey, s.store.Alliance)
+	match, err := s.store.Match.Get(r.Context(), eventKey, matchKey, s.store.Alliance)
 	if err != nil {
 		if err == store.ErrNoResults {
-			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			respond.Error(w, http.StatusNotFound)
 		} else {
 			s.logger.LogRequestError(r, fmt.Errorf("getting match: %v", err))
-			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			respond.Error(w, http.StatusInternalServerError)
 		}
 		return
 	}
 
-	respond.JSON(w, match)
+	respond.Negotiate(w, r, match)
+}
+
+// changesHandler gets the changes to the matches of an event found by syncing
+// it from TBA, after the change given by the since cursor.
+func (s *Server) changesHandler(w http.ResponseWriter, r *http.Request) {
+	eventKey := mux.Vars(r)["eventKey"]
+
+	var since int64
+	if v := r.URL.Query().Get("since"); v != "" {
+		var err error
+		if since, err = strconv.ParseInt(v, 10, 64); err != nil || since < 0 {
+			respond.Error(w, http.StatusBadRequest)
+			return
+		}
+	}
+
+	changes, err := s.store.Match.GetChanges(r.Context(), eventKey, since)
+	if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("getting changes to matches: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	cursor := since
+	if len(changes) > 0 {
+		cursor = changes[len(changes)-1].ID
+	}
+
+	respond.Negotiate(w, r, map[string]interface{}{"cursor": cursor, "changes": changes})
+}
+
+func (s *Server) rankingsHandler(w http.ResponseWriter, r *http.Request) {
+	eventKey := mux.Vars(r)["eventKey"]
+
+	if s.isOffline() {
+		respond.ErrorCode(w, http.StatusServiceUnavailable, codeOffline, "rankings aren't stored, and the server is offline")
+		return
+	}
+
+	ranker, ok := s.consumer.(tba.Ranker)
+	if !ok {
+		respond.Error(w, http.StatusNotFound)
+		return
+	}
+
+	// only events that are stored have rankings retrieved, so that requests
+	// for made up events don't reach the upstream API
+	if _, err := s.store.Event.Get(r.Context(), eventKey, s.store.Match); err == store.ErrNoResults {
+		respond.Error(w, http.StatusNotFound)
+		return
+	} else if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("getting event: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	rankings, err := s.getRankings(r.Context(), ranker, eventKey)
+	if err == tba.ErrNoRankings {
+		respond.Error(w, http.StatusNotFound)
+		return
+	} else if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("getting rankings: %v", err))
+		respond.Error(w, http.StatusBadGateway)
+		return
+	}
+
+	respond.Negotiate(w, r, rankings)
 }
@@ -0,0 +1,53 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Pigmice2733/scouting-backend/internal/export"
+	"github.com/Pigmice2733/scouting-backend/internal/respond"
+	"github.com/gorilla/mux"
+)
+
+func (s *Server) exportHandler(w http.ResponseWriter, r *http.Request) {
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	vars := mux.Vars(r)
+	eventKey, table := vars["eventKey"], vars["table"]
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = export.CSV
+	}
+
+	contentType, ok := export.ContentTypes[format]
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	schema, err := s.getSchema(r.Context(), org)
+	if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("getting schema: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	t, err := export.Build(r.Context(), table, org, eventKey, schema, s.store)
+	if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("building export: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-%s.%s"`, eventKey, table, format))
+
+	if err := export.Write(w, format, t); err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("writing export: %v", err))
+	}
+}
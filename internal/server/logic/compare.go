@@ -0,0 +1,132 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/Pigmice2733/scouting-backend/internal/analysis"
+	"github.com/Pigmice2733/scouting-backend/internal/store/alliance"
+	"github.com/Pigmice2733/scouting-backend/internal/store/report"
+)
+
+// SignificanceLevel is the p-value below which a difference between two teams
+// is considered significant.
+const SignificanceLevel = 0.05
+
+// Comparison holds how several teams performed side by side.
+type Comparison struct {
+	Teams   []string          `json:"teams"`
+	Events  []string          `json:"events"`
+	Reports map[string]int    `json:"reports"`
+	Fields  []FieldComparison `json:"fields"`
+	Matches []SharedMatch     `json:"matches"`
+}
+
+// FieldComparison holds how teams performed on one schema field, and whether
+// the difference between each pair of teams is significant.
+type FieldComparison struct {
+	Field string                      `json:"field"`
+	Type  string                      `json:"type"`
+	Teams map[string]analysis.Summary `json:"teams"`
+	Pairs []PairComparison            `json:"pairs"`
+}
+
+// PairComparison compares two teams on a field with a Welch's t-test. The test
+// is nil if either team has fewer than two reports with the field.
+type PairComparison struct {
+	Teams       [2]string       `json:"teams"`
+	Difference  float64         `json:"difference"`
+	Test        *analysis.TTest `json:"test"`
+	Significant bool            `json:"significant"`
+}
+
+// SharedMatch is a match where at least two of the compared teams played,
+// with the compared teams on each alliance.
+type SharedMatch struct {
+	EventKey string   `json:"eventKey"`
+	MatchKey string   `json:"matchKey"`
+	Red      []string `json:"red"`
+	Blue     []string `json:"blue"`
+	Together bool     `json:"together"`
+	Against  bool     `json:"against"`
+}
+
+// Compare compares how teams performed at events, from the reports an
+// organization can see.
+func Compare(ctx context.Context, org string, eventKeys, teams []string, schema analysis.Schema, rs report.Service, as alliance.Service) (Comparison, error) {
+	c := Comparison{Teams: teams, Events: eventKeys, Reports: make(map[string]int), Fields: []FieldComparison{}, Matches: []SharedMatch{}}
+
+	summaries := make(map[string]map[string]analysis.Summary)
+	for _, team := range teams {
+		var stats []analysis.Data
+		for _, eventKey := range eventKeys {
+			eventStats, err := rs.GetStatsByEventAndTeam(ctx, org, eventKey, team)
+			if err != nil {
+				return c, fmt.Errorf("getting stats by event and team: %v", err)
+			}
+			stats = append(stats, eventStats...)
+		}
+
+		summary, err := analysis.Summarize(schema, stats...)
+		if err != nil {
+			return c, fmt.Errorf("summarizing statistics: %v", err)
+		}
+
+		summaries[team] = summary
+		c.Reports[team] = len(stats)
+	}
+
+	var fields []string
+	for field := range schema {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		fc := FieldComparison{Field: field, Type: schema[field], Teams: make(map[string]analysis.Summary), Pairs: []PairComparison{}}
+
+		for _, team := range teams {
+			fc.Teams[team] = summaries[team][field]
+		}
+
+		for i := range teams {
+			for j := i + 1; j < len(teams); j++ {
+				a, b := summaries[teams[i]][field], summaries[teams[j]][field]
+				pair := PairComparison{Teams: [2]string{teams[i], teams[j]}, Difference: a.Mean - b.Mean}
+
+				if test, ok := analysis.WelchTTest(a, b); ok {
+					pair.Test = &test
+					pair.Significant = test.PValue < SignificanceLevel
+				}
+
+				fc.Pairs = append(fc.Pairs, pair)
+			}
+		}
+
+		c.Fields = append(c.Fields, fc)
+	}
+
+	appearances, err := as.GetSharedMatches(ctx, eventKeys, teams)
+	if err != nil {
+		return c, fmt.Errorf("getting shared matches: %v", err)
+	}
+
+	for _, a := range appearances {
+		if len(c.Matches) == 0 || c.Matches[len(c.Matches)-1].MatchKey != a.MatchKey {
+			c.Matches = append(c.Matches, SharedMatch{EventKey: a.EventKey, MatchKey: a.MatchKey, Red: []string{}, Blue: []string{}})
+		}
+
+		m := &c.Matches[len(c.Matches)-1]
+		if a.IsBlue {
+			m.Blue = append(m.Blue, a.Team)
+		} else {
+			m.Red = append(m.Red, a.Team)
+		}
+
+		m.Together = len(m.Red) > 1 || len(m.Blue) > 1
+		m.Against = len(m.Red) > 0 && len(m.Blue) > 0
+	}
+
+	return c, nil
+}
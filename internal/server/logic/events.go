@@ -1,47 +1,51 @@
 package logic
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/Pigmice2733/scouting-backend/internal/analysis"
 	"github.com/Pigmice2733/scouting-backend/internal/store/alliance"
 	"github.com/Pigmice2733/scouting-backend/internal/store/report"
+	"github.com/Pigmice2733/scouting-backend/internal/store/tag"
 )
 
 // TeamAnalysis holds information about a team, and their analyzed performance.
 type TeamAnalysis struct {
-	Team    string            `json:"team"`
-	Notes   map[string]string `json:"notes"`
-	Reports int               `json:"reports"`
-	Stats   analysis.Results  `json:"stats"`
+	Team        string             `json:"team"`
+	Notes       map[string]string  `json:"notes"`
+	Reports     int                `json:"reports"`
+	Stats       analysis.Results   `json:"stats"`
+	Reliability map[string]float64 `json:"reliability"`
 }
 
 // EventAnalysis gets information about how all teams at an event performed.
-func EventAnalysis(eventKey string, schema analysis.Schema, rs report.Service) ([]TeamAnalysis, error) {
-	reportedOn, err := rs.GetReportedOn(eventKey)
+func EventAnalysis(ctx context.Context, org, eventKey string, schema analysis.Schema, rs report.Service) ([]TeamAnalysis, error) {
+	reportedOn, err := rs.GetReportedOn(ctx, org, eventKey)
 	if err != nil {
 		return nil, fmt.Errorf("getting teams at event reported on: %v", err)
 	}
 
-	return Analyze(eventKey, reportedOn, schema, rs)
+	return Analyze(ctx, org, eventKey, reportedOn, schema, rs)
 }
 
 // AllianceAnalysis gets information about how all teams at a certain event and match of a certain alliance performed.
-func AllianceAnalysis(eventKey, matchKey, color string, schema analysis.Schema, rs report.Service, as alliance.Service) ([]TeamAnalysis, error) {
-	teams, err := as.Get(matchKey, color == "blue")
+func AllianceAnalysis(ctx context.Context, org, eventKey, matchKey, color string, schema analysis.Schema, rs report.Service, as alliance.Service) ([]TeamAnalysis, error) {
+	teams, err := as.Get(ctx, matchKey, color == "blue")
 	if err != nil {
 		return nil, fmt.Errorf("getting teams on an alliance at a match reported on: %v", err)
 	}
 
-	return Analyze(eventKey, teams, schema, rs)
+	return Analyze(ctx, org, eventKey, teams, schema, rs)
 }
 
-// Analyze gets statistics on how a team performed.
-func Analyze(eventKey string, teams []string, schema analysis.Schema, rs report.Service) ([]TeamAnalysis, error) {
+// Analyze gets statistics on how a team performed, from the reports an
+// organization can see.
+func Analyze(ctx context.Context, org, eventKey string, teams []string, schema analysis.Schema, rs report.Service) ([]TeamAnalysis, error) {
 	teamAnalyses := make([]TeamAnalysis, 0)
 
 	for _, team := range teams {
-		stats, err := rs.GetStatsByEventAndTeam(eventKey, team)
+		stats, err := rs.GetStatsByEventAndTeam(ctx, org, eventKey, team)
 		if err != nil {
 			return nil, fmt.Errorf("getting stats by event and team: %v", err)
 		}
@@ -55,12 +59,17 @@ func Analyze(eventKey string, teams []string, schema analysis.Schema, rs report.
 			return nil, fmt.Errorf("averaging statistics: %v", err)
 		}
 
-		notes, err := rs.GetNotesByEventAndTeam(eventKey, team)
+		notes, err := rs.GetNotesByEventAndTeam(ctx, org, eventKey, team)
 		if err != nil {
 			return nil, fmt.Errorf("getting notes: %v", err)
 		}
 
-		teamAnalyses = append(teamAnalyses, TeamAnalysis{Team: team, Notes: notes, Stats: results, Reports: len(stats)})
+		tags, err := rs.GetTagsByEventAndTeam(ctx, org, eventKey, team)
+		if err != nil {
+			return nil, fmt.Errorf("getting tags: %v", err)
+		}
+
+		teamAnalyses = append(teamAnalyses, TeamAnalysis{Team: team, Notes: notes, Stats: results, Reports: len(stats), Reliability: tag.Rates(tags)})
 	}
 
 	return teamAnalyses, nil
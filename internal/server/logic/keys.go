@@ -0,0 +1,48 @@
+package logic
+
+import (
+	"time"
+
+	"github.com/Pigmice2733/scouting-backend/internal/jwtkeys"
+	"github.com/Pigmice2733/scouting-backend/internal/store/signingkey"
+)
+
+// RetiredKeyTTL is how long jwts signed with a retired key are still accepted,
+// and how long its public key is still published. It must be longer than
+// AccessTokenTTL.
+const RetiredKeyTTL = time.Hour * 24
+
+// RotateSigningKey adds a new signing key with an algorithm and retires the
+// current one if there is no active key, or if the active key is older than
+// rotateAfter. If rotateAfter is zero keys are never rotated. Keys that were
+// retired more than RetiredKeyTTL ago are removed.
+func RotateSigningKey(algorithm string, rotateAfter time.Duration, ks signingkey.Service) (bool, error) {
+	if err := ks.DeleteRetired(time.Now().Add(-RetiredKeyTTL)); err != nil {
+		return false, err
+	}
+
+	var createdBefore time.Time
+	if rotateAfter > 0 {
+		createdBefore = time.Now().Add(-rotateAfter)
+	}
+
+	keys, err := ks.GetAll()
+	if err != nil {
+		return false, err
+	}
+
+	for _, k := range keys {
+		if k.Retired == nil && !k.Created.Before(createdBefore) {
+			return false, nil
+		}
+	}
+
+	// generating keys can be slow, so only do it when the keys probably need to
+	// be rotated, Rotate checks again while holding a lock
+	k, err := jwtkeys.Generate(algorithm)
+	if err != nil {
+		return false, err
+	}
+
+	return ks.Rotate(k, createdBefore)
+}
@@ -1,30 +1,98 @@
 package logic
 
 import (
+	"bufio"
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Pigmice2733/scouting-backend/internal/imagecache"
 	"github.com/Pigmice2733/scouting-backend/internal/store"
 	"github.com/Pigmice2733/scouting-backend/internal/store/photo"
 	"github.com/Pigmice2733/scouting-backend/internal/tba"
 )
 
-// GetPhoto gets a photo from either the photo store if it exists there, or,
-// fetches it from the TBA API and attempts to store the photo in the photo
-// store.
-func GetPhoto(team string, year int, ps photo.Service, consumer tba.Consumer) (string, error) {
-	url, err := ps.Get(team)
-	if err != nil && err != store.ErrNoResults {
+// PhotoTTL is how long a stored photo URL is used before it is refreshed from
+// the TBA API.
+const PhotoTTL = time.Hour * 24 * 7
+
+// UpstreamError is returned when a photo could not be retrieved from an
+// upstream service (the TBA API or the image host).
+type UpstreamError struct {
+	Err error
+}
+
+func (e UpstreamError) Error() string {
+	return fmt.Sprintf("upstream: %v", e.Err)
+}
+
+// GetPhoto gets the URL of a team's photo for a year from the photo store if it
+// exists there, or, fetches it from the TBA API and stores it in the photo
+// store. Stale URLs are still returned, and are left for the server's photo
+// refresh to update, so that busy teams don't start a refresh per request.
+func GetPhoto(ctx context.Context, team string, year int, ps photo.Service, consumer tba.Consumer) (string, error) {
+	p, err := ps.Get(ctx, team, year)
+	if err == store.ErrNoResults {
+		p, err = RefreshPhoto(ctx, team, year, ps, consumer)
+		if err == tba.ErrNotModified {
+			return "", nil
+		}
+		return p.URL, err
+	} else if err != nil {
 		return "", err
 	}
 
-	if err == store.ErrNoResults || url == "" {
-		url, err = consumer.GetPhotoURL(team, year)
+	return p.URL, nil
+}
+
+// RefreshPhoto fetches the URL of a team's photo for a year from the TBA API and
+// stores it in the photo store.
+func RefreshPhoto(ctx context.Context, team string, year int, ps photo.Service, consumer tba.Consumer) (photo.Photo, error) {
+	p := photo.Photo{Team: team, Year: year, Updated: time.Now()}
+
+	url, err := consumer.GetPhotoURL(ctx, team, year)
+	if err == tba.ErrNotModified {
+		old, err := ps.Get(ctx, team, year)
 		if err != nil {
-			return "", err
+			return p, tba.ErrNotModified
 		}
+		url = old.URL
+	} else if err != nil {
+		return p, UpstreamError{err}
+	}
 
-		if url != "" {
-			go ps.Create(team, url)
-		}
+	p.URL = url
+
+	return p, ps.Upsert(ctx, p)
+}
+
+// FetchImage downloads the image at a URL and stores it in an image cache.
+func FetchImage(client *http.Client, url string, cache *imagecache.Cache) (imagecache.Entry, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return imagecache.Entry{}, UpstreamError{err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return imagecache.Entry{}, UpstreamError{fmt.Errorf("fetching image failed with status code: %d", resp.StatusCode)}
+	}
+
+	body := bufio.NewReader(resp.Body)
+
+	contentType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || !strings.HasPrefix(contentType, "image/") {
+		sniff, _ := body.Peek(512)
+		contentType = http.DetectContentType(sniff)
+	}
+
+	entry, err := cache.Put(url, contentType, body)
+	if err == imagecache.ErrTooLarge {
+		return entry, UpstreamError{err}
 	}
 
-	return url, err
+	return entry, err
 }
@@ -0,0 +1,68 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/Pigmice2733/scouting-backend/internal/store/role"
+)
+
+// ValidRoles returns whether all of the given roles exist in the role store.
+func ValidRoles(ctx context.Context, roles []string, rs role.Service) (bool, error) {
+	existing, err := rs.GetRoles(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	names := make(map[string]bool)
+	for _, r := range existing {
+		names[r.Name] = true
+	}
+
+	for _, r := range roles {
+		if !names[r] {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// RolesRemoved returns whether any of the old roles are missing from the new
+// roles.
+func RolesRemoved(oldRoles, newRoles []string) bool {
+	kept := make(map[string]bool)
+	for _, r := range newRoles {
+		kept[r] = true
+	}
+
+	for _, r := range oldRoles {
+		if !kept[r] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CanGrant returns whether a user with the granted permissions can give the
+// roles to someone, which is only allowed if the user has every permission the
+// roles grant.
+func CanGrant(ctx context.Context, granted []string, roles []string, rs role.Service) (bool, error) {
+	permissions, err := rs.GetPermissions(ctx, roles)
+	if err != nil {
+		return false, err
+	}
+
+	has := make(map[string]bool)
+	for _, p := range granted {
+		has[p] = true
+	}
+
+	for _, p := range permissions {
+		if !has[p] {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,125 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Pigmice2733/scouting-backend/internal/analysis"
+	"github.com/Pigmice2733/scouting-backend/internal/store/event"
+	"github.com/Pigmice2733/scouting-backend/internal/store/report"
+)
+
+// DefaultHalfLife is how long it takes by default for an event to count half
+// as much in season analysis as the team's most recent event.
+const DefaultHalfLife = 14 * 24 * time.Hour
+
+// EventBreakdown holds how a team performed at one event of a season, and how
+// much that event counts towards their season stats.
+type EventBreakdown struct {
+	EventKey string           `json:"eventKey"`
+	Name     string           `json:"name"`
+	Date     time.Time        `json:"date"`
+	Weight   float64          `json:"weight"`
+	Reports  int              `json:"reports"`
+	Stats    analysis.Results `json:"stats"`
+}
+
+// SeasonAnalysis holds how a team performed over every event of a season they
+// were reported on at.
+type SeasonAnalysis struct {
+	Team    string           `json:"team"`
+	Year    int              `json:"year"`
+	Reports int              `json:"reports"`
+	Stats   analysis.Results `json:"stats"`
+	Events  []EventBreakdown `json:"events"`
+}
+
+// AnalyzeSeason gets statistics on how a team performed at all events in a
+// year, from the reports an organization can see. The season stats weight
+// reports by how recent their event was: an event halfLife before the team's
+// latest event counts half as much, one twice as long before counts a quarter,
+// and so on.
+func AnalyzeSeason(ctx context.Context, org, team string, year int, halfLife time.Duration, schema analysis.Schema, rs report.Service, es event.Service) (SeasonAnalysis, error) {
+	sa := SeasonAnalysis{Team: team, Year: year, Stats: analysis.Results{}, Events: []EventBreakdown{}}
+
+	reports, err := rs.GetReportsByTeam(ctx, org, team)
+	if err != nil {
+		return sa, fmt.Errorf("getting reports by team: %v", err)
+	}
+
+	bEvents, err := es.GetBasicEvents(ctx)
+	if err != nil {
+		return sa, fmt.Errorf("getting events: %v", err)
+	}
+
+	events := make(map[string]event.BasicEvent)
+	for _, e := range bEvents {
+		if e.Date.Year() == year {
+			events[e.Key] = e
+		}
+	}
+
+	stats := make(map[string][]analysis.Data)
+	for _, rep := range reports {
+		if _, ok := events[rep.EventKey]; ok {
+			stats[rep.EventKey] = append(stats[rep.EventKey], rep.Stats)
+		}
+	}
+
+	var latest time.Time
+	for eventKey := range stats {
+		if e := events[eventKey]; e.Date.After(latest) {
+			latest = e.Date
+		}
+	}
+
+	var data []analysis.Data
+	var weights []float64
+
+	for eventKey, eventStats := range stats {
+		e := events[eventKey]
+
+		results, err := analysis.Average(schema, eventStats...)
+		if err != nil {
+			return sa, fmt.Errorf("averaging statistics: %v", err)
+		}
+
+		weight := 1.0
+		if halfLife > 0 {
+			weight = math.Pow(0.5, float64(latest.Sub(e.Date))/float64(halfLife))
+		}
+
+		sa.Events = append(sa.Events, EventBreakdown{
+			EventKey: eventKey,
+			Name:     e.Name,
+			Date:     e.Date,
+			Weight:   weight,
+			Reports:  len(eventStats),
+			Stats:    results,
+		})
+
+		for _, datum := range eventStats {
+			data = append(data, datum)
+			weights = append(weights, weight)
+		}
+	}
+
+	sort.Slice(sa.Events, func(i, j int) bool {
+		return sa.Events[i].Date.Before(sa.Events[j].Date)
+	})
+
+	sa.Reports = len(data)
+	if len(data) == 0 {
+		return sa, nil
+	}
+
+	sa.Stats, err = analysis.WeightedAverage(schema, data, weights)
+	if err != nil {
+		return sa, fmt.Errorf("averaging season statistics: %v", err)
+	}
+
+	return sa, nil
+}
@@ -1,10 +1,20 @@
 package logic
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
+	"github.com/Pigmice2733/scouting-backend/internal/jwtkeys"
 	"github.com/Pigmice2733/scouting-backend/internal/store"
+	"github.com/Pigmice2733/scouting-backend/internal/store/session"
 	"github.com/Pigmice2733/scouting-backend/internal/store/user"
 	jwt "github.com/dgrijalva/jwt-go"
 	"golang.org/x/crypto/bcrypt"
@@ -15,38 +25,163 @@ const (
 	SubjectClaim = "sub"
 	// ExpiresAtClaim specifies the time at which the jwt will expire
 	ExpiresAtClaim = "exp"
-	// IsAdminClaim specifies if the user is an admin user, and is prefixed with
-	// the pigmice prefix for collision resistance
-	IsAdminClaim = "pigmice_is_admin"
+	// SessionClaim specifies the session the jwt was issued for
+	SessionClaim = "sid"
+	// OrgClaim specifies the organization of the user, and is prefixed with the
+	// pigmice prefix for collision resistance
+	OrgClaim = "pigmice_org"
+	// RolesClaim specifies the roles of the user, and is prefixed with the
+	// pigmice prefix for collision resistance
+	RolesClaim = "pigmice_roles"
+)
+
+const (
+	// AccessTokenTTL is how long a jwt is valid for after it is issued.
+	AccessTokenTTL = time.Minute * 15
+	// RefreshTokenTTL is how long a refresh token is valid for after it is
+	// issued. Every time a refresh token is used it is replaced by a new one.
+	RefreshTokenTTL = time.Hour * 24 * 30
 )
 
 // ErrUnauthorized is returned when the user being attempted to be authorized has either the wrong username or password.
 var ErrUnauthorized = fmt.Errorf("unauthorized user")
 
-// Authenticate gives a jwt signed string for a certain user.
-func Authenticate(username, password string, jwtSecret []byte, us user.Service) (string, error) {
-	user, err := us.Get(username)
+var sessionIDRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// Tokens holds a signed jwt along with the refresh token that can be used to get
+// a new one once it expires.
+type Tokens struct {
+	JWT          string `json:"jwt"`
+	RefreshToken string `json:"refreshToken"`
+}
+
+// Authenticate starts a new session for a certain user and gives a jwt signed
+// string and a refresh token for it.
+func Authenticate(ctx context.Context, username, password string, keys *jwtkeys.Set, us user.Service, ss session.Service) (Tokens, error) {
+	user, err := us.Get(ctx, username)
 	if err != nil {
 		if err == store.ErrNoResults {
-			return "", ErrUnauthorized
+			return Tokens{}, ErrUnauthorized
 		}
-		return "", err
+		return Tokens{}, err
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.HashedPassword), []byte(password)); err != nil {
 		if err == bcrypt.ErrMismatchedHashAndPassword {
-			return "", ErrUnauthorized
+			return Tokens{}, ErrUnauthorized
 		}
-		return "", err
+		return Tokens{}, err
+	}
+
+	if !user.IsVerified {
+		return Tokens{}, ErrUnauthorized
+	}
+
+	secret, err := newRefreshSecret()
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	sessionID, err := ss.Create(ctx, session.Session{
+		Username:           user.Username,
+		HashedRefreshToken: hashRefreshSecret(secret),
+		Expires:            time.Now().Add(RefreshTokenTTL),
+	})
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	signed, err := signJWT(user, sessionID, keys)
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	return Tokens{JWT: signed, RefreshToken: sessionID + "." + secret}, nil
+}
+
+// Refresh exchanges a refresh token for a new jwt signed string and a new
+// refresh token. Each refresh token can only be used once, if a refresh token is
+// reused the whole session is revoked.
+func Refresh(ctx context.Context, refreshToken string, keys *jwtkeys.Set, us user.Service, ss session.Service) (Tokens, error) {
+	parts := strings.SplitN(refreshToken, ".", 2)
+	if len(parts) != 2 || !sessionIDRegex.MatchString(parts[0]) {
+		return Tokens{}, ErrUnauthorized
+	}
+	sessionID, secret := parts[0], parts[1]
+
+	sess, err := ss.Get(ctx, sessionID)
+	if err != nil {
+		if err == store.ErrNoResults {
+			return Tokens{}, ErrUnauthorized
+		}
+		return Tokens{}, err
+	}
+
+	if !sess.Active() {
+		return Tokens{}, ErrUnauthorized
+	}
+
+	hashedSecret := hashRefreshSecret(secret)
+	if subtle.ConstantTimeCompare([]byte(hashedSecret), []byte(sess.HashedRefreshToken)) != 1 {
+		// an old refresh token was reused, so it may have been stolen
+		if err := ss.Revoke(ctx, sess.ID); err != nil {
+			return Tokens{}, err
+		}
+		return Tokens{}, ErrUnauthorized
+	}
+
+	user, err := us.Get(ctx, sess.Username)
+	if err != nil {
+		if err == store.ErrNoResults {
+			return Tokens{}, ErrUnauthorized
+		}
+		return Tokens{}, err
 	}
 
 	if !user.IsVerified {
-		return "", ErrUnauthorized
+		return Tokens{}, ErrUnauthorized
 	}
 
-	return jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		SubjectClaim:   username,
-		ExpiresAtClaim: time.Now().Add(time.Hour * 24).Unix(),
-		IsAdminClaim:   user.IsAdmin,
-	}).SignedString(jwtSecret)
+	newSecret, err := newRefreshSecret()
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	if err := ss.Rotate(ctx, sess.ID, hashedSecret, hashRefreshSecret(newSecret), time.Now().Add(RefreshTokenTTL)); err != nil {
+		if err == store.ErrNoResults {
+			return Tokens{}, ErrUnauthorized
+		}
+		return Tokens{}, err
+	}
+
+	signed, err := signJWT(user, sess.ID, keys)
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	return Tokens{JWT: signed, RefreshToken: sess.ID + "." + newSecret}, nil
+}
+
+func signJWT(u user.User, sessionID string, keys *jwtkeys.Set) (string, error) {
+	return keys.Sign(jwt.MapClaims{
+		SubjectClaim:   u.Username,
+		ExpiresAtClaim: time.Now().Add(AccessTokenTTL).Unix(),
+		SessionClaim:   sessionID,
+		OrgClaim:       u.Org,
+		RolesClaim:     u.Roles,
+	})
+}
+
+func newRefreshSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+func hashRefreshSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
 }
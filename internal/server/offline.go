@@ -0,0 +1,191 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/Pigmice2733/scouting-backend/internal/respond"
+	"github.com/Pigmice2733/scouting-backend/internal/store"
+	"github.com/Pigmice2733/scouting-backend/internal/store/event"
+	"github.com/Pigmice2733/scouting-backend/internal/store/match"
+	"github.com/Pigmice2733/scouting-backend/internal/tba"
+)
+
+// offlineStatus describes whether the server is polling the TBA API.
+type offlineStatus struct {
+	Offline    bool       `json:"offline"`
+	Since      *time.Time `json:"since,omitempty"`
+	Reconciled *time.Time `json:"reconciled,omitempty"`
+}
+
+// isOffline returns whether TBA polling is disabled.
+func (s *Server) isOffline() bool {
+	s.offlineMu.RLock()
+	defer s.offlineMu.RUnlock()
+	return s.offline.Offline
+}
+
+// setOffline enables or disables TBA polling. It returns whether the server
+// was offline before.
+func (s *Server) setOffline(offline bool) bool {
+	s.offlineMu.Lock()
+	defer s.offlineMu.Unlock()
+
+	was := s.offline.Offline
+	if offline && !was {
+		now := time.Now()
+		s.offline.Since = &now
+	}
+	s.offline.Offline = offline
+
+	return was
+}
+
+// offlineEntries are the keys of the events and matches that were loaded from
+// the offline schedule or entered by hand while offline. Only these are
+// replaced by TBA data when reconciling, so manual data entered on purpose
+// while online stays until an admin syncs it. Matches map to their events.
+type offlineEntries struct {
+	events  map[string]bool
+	matches map[string]string
+}
+
+// recordEntered records events and matches as loaded or entered while offline.
+func (s *Server) recordEntered(events []event.BasicEvent, matches []match.Match) {
+	s.offlineMu.Lock()
+	defer s.offlineMu.Unlock()
+
+	if s.offlineEntered.events == nil {
+		s.offlineEntered = offlineEntries{events: make(map[string]bool), matches: make(map[string]string)}
+	}
+
+	for _, e := range events {
+		s.offlineEntered.events[e.Key] = true
+	}
+	for _, m := range matches {
+		s.offlineEntered.matches[m.Key] = m.EventKey
+	}
+}
+
+// enteredOffline records events and matches entered by hand if the server is
+// offline.
+func (s *Server) enteredOffline(events []event.BasicEvent, matches []match.Match) {
+	if s.isOffline() {
+		s.recordEntered(events, matches)
+	}
+}
+
+// takeEntered removes the records of what was loaded or entered offline for an
+// event, returning whether the event was and the keys of the matches that were.
+func (s *Server) takeEntered(eventKey string) (bool, []string) {
+	s.offlineMu.Lock()
+	defer s.offlineMu.Unlock()
+
+	entered := s.offlineEntered.events[eventKey]
+	delete(s.offlineEntered.events, eventKey)
+
+	var matchKeys []string
+	for key, ek := range s.offlineEntered.matches {
+		if ek == eventKey {
+			matchKeys = append(matchKeys, key)
+			delete(s.offlineEntered.matches, key)
+		}
+	}
+	sort.Strings(matchKeys)
+
+	return entered, matchKeys
+}
+
+func (s *Server) getOfflineStatus() offlineStatus {
+	s.offlineMu.RLock()
+	defer s.offlineMu.RUnlock()
+	return s.offline
+}
+
+// reconcile polls the TBA API for the events of the season, and the matches of
+// every event that was running while the server was offline. The schedule data
+// of those events that was loaded from the offline schedule or entered while
+// offline is unmarked as manual first, so TBA data replaces it. Other manual
+// data is left for admins to sync.
+func (s *Server) reconcile(ctx context.Context) {
+	since := time.Now()
+	if status := s.getOfflineStatus(); status.Since != nil {
+		since = *status.Since
+	}
+
+	ranWhileOffline := func(e event.BasicEvent) bool {
+		return e.Date.Year() == s.year && !e.EndDate.Add(time.Hour*24).Before(since) && !e.Date.After(time.Now().Add(time.Hour*24))
+	}
+
+	events, err := s.store.Event.GetBasicEvents(ctx)
+	if err != nil && err != store.ErrNoResults {
+		s.logger.LogJSON(map[string]interface{}{"error": fmt.Errorf("server: reconciling: getting events: %v", err).Error()})
+		return
+	}
+
+	for _, e := range events {
+		if !ranWhileOffline(e) {
+			continue
+		}
+
+		entered, matchKeys := s.takeEntered(e.Key)
+		if entered {
+			if err := s.store.Event.SetManual(ctx, e.Key, false); err != nil {
+				s.logger.LogJSON(map[string]interface{}{"error": fmt.Errorf("server: reconciling: unmarking manual event '%s': %v", e.Key, err).Error()})
+			}
+		}
+		if len(matchKeys) > 0 {
+			if err := s.store.Match.SetManualByKey(ctx, matchKeys, false); err != nil {
+				s.logger.LogJSON(map[string]interface{}{"error": fmt.Errorf("server: reconciling: unmarking manual matches for event '%s': %v", e.Key, err).Error()})
+			}
+		}
+		s.forgetMatches(e.Key)
+	}
+
+	if f, ok := s.consumer.(tba.Forgetter); ok {
+		f.ForgetEvents(s.year)
+	}
+	s.pollEvents(ctx)
+
+	// events polled just now may have been running too
+	events, err = s.store.Event.GetBasicEvents(ctx)
+	if err != nil && err != store.ErrNoResults {
+		s.logger.LogJSON(map[string]interface{}{"error": fmt.Errorf("server: reconciling: getting events: %v", err).Error()})
+		return
+	}
+
+	for _, e := range events {
+		if ranWhileOffline(e) {
+			s.pollMatches(ctx, e.Key)
+		}
+	}
+
+	now := time.Now()
+	s.offlineMu.Lock()
+	s.offline.Reconciled = &now
+	s.offlineMu.Unlock()
+}
+
+func (s *Server) offlineHandler(w http.ResponseWriter, r *http.Request) {
+	respond.Negotiate(w, r, s.getOfflineStatus())
+}
+
+func (s *Server) updateOfflineHandler(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Offline *bool `json:"offline"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Offline == nil {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	if wasOffline := s.setOffline(*req.Offline); wasOffline && !*req.Offline {
+		go s.reconcile(context.Background())
+	}
+
+	respond.Negotiate(w, r, s.getOfflineStatus())
+}
@@ -0,0 +1,133 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Pigmice2733/scouting-backend/internal/archive"
+	"github.com/Pigmice2733/scouting-backend/internal/logger"
+	"github.com/Pigmice2733/scouting-backend/internal/schedule"
+	"github.com/Pigmice2733/scouting-backend/internal/store"
+	"github.com/Pigmice2733/scouting-backend/internal/store/event"
+	"github.com/Pigmice2733/scouting-backend/internal/store/match"
+	"github.com/Pigmice2733/scouting-backend/internal/tba/mock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestServer(consumer mock.DB) (*Server, *memEvents, *memMatches) {
+	events, matches := newMemEvents(), newMemMatches()
+
+	s := &Server{
+		store:    &store.Service{Event: events, Match: matches},
+		consumer: consumer,
+		logger:   logger.New(ioutil.Discard),
+		year:     time.Now().Year(),
+	}
+
+	return s, events, matches
+}
+
+func testMatch(key string, red, blue []string) match.Match {
+	return match.Match{
+		BasicMatch:   match.BasicMatch{Key: key, EventKey: "orwil"},
+		RedScore:     -1,
+		BlueScore:    -1,
+		RedAlliance:  red,
+		BlueAlliance: blue,
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	running := event.BasicEvent{Key: "orwil", Name: "Wilsonville", Date: now.Add(-time.Hour * 24), EndDate: now.Add(time.Hour * 24)}
+	upcoming := event.BasicEvent{Key: "wasno", Name: "Glacier Peak", Date: now.Add(time.Hour * 24 * 14), EndDate: now.Add(time.Hour * 24 * 16)}
+
+	tbaQM1 := testMatch("orwil_qm1", []string{"frc2733", "frc1540", "frc4488"}, []string{"frc1425", "frc360", "frc1318"})
+	tbaQM1.RedScore, tbaQM1.BlueScore = 120, 98
+	tbaQM2 := testMatch("orwil_qm2", []string{"frc2990", "frc753", "frc955"}, []string{"frc1983", "frc2471", "frc3674"})
+
+	tbaRunning := running
+	tbaRunning.Name = "PNW District Wilsonville Event"
+
+	s, events, matches := newTestServer(mock.DB{
+		Events:  map[int][]event.BasicEvent{now.Year(): {tbaRunning, upcoming}},
+		Matches: map[string][]match.Match{"orwil": {tbaQM1, tbaQM2}},
+	})
+
+	// a match is corrected by hand before the outage
+	correctedQM3 := testMatch("orwil_qm3", []string{"frc2733", "frc2990", "frc753"}, []string{"frc1425", "frc360", "frc1318"})
+	correctedQM3.Manual = true
+	_, err := s.store.Match.MassUpsert(ctx, []match.Match{correctedQM3})
+	assert.Nil(t, err)
+
+	// the schedule is preloaded, and a match is entered by hand at the venue
+	loadedQM1 := testMatch("orwil_qm1", []string{"frc2733", "frc1540", "frc9999"}, []string{"frc1425", "frc360", "frc1318"})
+	sch, err := json.Marshal(schedule.Schedule{
+		Events:  []event.BasicEvent{running, upcoming},
+		Matches: []archive.Match{{EventKey: "orwil", Match: loadedQM1}},
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	f, err := ioutil.TempFile("", "schedule")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer os.Remove(f.Name())
+	_, err = f.Write(sch)
+	assert.Nil(t, err)
+	assert.Nil(t, f.Close())
+
+	if !assert.Nil(t, s.loadSchedule(ctx, f.Name())) {
+		return
+	}
+	s.setOffline(true)
+
+	router := mux.NewRouter()
+	router.HandleFunc("/events/{eventKey}/matches/{matchKey}", s.upsertMatchHandler)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("PUT", "/events/orwil/matches/orwil_qm2",
+		strings.NewReader(`{"redAlliance": ["frc2990", "frc753", "frc955"], "blueAlliance": ["frc1983", "frc2471", "frc3674"]}`)))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// polling while offline changes nothing
+	s.pollMatches(ctx, "orwil")
+	assert.Equal(t, []string{"frc2733", "frc1540", "frc9999"}, matches.matches["orwil_qm1"].RedAlliance)
+
+	s.setOffline(false)
+	s.reconcile(ctx)
+
+	// the event that ran while offline is replaced by tba data
+	assert.Equal(t, tbaRunning.Name, events.events["orwil"].Name)
+	assert.False(t, events.events["orwil"].Manual)
+
+	qm1 := matches.matches["orwil_qm1"]
+	assert.False(t, qm1.Manual)
+	assert.Equal(t, tbaQM1.RedAlliance, qm1.RedAlliance)
+	assert.Equal(t, 120, qm1.RedScore)
+
+	qm2 := matches.matches["orwil_qm2"]
+	assert.False(t, qm2.Manual)
+
+	// manual data that wasn't loaded or entered while offline stays, as do
+	// events that didn't run while offline, until they are synced
+	assert.True(t, matches.matches["orwil_qm3"].Manual)
+	assert.Equal(t, correctedQM3.RedAlliance, matches.matches["orwil_qm3"].RedAlliance)
+	assert.True(t, events.events["wasno"].Manual)
+
+	if status := s.getOfflineStatus(); assert.NotNil(t, status.Reconciled) {
+		assert.False(t, status.Offline)
+	}
+}
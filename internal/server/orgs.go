@@ -0,0 +1,43 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/Pigmice2733/scouting-backend/internal/respond"
+	"github.com/Pigmice2733/scouting-backend/internal/store/organization"
+)
+
+var orgIDRegex = regexp.MustCompile(`^[0-9a-z-]+$`)
+
+func (s *Server) orgsHandler(w http.ResponseWriter, r *http.Request) {
+	orgs, err := s.store.Org.GetAll(r.Context())
+	if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("getting organizations: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	respond.Negotiate(w, r, orgs)
+}
+
+func (s *Server) createOrgHandler(w http.ResponseWriter, r *http.Request) {
+	var o organization.Organization
+	if err := json.NewDecoder(r.Body).Decode(&o); err != nil {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	if !orgIDRegex.MatchString(o.ID) || o.Name == "" {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	if err := s.store.Org.Create(r.Context(), o); err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("creating organization: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+}
@@ -4,162 +4,692 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
+	"github.com/Pigmice2733/scouting-backend/internal/consensus"
 	"github.com/Pigmice2733/scouting-backend/internal/store/picklist"
+	"github.com/Pigmice2733/scouting-backend/internal/store/role"
 
 	"github.com/Pigmice2733/scouting-backend/internal/respond"
+	"github.com/Pigmice2733/scouting-backend/internal/server/logic"
 	"github.com/Pigmice2733/scouting-backend/internal/store"
 
 	"github.com/gorilla/mux"
 )
 
 func (s *Server) picklistHandler(w http.ResponseWriter, r *http.Request) {
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
 	id := mux.Vars(r)["id"]
 
-	p, err := s.store.Picklist.Get(id)
+	p, err := s.store.Picklist.Get(r.Context(), org, id)
 	if err != nil {
 		if err == store.ErrNoResults {
-			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			respond.Error(w, http.StatusNotFound)
 		} else {
-			s.logger.LogRequestError(r, fmt.Errorf("getting picklist %d: %v", id, err))
-			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			s.logger.LogRequestError(r, fmt.Errorf("getting picklist %s: %v", id, err))
+			respond.Error(w, http.StatusInternalServerError)
 		}
 		return
 	}
 
-	respond.JSON(w, p)
+	if !hasPermission(r, role.PicklistReadAll) {
+		permission, err := s.picklistPermission(r, org, id, p.Owner)
+		if err != nil {
+			s.logger.LogRequestError(r, fmt.Errorf("getting picklist permission: %v", err))
+			respond.Error(w, http.StatusInternalServerError)
+			return
+		}
+
+		if permission == "" {
+			respond.Error(w, http.StatusForbidden)
+			return
+		}
+	}
+
+	respond.Negotiate(w, r, struct {
+		picklist.Picklist
+		List interface{} `json:"list"`
+	}{p, pickList(r, p.List)})
+}
+
+// pickList is the list of picks to respond with. Clients get just the teams,
+// like before picks could be annotated, unless they ask for the picks
+// themselves with ?annotated=true.
+func pickList(r *http.Request, picks []picklist.Pick) interface{} {
+	if r.URL.Query().Get("annotated") == "true" {
+		return picks
+	}
+	return picklist.Teams(picks)
+}
+
+func validPicks(picks []picklist.Pick) bool {
+	for _, p := range picks {
+		if !p.Valid() {
+			return false
+		}
+	}
+	return true
+}
+
+// picklistPermission gets the permission the authenticated user of a request
+// has on a picklist. Owners can edit their picklists, anyone else has the
+// highest permission the picklist was shared with them with, or none.
+func (s *Server) picklistPermission(r *http.Request, org, id, owner string) (string, error) {
+	username, _ := r.Context().Value(keyUsernameCtx).(string)
+	if username == owner {
+		return picklist.Edit, nil
+	}
+
+	roles, _ := r.Context().Value(keyRolesCtx).([]string)
+
+	permission, err := s.store.Picklist.GetPermission(r.Context(), org, id, username, roles)
+	if err == store.ErrNoResults {
+		return "", nil
+	}
+	return permission, err
 }
 
 func (s *Server) picklistsHandler(w http.ResponseWriter, r *http.Request) {
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
 	username, ok := r.Context().Value(keyUsernameCtx).(string)
 	if !ok {
-		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		respond.Error(w, http.StatusBadRequest)
 		return
 	}
 
-	bPicklists, err := s.store.Picklist.GetBasicPicklists(username)
+	bPicklists, err := s.store.Picklist.GetBasicPicklists(r.Context(), org, username)
 	if err != nil {
 		s.logger.LogRequestError(r, fmt.Errorf("getting picklists: %v", err))
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		respond.Error(w, http.StatusInternalServerError)
 		return
 	}
 
-	respond.JSON(w, bPicklists)
+	respond.Negotiate(w, r, bPicklists)
 }
 
 func (s *Server) newPicklistHandler(w http.ResponseWriter, r *http.Request) {
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
 	username, ok := r.Context().Value(keyUsernameCtx).(string)
 	if !ok {
-		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		respond.Error(w, http.StatusBadRequest)
 		return
 	}
 
 	var p picklist.Picklist
 	if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
-		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	if !validPicks(p.List) {
+		respond.ErrorCode(w, http.StatusBadRequest, codeInvalidPicks, "picks must be either a team or a separator")
 		return
 	}
 
 	p.Owner = username
+	p.Org = org
 
-	id, err := s.store.Picklist.Insert(p)
+	id, err := s.store.Picklist.Insert(r.Context(), p)
 	if err != nil {
 		s.logger.LogRequestError(r, fmt.Errorf("submitting picklist: %v", err))
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		respond.Error(w, http.StatusInternalServerError)
 		return
 	}
 
-	respond.JSON(w, id)
+	respond.Negotiate(w, r, id)
 }
 
 func (s *Server) updatePicklistHandler(w http.ResponseWriter, r *http.Request) {
-	username, ok := r.Context().Value(keyUsernameCtx).(string)
+	org, ok := r.Context().Value(keyOrgCtx).(string)
 	if !ok {
-		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		respond.Error(w, http.StatusBadRequest)
 		return
 	}
 
 	id := mux.Vars(r)["id"]
 
-	var p picklist.Picklist
-	if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
-		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+	// the version is optional, so clients from before picklists were
+	// versioned can still update them
+	var req struct {
+		picklist.Picklist
+		Version *int              `json:"version"`
+		List    []json.RawMessage `json:"list"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respond.Error(w, http.StatusBadRequest)
 		return
 	}
 
-	p.Owner = username
+	p := req.Picklist
+	if req.List != nil {
+		p.List = make([]picklist.Pick, len(req.List))
+		for i, data := range req.List {
+			if err := json.Unmarshal(data, &p.List[i]); err != nil {
+				respond.Error(w, http.StatusBadRequest)
+				return
+			}
+		}
+	}
+	if req.Version != nil {
+		if *req.Version < 1 {
+			respond.ErrorCode(w, http.StatusConflict, codeVersionConflict, "picklist versions start at 1")
+			return
+		}
+		p.Version = *req.Version
+	}
+
+	if !validPicks(p.List) {
+		respond.ErrorCode(w, http.StatusBadRequest, codeInvalidPicks, "picks must be either a team or a separator")
+		return
+	}
+
+	p.Org = org
 	p.ID = id
 
-	realOwner, err := s.store.Picklist.GetOwner(p.ID)
+	realOwner, err := s.store.Picklist.GetOwner(r.Context(), org, p.ID)
 	if err != nil {
 		if err == store.ErrNoResults {
-			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			respond.Error(w, http.StatusNotFound)
 		} else {
 			s.logger.LogRequestError(r, fmt.Errorf("getting picklist owner: %v", err))
-			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			respond.Error(w, http.StatusInternalServerError)
 		}
 		return
 	}
 
-	if realOwner != p.Owner {
-		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+	permission, err := s.picklistPermission(r, org, id, realOwner)
+	if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("getting picklist permission: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	if permission != picklist.Edit {
+		respond.Error(w, http.StatusForbidden)
 		return
 	}
 
-	if err := s.store.Picklist.Update(p); err != nil {
+	p.Owner = realOwner
+
+	// clients that only know about teams send them as strings, so they are
+	// merged into the stored picks to keep the annotations and separators
+	if teams, plain := picklist.PlainTeams(req.List); plain {
+		stored, err := s.store.Picklist.Get(r.Context(), org, id)
+		if err == store.ErrNoResults {
+			respond.Error(w, http.StatusNotFound)
+			return
+		} else if err != nil {
+			s.logger.LogRequestError(r, fmt.Errorf("getting picklist %s: %v", id, err))
+			respond.Error(w, http.StatusInternalServerError)
+			return
+		}
+
+		p.List = picklist.MergeTeams(stored.List, teams)
+		if p.Version == 0 {
+			p.Version = stored.Version
+		}
+	}
+
+	username, _ := r.Context().Value(keyUsernameCtx).(string)
+
+	version, err := s.store.Picklist.Update(r.Context(), p, username)
+	if err == store.ErrConflict {
+		respond.ErrorCode(w, http.StatusConflict, codeVersionConflict, "picklist was changed since it was read")
+		return
+	} else if err == store.ErrNoResults {
+		respond.Error(w, http.StatusNotFound)
+		return
+	} else if err != nil {
 		s.logger.LogRequestError(r, fmt.Errorf("updating picklist: %v", err))
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		respond.Error(w, http.StatusInternalServerError)
 		return
 	}
+
+	respond.Negotiate(w, r, version)
 }
 
 func (s *Server) deletePicklistHandler(w http.ResponseWriter, r *http.Request) {
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
 	username, ok := r.Context().Value(keyUsernameCtx).(string)
 	if !ok {
-		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		respond.Error(w, http.StatusBadRequest)
 		return
 	}
 
 	id := mux.Vars(r)["id"]
 
-	realOwner, err := s.store.Picklist.GetOwner(id)
+	realOwner, err := s.store.Picklist.GetOwner(r.Context(), org, id)
 	if err != nil {
 		if err == store.ErrNoResults {
-			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			respond.Error(w, http.StatusNotFound)
 		} else {
 			s.logger.LogRequestError(r, fmt.Errorf("getting picklist owner: %v", err))
-			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			respond.Error(w, http.StatusInternalServerError)
 		}
 		return
 	}
 
 	if realOwner != username {
-		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		respond.Error(w, http.StatusUnauthorized)
 		return
 	}
 
-	if err := s.store.Picklist.Delete(id); err != nil {
+	if err := s.store.Picklist.Delete(r.Context(), org, id); err != nil {
 		s.logger.LogRequestError(r, fmt.Errorf("deleting picklist: %v", err))
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		respond.Error(w, http.StatusInternalServerError)
 		return
 	}
 }
 
 func (s *Server) picklistEventHandler(w http.ResponseWriter, r *http.Request) {
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
 	username, ok := r.Context().Value(keyUsernameCtx).(string)
 	if !ok {
-		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		respond.Error(w, http.StatusBadRequest)
 		return
 	}
 
 	eventKey := mux.Vars(r)["eventKey"]
 
-	bPicklists, err := s.store.Picklist.GetByEvent(username, eventKey)
+	bPicklists, err := s.store.Picklist.GetByEvent(r.Context(), org, username, eventKey)
 	if err != nil {
 		s.logger.LogRequestError(r, fmt.Errorf("getting picklists: %v", err))
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	respond.Negotiate(w, r, bPicklists)
+}
+
+func (s *Server) sharedPicklistsHandler(w http.ResponseWriter, r *http.Request) {
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	username, ok := r.Context().Value(keyUsernameCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	roles, _ := r.Context().Value(keyRolesCtx).([]string)
+
+	sPicklists, err := s.store.Picklist.GetShared(r.Context(), org, username, roles)
+	if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("getting shared picklists: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	respond.Negotiate(w, r, sPicklists)
+}
+
+func (s *Server) picklistSharesHandler(w http.ResponseWriter, r *http.Request) {
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	username, ok := r.Context().Value(keyUsernameCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+
+	realOwner, err := s.store.Picklist.GetOwner(r.Context(), org, id)
+	if err != nil {
+		if err == store.ErrNoResults {
+			respond.Error(w, http.StatusNotFound)
+		} else {
+			s.logger.LogRequestError(r, fmt.Errorf("getting picklist owner: %v", err))
+			respond.Error(w, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if realOwner != username {
+		respond.Error(w, http.StatusForbidden)
+		return
+	}
+
+	shares, err := s.store.Picklist.GetShares(r.Context(), org, id)
+	if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("getting picklist shares: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	respond.Negotiate(w, r, shares)
+}
+
+func (s *Server) updatePicklistSharesHandler(w http.ResponseWriter, r *http.Request) {
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	username, ok := r.Context().Value(keyUsernameCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+
+	var shares []picklist.Share
+	if err := json.NewDecoder(r.Body).Decode(&shares); err != nil {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	realOwner, err := s.store.Picklist.GetOwner(r.Context(), org, id)
+	if err != nil {
+		if err == store.ErrNoResults {
+			respond.Error(w, http.StatusNotFound)
+		} else {
+			s.logger.LogRequestError(r, fmt.Errorf("getting picklist owner: %v", err))
+			respond.Error(w, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if realOwner != username {
+		respond.Error(w, http.StatusForbidden)
+		return
+	}
+
+	var roles []string
+	for _, share := range shares {
+		if (share.Username == "") == (share.Role == "") || (share.Permission != picklist.Read && share.Permission != picklist.Edit) {
+			respond.Error(w, http.StatusBadRequest)
+			return
+		}
+
+		if share.Role != "" {
+			roles = append(roles, share.Role)
+			continue
+		}
+
+		// picklists can only be shared with other users in the same organization
+		u, err := s.store.User.Get(r.Context(), share.Username)
+		if err == store.ErrNoResults || (err == nil && (u.Org != org || u.Username == username)) {
+			respond.Error(w, http.StatusBadRequest)
+			return
+		} else if err != nil {
+			s.logger.LogRequestError(r, fmt.Errorf("getting user: %v", err))
+			respond.Error(w, http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if ok, err := logic.ValidRoles(r.Context(), roles, s.store.Role); err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("validating roles: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	} else if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	if err := s.store.Picklist.SetShares(r.Context(), org, id, shares); err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("updating picklist shares: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+}
+
+// authorizePicklist checks that the authenticated user of a request has a
+// permission on a picklist, responding with an error if they don't. Users with
+// picklist:read-all can read any picklist.
+func (s *Server) authorizePicklist(w http.ResponseWriter, r *http.Request, org, id, required string) bool {
+	owner, err := s.store.Picklist.GetOwner(r.Context(), org, id)
+	if err != nil {
+		if err == store.ErrNoResults {
+			respond.Error(w, http.StatusNotFound)
+		} else {
+			s.logger.LogRequestError(r, fmt.Errorf("getting picklist owner: %v", err))
+			respond.Error(w, http.StatusInternalServerError)
+		}
+		return false
+	}
+
+	if required == picklist.Read && hasPermission(r, role.PicklistReadAll) {
+		return true
+	}
+
+	permission, err := s.picklistPermission(r, org, id, owner)
+	if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("getting picklist permission: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return false
+	}
+
+	if permission == "" || (required == picklist.Edit && permission != picklist.Edit) {
+		respond.Error(w, http.StatusForbidden)
+		return false
+	}
+
+	return true
+}
+
+func (s *Server) picklistRevisionsHandler(w http.ResponseWriter, r *http.Request) {
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+
+	if !s.authorizePicklist(w, r, org, id, picklist.Read) {
+		return
+	}
+
+	revisions, err := s.store.Picklist.GetRevisions(r.Context(), org, id)
+	if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("getting picklist revisions: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	type revision struct {
+		picklist.Revision
+		List interface{} `json:"list"`
+	}
+
+	resp := make([]revision, 0, len(revisions))
+	for _, rev := range revisions {
+		resp = append(resp, revision{rev, pickList(r, rev.List)})
+	}
+
+	respond.Negotiate(w, r, resp)
+}
+
+func (s *Server) picklistDiffHandler(w http.ResponseWriter, r *http.Request) {
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+
+	from, fromErr := strconv.Atoi(r.URL.Query().Get("from"))
+	to, toErr := strconv.Atoi(r.URL.Query().Get("to"))
+	if fromErr != nil || toErr != nil {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	if !s.authorizePicklist(w, r, org, id, picklist.Read) {
+		return
+	}
+
+	var revisions [2]picklist.Revision
+	for i, version := range []int{from, to} {
+		rev, err := s.store.Picklist.GetRevision(r.Context(), org, id, version)
+		if err == store.ErrNoResults {
+			respond.Error(w, http.StatusNotFound)
+			return
+		} else if err != nil {
+			s.logger.LogRequestError(r, fmt.Errorf("getting picklist revision: %v", err))
+			respond.Error(w, http.StatusInternalServerError)
+			return
+		}
+		revisions[i] = rev
+	}
+
+	respond.Negotiate(w, r, picklist.Diff(picklist.Teams(revisions[0].List), picklist.Teams(revisions[1].List)))
+}
+
+func (s *Server) restorePicklistHandler(w http.ResponseWriter, r *http.Request) {
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	username, ok := r.Context().Value(keyUsernameCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	version, err := strconv.Atoi(vars["version"])
+	if err != nil {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	if !s.authorizePicklist(w, r, org, id, picklist.Edit) {
+		return
+	}
+
+	rev, err := s.store.Picklist.GetRevision(r.Context(), org, id, version)
+	if err == store.ErrNoResults {
+		respond.Error(w, http.StatusNotFound)
+		return
+	} else if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("getting picklist revision: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	p, err := s.store.Picklist.Get(r.Context(), org, id)
+	if err == store.ErrNoResults {
+		respond.Error(w, http.StatusNotFound)
+		return
+	} else if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("getting picklist %s: %v", id, err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	// restoring saves the old revision as a new version, so it can be undone
+	p.EventKey, p.Name, p.List = rev.EventKey, rev.Name, rev.List
+
+	newVersion, err := s.store.Picklist.Update(r.Context(), p, username)
+	if err == store.ErrConflict {
+		respond.ErrorCode(w, http.StatusConflict, codeVersionConflict, "picklist was changed since it was read")
+		return
+	} else if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("restoring picklist: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	respond.Negotiate(w, r, newVersion)
+}
+
+func (s *Server) picklistConsensusHandler(w http.ResponseWriter, r *http.Request) {
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	method := r.URL.Query().Get("method")
+	if method == "" {
+		method = consensus.Borda
+	}
+
+	ids := strings.Split(r.URL.Query().Get("ids"), ",")
+	if len(ids) < 2 {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	var eventKey string
+	var lists [][]string
+	for _, id := range ids {
+		if !s.authorizePicklist(w, r, org, id, picklist.Read) {
+			return
+		}
+
+		p, err := s.store.Picklist.Get(r.Context(), org, id)
+		if err == store.ErrNoResults {
+			respond.Error(w, http.StatusNotFound)
+			return
+		} else if err != nil {
+			s.logger.LogRequestError(r, fmt.Errorf("getting picklist %s: %v", id, err))
+			respond.Error(w, http.StatusInternalServerError)
+			return
+		}
+
+		// only picklists for the same event can be merged
+		if eventKey != "" && p.EventKey != eventKey {
+			respond.Error(w, http.StatusBadRequest)
+			return
+		}
+		eventKey = p.EventKey
+
+		lists = append(lists, picklist.Teams(p.List))
+	}
+
+	rankings, err := consensus.Aggregate(method, lists)
+	if err == consensus.ErrUnsupportedMethod {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	} else if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("aggregating picklists: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
 		return
 	}
 
-	respond.JSON(w, bPicklists)
+	respond.Negotiate(w, r, map[string]interface{}{"eventKey": eventKey, "method": method, "rankings": rankings})
 }
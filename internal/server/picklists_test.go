@@ -0,0 +1,122 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Pigmice2733/scouting-backend/internal/store/picklist"
+	"github.com/Pigmice2733/scouting-backend/internal/store/role"
+	"github.com/Pigmice2733/scouting-backend/internal/tba/mock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+const testPicklistID = "de3f0e41-8fde-45d5-b7b4-cb5e06c0584d"
+
+func newPicklistServer(list []picklist.Pick) (*Server, *memPicklists) {
+	s, _, _ := newTestServer(mock.DB{})
+
+	picklists := newMemPicklists()
+	picklists.picklists[testPicklistID] = picklist.Picklist{
+		BasicPicklist: picklist.BasicPicklist{ID: testPicklistID, EventKey: "2018orore", Name: "switch"},
+		List:          list,
+		Owner:         "franklin",
+		Org:           "frc2733",
+		Version:       3,
+	}
+	s.store.Picklist = picklists
+
+	return s, picklists
+}
+
+// updatePicklist updates the test picklist as its owner.
+func updatePicklist(s *Server, body string) *httptest.ResponseRecorder {
+	router := mux.NewRouter()
+	router.HandleFunc("/picklists/{id}", s.updatePicklistHandler)
+
+	ctx := context.WithValue(context.Background(), keyOrgCtx, "frc2733")
+	ctx = context.WithValue(ctx, keyUsernameCtx, "franklin")
+	ctx = context.WithValue(ctx, keyPermissionsCtx, []string{role.PicklistWrite})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("PUT", "/picklists/"+testPicklistID, strings.NewReader(body)).WithContext(ctx))
+	return w
+}
+
+func TestUpdatePicklistVersion(t *testing.T) {
+	s, picklists := newPicklistServer([]picklist.Pick{{Team: "frc2733"}})
+
+	w := updatePicklist(s, `{"eventKey": "2018orore", "name": "switch", "list": [{"team": "frc2471"}], "version": 3}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "4\n", w.Body.String())
+
+	// updates based on an old version are rejected
+	w = updatePicklist(s, `{"eventKey": "2018orore", "name": "switch", "list": [{"team": "frc254"}], "version": 3}`)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), codeVersionConflict)
+	assert.Equal(t, []picklist.Pick{{Team: "frc2471"}}, picklists.picklists[testPicklistID].List)
+
+	w = updatePicklist(s, `{"eventKey": "2018orore", "name": "switch", "list": [{"team": "frc254"}], "version": 0}`)
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestUpdatePicklistWithoutVersion(t *testing.T) {
+	s, picklists := newPicklistServer([]picklist.Pick{{Team: "frc2733"}})
+
+	// clients from before picklists were versioned don't send one
+	w := updatePicklist(s, `{"eventKey": "2018orore", "name": "switch", "list": [{"team": "frc2471"}]}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "4\n", w.Body.String())
+
+	w = updatePicklist(s, `{"eventKey": "2018orore", "name": "switch", "list": [{"team": "frc254"}]}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "5\n", w.Body.String())
+	assert.Equal(t, []picklist.Pick{{Team: "frc254"}}, picklists.picklists[testPicklistID].List)
+}
+
+func TestUpdatePicklistTeamsRoundTrip(t *testing.T) {
+	stored := []picklist.Pick{
+		{Team: "frc2733", Tier: 1, Notes: "fast climb", Tags: []string{"climber"}},
+		{Team: "frc2471"},
+		{Separator: "backups"},
+		{Team: "frc254", DoNotPick: true, DoNotPickReason: "brownouts in qm12"},
+	}
+	s, picklists := newPicklistServer(stored)
+
+	router := mux.NewRouter()
+	router.HandleFunc("/picklists/{id}", s.picklistHandler)
+
+	ctx := context.WithValue(context.Background(), keyOrgCtx, "frc2733")
+	ctx = context.WithValue(ctx, keyUsernameCtx, "franklin")
+
+	// a client that only knows about teams reads the picklist...
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/picklists/"+testPicklistID, nil).WithContext(ctx))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var read struct {
+		List []string `json:"list"`
+	}
+	assert.Nil(t, json.NewDecoder(w.Body).Decode(&read))
+	assert.Equal(t, []string{"frc2733", "frc2471", "frc254"}, read.List)
+
+	// ...and writes it back unchanged, which keeps every annotation
+	list, _ := json.Marshal(read.List)
+	w = updatePicklist(s, `{"eventKey": "2018orore", "name": "switch", "list": `+string(list)+`}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, stored, picklists.picklists[testPicklistID].List)
+
+	// reordering keeps them too
+	w = updatePicklist(s, `{"eventKey": "2018orore", "name": "switch", "list": ["frc2471", "frc2733", "frc254"]}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []picklist.Pick{stored[1], stored[0], stored[2], stored[3]}, picklists.picklists[testPicklistID].List)
+
+	// sending objects replaces the annotations
+	w = updatePicklist(s, `{"eventKey": "2018orore", "name": "switch", "list": [{"team": "frc2733"}, "frc254"]}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []picklist.Pick{{Team: "frc2733"}, {Team: "frc254"}}, picklists.picklists[testPicklistID].List)
+}
@@ -0,0 +1,81 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/Pigmice2733/scouting-backend/internal/tba"
+)
+
+// rankingsTTL is how long the rankings of an event are reused before they are
+// retrieved again, so that clients refreshing rankings don't each make a
+// request to the upstream API.
+const rankingsTTL = time.Minute
+
+// cachedRankings are the rankings of an event, or the error retrieving them.
+// done is closed once they have been retrieved, so that concurrent requests
+// for the same event wait for a single retrieval.
+type cachedRankings struct {
+	done     chan struct{}
+	rankings []tba.Ranking
+	err      error
+	expires  time.Time
+}
+
+// getRankings gets the rankings of an event from the ranker, reusing them for
+// rankingsTTL. Events without rankings are remembered too, but other errors
+// aren't.
+func (s *Server) getRankings(ctx context.Context, ranker tba.Ranker, eventKey string) ([]tba.Ranking, error) {
+	now := time.Now()
+
+	s.rankingsMu.Lock()
+	if s.rankings == nil {
+		s.rankings = make(map[string]*cachedRankings)
+	}
+
+	c, ok := s.rankings[eventKey]
+	if !ok || c.expired(now) {
+		// drop the other expired rankings while here, so events that aren't
+		// being looked at anymore don't stay around
+		for key, other := range s.rankings {
+			if other.expired(now) {
+				delete(s.rankings, key)
+			}
+		}
+
+		c = &cachedRankings{done: make(chan struct{})}
+		s.rankings[eventKey] = c
+		s.rankingsMu.Unlock()
+
+		// other requests wait on this retrieval, so it isn't tied to the
+		// request that started it
+		pollCtx, cancel := context.WithTimeout(context.Background(), pollTimeout)
+		c.rankings, c.err = ranker.GetRankings(pollCtx, eventKey)
+		cancel()
+
+		c.expires = time.Now().Add(rankingsTTL)
+		if c.err != nil && c.err != tba.ErrNoRankings {
+			c.expires = time.Now()
+		}
+		close(c.done)
+	} else {
+		s.rankingsMu.Unlock()
+	}
+
+	select {
+	case <-c.done:
+		return c.rankings, c.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// expired returns whether the rankings were retrieved and are too old to use.
+func (c *cachedRankings) expired(now time.Time) bool {
+	select {
+	case <-c.done:
+		return !now.Before(c.expires)
+	default:
+		return false
+	}
+}
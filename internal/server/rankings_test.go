@@ -0,0 +1,70 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Pigmice2733/scouting-backend/internal/store/event"
+	"github.com/Pigmice2733/scouting-backend/internal/tba"
+	"github.com/Pigmice2733/scouting-backend/internal/tba/mock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingRanker is a consumer with rankings that counts how many times they
+// are retrieved.
+type countingRanker struct {
+	mock.DB
+
+	mu    sync.Mutex
+	calls int
+}
+
+func (c *countingRanker) GetRankings(ctx context.Context, eventKey string) ([]tba.Ranking, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.calls++
+	return []tba.Ranking{{Rank: 1, Team: "frc2733"}}, nil
+}
+
+func TestRankingsCache(t *testing.T) {
+	s, events, _ := newTestServer(mock.DB{})
+	events.events["orwil"] = event.BasicEvent{Key: "orwil", Name: "Wilsonville"}
+
+	ranker := &countingRanker{}
+	s.consumer = ranker
+
+	router := mux.NewRouter()
+	router.HandleFunc("/events/{eventKey}/rankings", s.rankingsHandler)
+
+	get := func(eventKey string) int {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/events/"+eventKey+"/rankings", nil))
+		return w.Code
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, http.StatusOK, get("orwil"))
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 1, ranker.calls)
+
+	// unknown events never reach the ranker
+	assert.Equal(t, http.StatusNotFound, get("madeup"))
+	assert.Equal(t, 1, ranker.calls)
+
+	// expired rankings are retrieved again
+	s.rankings["orwil"].expires = time.Now().Add(-time.Second)
+	assert.Equal(t, http.StatusOK, get("orwil"))
+	assert.Equal(t, 2, ranker.calls)
+}
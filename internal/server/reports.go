@@ -4,73 +4,180 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/Pigmice2733/scouting-backend/internal/analysis"
 	"github.com/Pigmice2733/scouting-backend/internal/respond"
+	"github.com/Pigmice2733/scouting-backend/internal/search"
 	"github.com/Pigmice2733/scouting-backend/internal/store"
 	"github.com/Pigmice2733/scouting-backend/internal/store/report"
+	"github.com/Pigmice2733/scouting-backend/internal/store/tag"
 	"github.com/gorilla/mux"
 )
 
 func (s *Server) reportHandler(w http.ResponseWriter, r *http.Request) {
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
 	var rep report.Report
 	if err := json.NewDecoder(r.Body).Decode(&rep); err != nil {
-		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		respond.Error(w, http.StatusBadRequest)
 		return
 	}
 
 	vars := mux.Vars(r)
 	rep.EventKey, rep.MatchKey = vars["eventKey"], vars["matchKey"]
+	rep.Org = org
 
 	rep.Reporter = ""
 	if reporter, ok := r.Context().Value(keyUsernameCtx).(string); ok {
 		rep.Reporter = reporter
 	}
 
-	if !analysis.CompliantData(s.schema, rep.Stats) {
-		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+	schema, err := s.getSchema(r.Context(), org)
+	if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("getting schema: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	if !analysis.CompliantData(schema, rep.Stats) {
+		respond.ErrorCode(w, http.StatusBadRequest, codeInvalidStats, "stats don't match the report schema")
+		return
+	}
+
+	tags, err := s.getTags(r.Context(), org, s.eventYear(rep.EventKey))
+	if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("getting tags: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	if err := tag.Validate(tags, rep.Tags); err != nil {
+		respond.ErrorCode(w, http.StatusBadRequest, codeInvalidTags, err.Error())
 		return
 	}
 
-	if err := s.store.Report.Upsert(rep, s.store.Alliance); err != nil {
+	if err := s.store.Report.Upsert(r.Context(), rep, s.store.Alliance); err != nil {
 		s.logger.LogRequestError(r, fmt.Errorf("upserting report: %v", err))
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		respond.Error(w, http.StatusInternalServerError)
 		return
 	}
 }
 
 func (s *Server) getTeamEventReportsHandler(w http.ResponseWriter, r *http.Request) {
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
 	vars := mux.Vars(r)
 	eventKey, team := vars["eventKey"], vars["team"]
 
-	reps, err := s.store.Report.GetReportsByEventAndTeam(eventKey, team)
+	reps, err := s.store.Report.GetReportsByEventAndTeam(r.Context(), org, eventKey, team)
 	if err != nil {
 		if err == store.ErrNoResults {
-			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			respond.Error(w, http.StatusNotFound)
 		} else {
 			s.logger.LogRequestError(r, fmt.Errorf("getting reports: %v", err))
-			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			respond.Error(w, http.StatusInternalServerError)
 		}
 		return
 	}
 
-	respond.JSON(w, reps)
+	respond.Negotiate(w, r, reps)
 }
 
 func (s *Server) getTeamReportsHandler(w http.ResponseWriter, r *http.Request) {
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
 	vars := mux.Vars(r)
 	team := vars["team"]
 
-	reps, err := s.store.Report.GetReportsByTeam(team)
+	reps, err := s.store.Report.GetReportsByTeam(r.Context(), org, team)
 	if err != nil {
 		if err == store.ErrNoResults {
-			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			respond.Error(w, http.StatusNotFound)
 		} else {
 			s.logger.LogRequestError(r, fmt.Errorf("getting reports: %v", err))
-			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			respond.Error(w, http.StatusInternalServerError)
 		}
 		return
 	}
 
-	respond.JSON(w, reps)
+	respond.Negotiate(w, r, reps)
+}
+
+const (
+	defaultSearchLimit = 50
+	maxSearchLimit     = 200
+)
+
+// parseOptionalTime parses an RFC 3339 time, or returns nil if v is empty.
+func parseOptionalTime(v string) (*time.Time, error) {
+	if v == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, v)
+	return &t, err
+}
+
+func (s *Server) searchNotesHandler(w http.ResponseWriter, r *http.Request) {
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	query := r.URL.Query()
+
+	q := report.NoteQuery{
+		Text:     query.Get("q"),
+		EventKey: query.Get("event"),
+		Team:     query.Get("team"),
+		Reporter: query.Get("reporter"),
+		Limit:    defaultSearchLimit,
+	}
+
+	// a query without any words, or only stop words, would match nothing
+	if len(search.Tokenize(q.Text)) == 0 {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	var sinceErr, untilErr error
+	q.Since, sinceErr = parseOptionalTime(query.Get("since"))
+	q.Until, untilErr = parseOptionalTime(query.Get("until"))
+	if sinceErr != nil || untilErr != nil {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > maxSearchLimit {
+			respond.Error(w, http.StatusBadRequest)
+			return
+		}
+		q.Limit = limit
+	}
+
+	results, err := s.store.Report.SearchNotes(r.Context(), org, q)
+	if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("searching notes: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	respond.Negotiate(w, r, results)
 }
@@ -0,0 +1,55 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Pigmice2733/scouting-backend/internal/store/report"
+	"github.com/Pigmice2733/scouting-backend/internal/tba/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchNotes(t *testing.T) {
+	s, _, _ := newTestServer(mock.DB{})
+
+	notes := []string{"Tipped over <again> going up the ramp", "fast cycles, no brownouts", "tips over when defended"}
+	reports := &memReports{}
+	for i, team := range []string{"frc4488", "frc2733", "frc1540"} {
+		reports.reports = append(reports.reports, memReport{
+			Report:  report.Report{Org: "frc2733", Reporter: "franklin", EventKey: "2018orwil", MatchKey: "2018orwil_qm3", Team: team, Notes: &notes[i]},
+			created: time.Date(2018, 3, 9, 18, i, 0, 0, time.UTC),
+		})
+	}
+	s.store.Report = reports
+
+	search := func(query string) *httptest.ResponseRecorder {
+		ctx := context.WithValue(context.Background(), keyOrgCtx, "frc2733")
+		w := httptest.NewRecorder()
+		s.searchNotesHandler(w, httptest.NewRequest("GET", "/notes/search?"+query, nil).WithContext(ctx))
+		return w
+	}
+
+	w := search("q=tipped+over")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var results []report.NoteResult
+	assert.Nil(t, json.NewDecoder(w.Body).Decode(&results))
+	if assert.Len(t, results, 2) {
+		assert.Equal(t, "frc4488", results[0].Team)
+		assert.Equal(t, "<mark>Tipped</mark> over &lt;again&gt; going up the ramp", results[0].Snippet)
+		assert.Equal(t, "<mark>tips</mark> over when defended", results[1].Snippet)
+	}
+
+	w = search("q=brownout&team=frc1540")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "[]\n", w.Body.String())
+
+	// queries of only stop words are rejected like queries without words
+	for _, query := range []string{"q=", "q=!!", "q=the", "q=over+the"} {
+		assert.Equal(t, http.StatusBadRequest, search(query).Code, query)
+	}
+}
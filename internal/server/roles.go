@@ -0,0 +1,92 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/Pigmice2733/scouting-backend/internal/respond"
+	"github.com/Pigmice2733/scouting-backend/internal/store"
+	"github.com/Pigmice2733/scouting-backend/internal/store/role"
+	"github.com/gorilla/mux"
+)
+
+var roleNameRegex = regexp.MustCompile(`^[0-9a-z-]+$`)
+
+func (s *Server) rolesHandler(w http.ResponseWriter, r *http.Request) {
+	roles, err := s.store.Role.GetRoles(r.Context())
+	if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("getting roles: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	respond.Negotiate(w, r, roles)
+}
+
+func (s *Server) upsertRoleHandler(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+
+	if !roleNameRegex.MatchString(name) {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	// the admin roles can't be changed so that nobody can lock themselves out
+	if name == role.Admin || name == role.SuperAdmin {
+		respond.ErrorCode(w, http.StatusConflict, codeDefaultRole, fmt.Sprintf("'%s' is a default role and can't be changed", name))
+		return
+	}
+
+	var rl role.Role
+	if err := json.NewDecoder(r.Body).Decode(&rl); err != nil {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	// roles are named by their URL, so a different name in the body would be
+	// a rename
+	if rl.Name != "" && rl.Name != name {
+		if role.Default(name) {
+			respond.ErrorCode(w, http.StatusConflict, codeDefaultRole, fmt.Sprintf("'%s' is a default role and can't be renamed", name))
+		} else {
+			respond.Error(w, http.StatusBadRequest)
+		}
+		return
+	}
+	rl.Name = name
+
+	for _, permission := range rl.Permissions {
+		if !role.ValidPermission(permission) {
+			respond.Error(w, http.StatusBadRequest)
+			return
+		}
+	}
+
+	if err := s.store.Role.Upsert(r.Context(), rl); err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("upserting role: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+}
+
+func (s *Server) deleteRoleHandler(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+
+	// default roles are given to new users and relied on by the permission
+	// checks, so they always have to exist
+	if role.Default(name) {
+		respond.ErrorCode(w, http.StatusConflict, codeDefaultRole, fmt.Sprintf("'%s' is a default role and can't be deleted", name))
+		return
+	}
+
+	if err := s.store.Role.Delete(r.Context(), name); err == store.ErrNoResults {
+		respond.Error(w, http.StatusNotFound)
+		return
+	} else if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("deleting role: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+}
@@ -4,16 +4,21 @@ import (
 	"net/http"
 
 	"github.com/Pigmice2733/scouting-backend/internal/mroute"
+	"github.com/Pigmice2733/scouting-backend/internal/store/role"
 	"github.com/gorilla/mux"
 )
 
 func initRoutes(r *mux.Router, s *Server) {
 	mroute.HandleRoutes(r, map[string]mroute.Route{
 		"/authenticate": mroute.Simple(http.HandlerFunc(s.authenticateHandler), "POST"),
+		"/refresh":      mroute.Simple(http.HandlerFunc(s.refreshHandler), "POST"),
+		"/logout":       mroute.Simple(http.HandlerFunc(s.logoutHandler), "POST", s.authHandler),
+
+		"/.well-known/jwks.json": mroute.Simple(http.HandlerFunc(s.jwksHandler), "GET", cache),
 
 		"/users": {
 			Handler: mroute.Multi(map[string]http.Handler{
-				"GET":  s.authHandler(adminHandler(http.HandlerFunc(s.getUsersHandler))),
+				"GET":  s.authHandler(require(role.UserReadAll)(http.HandlerFunc(s.getUsersHandler))),
 				"POST": http.HandlerFunc(s.createUserHandler),
 			}),
 			Methods:     []string{"GET", "POST"},
@@ -26,44 +31,147 @@ func initRoutes(r *mux.Router, s *Server) {
 				"DELETE": http.HandlerFunc(s.deleteUserHandler),
 			}),
 			Methods:     []string{"PUT", "DELETE"},
+			Middlewares: []mroute.Middleware{s.authHandler, requireSelfOr(role.UserEditAll)},
+		},
+
+		"/organizations": {
+			Handler: mroute.Multi(map[string]http.Handler{
+				"GET":  http.HandlerFunc(s.orgsHandler),
+				"POST": s.authHandler(require(role.OrgManage)(http.HandlerFunc(s.createOrgHandler))),
+			}),
+			Methods: []string{"GET", "POST"},
+		},
+
+		"/sharing": {
+			Handler: mroute.Multi(map[string]http.Handler{
+				"GET":  http.HandlerFunc(s.sharingHandler),
+				"POST": require(role.SharingEdit)(http.HandlerFunc(s.proposeSharingHandler)),
+			}),
+			Methods:     []string{"GET", "POST"},
 			Middlewares: []mroute.Middleware{s.authHandler},
 		},
+		"/sharing/{id}":        mroute.Simple(http.HandlerFunc(s.deleteSharingHandler), "DELETE", s.authHandler, require(role.SharingEdit)),
+		"/sharing/{id}/accept": mroute.Simple(http.HandlerFunc(s.acceptSharingHandler), "PUT", s.authHandler, require(role.SharingEdit)),
+
+		"/events/{eventKey}/bundle": mroute.Simple(http.HandlerFunc(s.exportBundleHandler), "GET", s.authHandler, require(role.SharingEdit)),
+		"/bundles":                  mroute.Simple(http.HandlerFunc(s.importBundleHandler), "POST", s.authHandler, require(role.SharingEdit)),
+		"/bundles/key":              mroute.Simple(http.HandlerFunc(s.bundleKeyHandler), "GET", s.authHandler),
+		"/bundles/trusted":          mroute.Simple(http.HandlerFunc(s.trustedBundleKeysHandler), "GET", s.authHandler),
+		"/bundles/trusted/{org}": {
+			Handler: mroute.Multi(map[string]http.Handler{
+				"PUT":    http.HandlerFunc(s.trustBundleKeyHandler),
+				"DELETE": http.HandlerFunc(s.distrustBundleKeyHandler),
+			}),
+			Methods:     []string{"PUT", "DELETE"},
+			Middlewares: []mroute.Middleware{s.authHandler, require(role.SharingEdit)},
+		},
+
+		"/roles": mroute.Simple(http.HandlerFunc(s.rolesHandler), "GET", s.authHandler),
+		"/roles/{name}": {
+			Handler: mroute.Multi(map[string]http.Handler{
+				"PUT":    http.HandlerFunc(s.upsertRoleHandler),
+				"DELETE": http.HandlerFunc(s.deleteRoleHandler),
+			}),
+			Methods:     []string{"PUT", "DELETE"},
+			Middlewares: []mroute.Middleware{s.authHandler, require(role.RoleEdit)},
+		},
+
+		"/events": mroute.Simple(http.HandlerFunc(s.eventsHandler), "GET", cache),
+		"/events/{eventKey}": {
+			Handler: mroute.Multi(map[string]http.Handler{
+				"GET": cache(s.pollMatchMiddleware(http.HandlerFunc(s.eventHandler))),
+				"PUT": s.authHandler(require(role.ScheduleEdit)(http.HandlerFunc(s.upsertEventHandler))),
+			}),
+			Methods: []string{"GET", "PUT"},
+		},
+		"/events/{eventKey}/changes":  mroute.Simple(http.HandlerFunc(s.changesHandler), "GET", s.pollMatchMiddleware),
+		"/events/{eventKey}/schedule": mroute.Simple(http.HandlerFunc(s.importScheduleHandler), "POST", s.authHandler, require(role.ScheduleEdit)),
+		"/events/{eventKey}/rankings": mroute.Simple(http.HandlerFunc(s.rankingsHandler), "GET", cache),
+		"/events/{eventKey}/sync":     mroute.Simple(http.HandlerFunc(s.syncEventHandler), "POST", s.authHandler, require(role.ScheduleEdit)),
+		"/events/{eventKey}/teams":    mroute.Simple(http.HandlerFunc(s.teamsAtEventHandler), "GET", s.authHandler, privateCache),
+		"/events/{eventKey}/matches/{matchKey}": {
+			Handler: mroute.Multi(map[string]http.Handler{
+				"GET": cache(s.pollMatchMiddleware(http.HandlerFunc(s.matchHandler))),
+				"PUT": s.authHandler(require(role.ScheduleEdit)(http.HandlerFunc(s.upsertMatchHandler))),
+			}),
+			Methods: []string{"GET", "PUT"},
+		},
 
-		"/events":                               mroute.Simple(http.HandlerFunc(s.eventsHandler), "GET", cache),
-		"/events/{eventKey}":                    mroute.Simple(http.HandlerFunc(s.eventHandler), "GET", cache, s.pollMatchMiddleware),
-		"/events/{eventKey}/teams":              mroute.Simple(http.HandlerFunc(s.teamsAtEventHandler), "GET", cache),
-		"/events/{eventKey}/matches/{matchKey}": mroute.Simple(http.HandlerFunc(s.matchHandler), "GET", cache, s.pollMatchMiddleware),
+		"/events/{eventKey}/matches/{matchKey}/reports": mroute.Simple(http.HandlerFunc(s.reportHandler), "PUT", s.authHandler, require(role.ReportWrite)),
+		"/events/{eventKey}/teams/{team}/reports":       mroute.Simple(http.HandlerFunc(s.getTeamEventReportsHandler), "GET", s.authHandler, privateCache, s.pollMatchMiddleware),
+		"/teams/{team}/reports":                         mroute.Simple(http.HandlerFunc(s.getTeamReportsHandler), "GET", s.authHandler, privateCache, s.pollMatchMiddleware),
+		"/notes/search":                                 mroute.Simple(http.HandlerFunc(s.searchNotesHandler), "GET", s.authHandler),
 
-		"/events/{eventKey}/matches/{matchKey}/reports": mroute.Simple(http.HandlerFunc(s.reportHandler), "PUT", s.authHandler),
-		"/events/{eventKey}/teams/{team}/reports":       mroute.Simple(http.HandlerFunc(s.getTeamEventReportsHandler), "GET", cache, s.pollMatchMiddleware),
-		"/teams/{team}/reports":                         mroute.Simple(http.HandlerFunc(s.getTeamReportsHandler), "GET", cache, s.pollMatchMiddleware),
+		"/schema": {
+			Handler: mroute.Multi(map[string]http.Handler{
+				"GET": s.authHandler(privateCache(http.HandlerFunc(s.schemaHandler))),
+				"PUT": s.authHandler(require(role.SchemaEdit)(http.HandlerFunc(s.updateSchemaHandler))),
+			}),
+			Methods: []string{"GET", "PUT"},
+		},
 
-		"/schema": mroute.Simple(http.HandlerFunc(s.schemaHandler), "GET", cache),
+		"/tags": {
+			Handler: mroute.Multi(map[string]http.Handler{
+				"GET": s.authHandler(http.HandlerFunc(s.tagsHandler)),
+				"PUT": s.authHandler(require(role.SchemaEdit)(http.HandlerFunc(s.updateTagsHandler))),
+			}),
+			Methods: []string{"GET", "PUT"},
+		},
 
-		"/photo/{team}": mroute.Simple(http.HandlerFunc(s.photoHandler), "GET", cache),
+		"/photo/{team}": mroute.Simple(http.HandlerFunc(s.photoHandler), "GET"),
 
-		"/events/{eventKey}/analysis":                                     mroute.Simple(http.HandlerFunc(s.eventAnalysisHandler), "GET", s.pollMatchMiddleware),
-		"/events/{eventKey}/teams/{team}/analysis":                        mroute.Simple(http.HandlerFunc(s.teamAnalysisHandler), "GET", s.pollMatchMiddleware),
-		"/events/{eventKey}/matches/{matchKey}/alliance/{color}/analysis": mroute.Simple(http.HandlerFunc(s.allianceAnalysisHandler), "GET", s.pollMatchMiddleware),
+		"/events/{eventKey}/analysis":                                     mroute.Simple(http.HandlerFunc(s.eventAnalysisHandler), "GET", s.authHandler, s.pollMatchMiddleware),
+		"/events/{eventKey}/teams/{team}/analysis":                        mroute.Simple(http.HandlerFunc(s.teamAnalysisHandler), "GET", s.authHandler, s.pollMatchMiddleware),
+		"/events/{eventKey}/matches/{matchKey}/alliance/{color}/analysis": mroute.Simple(http.HandlerFunc(s.allianceAnalysisHandler), "GET", s.authHandler, s.pollMatchMiddleware),
+		"/events/{eventKey}/export/{table:reports|analysis|schedule}":     mroute.Simple(http.HandlerFunc(s.exportHandler), "GET", s.authHandler, s.pollMatchMiddleware),
+		"/teams/{team}/analysis":                                          mroute.Simple(http.HandlerFunc(s.seasonAnalysisHandler), "GET", s.authHandler),
+		"/compare":                                                        mroute.Simple(http.HandlerFunc(s.compareHandler), "GET", s.authHandler),
 
 		"/picklists": {
 			Handler: mroute.Multi(map[string]http.Handler{
 				"GET":  http.HandlerFunc(s.picklistsHandler),
-				"POST": http.HandlerFunc(s.newPicklistHandler),
+				"POST": require(role.PicklistWrite)(http.HandlerFunc(s.newPicklistHandler)),
 			}),
 			Methods:     []string{"GET", "POST"},
 			Middlewares: []mroute.Middleware{s.authHandler},
 		},
-		"/picklists/{id}": {
+		// picklist ids are uuids, so they can't match /picklists/shared or
+		// /picklists/consensus
+		"/picklists/{id:[0-9a-fA-F-]+}": {
 			Handler: mroute.Multi(map[string]http.Handler{
 				"GET":    http.HandlerFunc(s.picklistHandler),
-				"PUT":    s.authHandler(http.HandlerFunc(s.updatePicklistHandler)),
-				"DELETE": s.authHandler(http.HandlerFunc(s.deletePicklistHandler)),
+				"PUT":    require(role.PicklistWrite)(http.HandlerFunc(s.updatePicklistHandler)),
+				"DELETE": require(role.PicklistWrite)(http.HandlerFunc(s.deletePicklistHandler)),
 			}),
-			Methods: []string{"GET", "PUT", "DELETE"},
+			Methods:     []string{"GET", "PUT", "DELETE"},
+			Middlewares: []mroute.Middleware{s.authHandler},
+		},
+		"/picklists/{id:[0-9a-fA-F-]+}/shares": {
+			Handler: mroute.Multi(map[string]http.Handler{
+				"GET": http.HandlerFunc(s.picklistSharesHandler),
+				"PUT": require(role.PicklistWrite)(http.HandlerFunc(s.updatePicklistSharesHandler)),
+			}),
+			Methods:     []string{"GET", "PUT"},
+			Middlewares: []mroute.Middleware{s.authHandler},
 		},
-		"/picklists/event/{eventKey}": mroute.Simple(http.HandlerFunc(s.picklistEventHandler), "GET", s.authHandler),
+		"/picklists/{id:[0-9a-fA-F-]+}/revisions":                   mroute.Simple(http.HandlerFunc(s.picklistRevisionsHandler), "GET", s.authHandler),
+		"/picklists/{id:[0-9a-fA-F-]+}/revisions/{version}/restore": mroute.Simple(http.HandlerFunc(s.restorePicklistHandler), "POST", s.authHandler, require(role.PicklistWrite)),
+		"/picklists/{id:[0-9a-fA-F-]+}/diff":                        mroute.Simple(http.HandlerFunc(s.picklistDiffHandler), "GET", s.authHandler),
+		"/picklists/consensus":                                      mroute.Simple(http.HandlerFunc(s.picklistConsensusHandler), "GET", s.authHandler),
+		"/picklists/shared":                                         mroute.Simple(http.HandlerFunc(s.sharedPicklistsHandler), "GET", s.authHandler),
+		"/picklists/event/{eventKey}":                               mroute.Simple(http.HandlerFunc(s.picklistEventHandler), "GET", s.authHandler),
+
+		"/offline": {
+			Handler: mroute.Multi(map[string]http.Handler{
+				"GET": http.HandlerFunc(s.offlineHandler),
+				"PUT": require(role.ScheduleEdit)(http.HandlerFunc(s.updateOfflineHandler)),
+			}),
+			Methods:     []string{"GET", "PUT"},
+			Middlewares: []mroute.Middleware{s.authHandler},
+		},
+
+		"/upstream": mroute.Simple(http.HandlerFunc(s.upstreamHandler), "GET", s.authHandler),
 
-		"/leaderboard": mroute.Simple(http.HandlerFunc(s.leaderboardHandler), "GET"),
+		"/leaderboard": mroute.Simple(http.HandlerFunc(s.leaderboardHandler), "GET", s.authHandler),
 	})
 }
@@ -0,0 +1,177 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Pigmice2733/scouting-backend/internal/respond"
+	"github.com/Pigmice2733/scouting-backend/internal/schedule"
+	"github.com/Pigmice2733/scouting-backend/internal/store"
+	"github.com/Pigmice2733/scouting-backend/internal/store/event"
+	"github.com/Pigmice2733/scouting-backend/internal/store/match"
+	"github.com/Pigmice2733/scouting-backend/internal/tba"
+	"github.com/gorilla/mux"
+)
+
+// eventExists responds with an error and returns false if an event doesn't
+// exist.
+func (s *Server) eventExists(w http.ResponseWriter, r *http.Request, eventKey string) bool {
+	if _, err := s.store.Event.Get(r.Context(), eventKey, s.store.Match); err == store.ErrNoResults {
+		respond.Error(w, http.StatusNotFound)
+		return false
+	} else if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("getting event: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return false
+	}
+	return true
+}
+
+// upsertEventHandler lets events that aren't on TBA, like off-season events and
+// scrimmages, be entered by hand.
+func (s *Server) upsertEventHandler(w http.ResponseWriter, r *http.Request) {
+	var e event.BasicEvent
+	if err := json.NewDecoder(r.Body).Decode(&e); err != nil {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	if e.Name == "" || e.Date.IsZero() || e.EndDate.Before(e.Date) {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	e.Key = mux.Vars(r)["eventKey"]
+	e.Manual = true
+
+	if _, err := s.store.Event.MassUpsert(r.Context(), []event.BasicEvent{e}); err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("upserting event: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+	s.enteredOffline([]event.BasicEvent{e}, nil)
+}
+
+// upsertMatchHandler lets matches be entered by hand, for events that aren't on
+// TBA or when the TBA API can't be reached from the venue.
+func (s *Server) upsertMatchHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eventKey, matchKey := vars["eventKey"], vars["matchKey"]
+
+	if !strings.HasPrefix(matchKey, eventKey+"_") {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	var m match.Match
+	if err := json.NewDecoder(r.Body).Decode(&m); err != nil {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	if err := schedule.ValidateAlliances(m.RedAlliance, m.BlueAlliance); err != nil {
+		respond.ErrorCode(w, http.StatusBadRequest, codeInvalidAlliances, err.Error())
+		return
+	}
+
+	if !s.eventExists(w, r, eventKey) {
+		return
+	}
+
+	m.Key = matchKey
+	m.EventKey = eventKey
+	m.Manual = true
+
+	if _, err := s.store.Match.MassUpsert(r.Context(), []match.Match{m}); err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("upserting match: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+	s.enteredOffline(nil, []match.Match{m})
+}
+
+// importScheduleHandler imports the matches of an event from a schedule
+// exported from the FMS.
+func (s *Server) importScheduleHandler(w http.ResponseWriter, r *http.Request) {
+	eventKey := mux.Vars(r)["eventKey"]
+
+	loc := time.UTC
+	if tz := r.URL.Query().Get("tz"); tz != "" {
+		var err error
+		if loc, err = time.LoadLocation(tz); err != nil {
+			respond.Error(w, http.StatusBadRequest)
+			return
+		}
+	}
+
+	parsed, err := schedule.ParseFMS(r.Body, eventKey, loc)
+	if err != nil {
+		respond.ErrorCode(w, http.StatusBadRequest, codeInvalidSchedule, err.Error())
+		return
+	}
+
+	if !s.eventExists(w, r, eventKey) {
+		return
+	}
+
+	matches := make([]match.Match, 0, len(parsed))
+	for _, m := range parsed {
+		matches = append(matches, m.Match)
+	}
+
+	changes, err := s.store.Match.MassUpsert(r.Context(), matches)
+	if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("upserting matches: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+	s.enteredOffline(nil, matches)
+
+	respond.Negotiate(w, r, map[string]interface{}{"matches": len(matches), "changes": changes})
+}
+
+// syncEventHandler unmarks an event and its matches as manual, and polls them
+// from TBA again so TBA data replaces what was entered by hand.
+func (s *Server) syncEventHandler(w http.ResponseWriter, r *http.Request) {
+	eventKey := mux.Vars(r)["eventKey"]
+
+	if s.isOffline() {
+		respond.ErrorCode(w, http.StatusServiceUnavailable, codeOffline, "can't sync with TBA while the server is offline")
+		return
+	}
+
+	if err := s.store.Event.SetManual(r.Context(), eventKey, false); err == store.ErrNoResults {
+		respond.Error(w, http.StatusNotFound)
+		return
+	} else if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("unmarking manual event: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	if err := s.store.Match.SetManual(r.Context(), eventKey, false); err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("unmarking manual matches: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	if f, ok := s.consumer.(tba.Forgetter); ok {
+		f.ForgetEvents(s.year)
+		f.ForgetMatches(eventKey)
+	}
+
+	s.pollEvents(r.Context())
+	s.pollMatches(r.Context(), eventKey)
+
+	e, err := s.store.Event.Get(r.Context(), eventKey, s.store.Match)
+	if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("getting event: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	respond.Negotiate(w, r, e)
+}
@@ -1,54 +1,109 @@
 package server
 
 import (
-	"crypto/rand"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/Pigmice2733/scouting-backend/internal/respond"
+	"github.com/Pigmice2733/scouting-backend/internal/schedule"
 	"github.com/Pigmice2733/scouting-backend/internal/server/logic"
 	"github.com/Pigmice2733/scouting-backend/internal/tba"
 
 	"github.com/Pigmice2733/scouting-backend/internal/analysis"
+	"github.com/Pigmice2733/scouting-backend/internal/imagecache"
+	"github.com/Pigmice2733/scouting-backend/internal/jwtkeys"
 	"github.com/Pigmice2733/scouting-backend/internal/logger"
 	"github.com/Pigmice2733/scouting-backend/internal/store"
+	"github.com/Pigmice2733/scouting-backend/internal/store/match"
 	"github.com/gorilla/mux"
 )
 
+// pollTimeout is the deadline of polling TBA for the matches of an event while
+// handling a request. Polls don't use the request's context, since TBA data that
+// was received but not stored wouldn't be received again.
+const pollTimeout = time.Second * 30
+
 // A Server is an instance of the scouting server
 type Server struct {
-	handler   http.Handler
-	store     *store.Service
-	consumer  tba.Consumer
-	logger    logger.Service
-	schema    analysis.Schema
-	jwtSecret []byte
-	certFile  string
-	keyFile   string
-	year      int
-}
-
-// New creates a new server given a db file and a io.Writer for logging
-func New(store *store.Service, consumer tba.Consumer, logWriter io.Writer, year int, origin, schemaPath string, certFile, keyFile string) (*Server, error) {
+	handler      http.Handler
+	store        *store.Service
+	consumer     tba.Consumer
+	logger       logger.Service
+	schema       analysis.Schema
+	photos       *imagecache.Cache
+	photoClient  *http.Client
+	keys         *jwtkeys.Set
+	keysFile     string
+	keyAlgorithm string
+	keyRotation  time.Duration
+	certFile     string
+	keyFile      string
+	year         int
+	timeout      time.Duration
+
+	offlineMu      sync.RWMutex
+	offline        offlineStatus
+	offlineEntered offlineEntries
+
+	rankingsMu sync.Mutex
+	rankings   map[string]*cachedRankings
+}
+
+// Options holds configuration for a server.
+type Options struct {
+	Year           int
+	Origin         string
+	SchemaPath     string
+	CertFile       string
+	KeyFile        string
+	PhotoCacheDir  string
+	PhotoCacheSize int64
+	JWTKeysFile    string
+	JWTAlgorithm   string
+	JWTKeyRotation time.Duration
+	// RequestTimeout is the deadline of the store queries and TBA requests made
+	// to handle a request. Zero means no deadline.
+	RequestTimeout time.Duration
+
+	// Offline disables all polling of the TBA API, for venues without an
+	// internet connection.
+	Offline bool
+	// OfflineSchedule is the path of a schedule file or event bundle to load
+	// events and matches from on startup.
+	OfflineSchedule string
+}
+
+// New creates a new server given a store, a TBA consumer, an io.Writer for
+// logging and server options.
+func New(store *store.Service, consumer tba.Consumer, logWriter io.Writer, options Options) (*Server, error) {
 	s := &Server{
-		logger:   logger.New(logWriter),
-		store:    store,
-		consumer: consumer,
-		certFile: certFile,
-		keyFile:  keyFile,
-		year:     year,
+		logger:       logger.New(logWriter),
+		store:        store,
+		consumer:     consumer,
+		photoClient:  &http.Client{Timeout: time.Second * 10},
+		certFile:     options.CertFile,
+		keyFile:      options.KeyFile,
+		year:         options.Year,
+		keysFile:     options.JWTKeysFile,
+		keyAlgorithm: options.JWTAlgorithm,
+		keyRotation:  options.JWTKeyRotation,
+		timeout:      options.RequestTimeout,
 	}
 
 	// setup report schema
 
-	f, err := os.Open(schemaPath)
+	f, err := os.Open(options.SchemaPath)
 	if err != nil {
 		return nil, err
 	}
+	defer f.Close()
 
 	s.schema = make(analysis.Schema)
 
@@ -56,16 +111,38 @@ func New(store *store.Service, consumer tba.Consumer, logWriter io.Writer, year
 		return nil, err
 	}
 
+	// load offline schedule
+
+	if options.OfflineSchedule != "" {
+		if err := s.loadSchedule(context.Background(), options.OfflineSchedule); err != nil {
+			return nil, fmt.Errorf("loading offline schedule: %v", err)
+		}
+	}
+	s.setOffline(options.Offline)
+
+	// setup photo cache
+
+	s.photos, err = imagecache.New(options.PhotoCacheDir, options.PhotoCacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("creating photo cache: %v", err)
+	}
+
 	// setup routes
 
-	s.handler = s.newHandler(origin)
+	s.handler = s.newHandler(options.Origin)
 
-	// setup jwt secret
+	// setup jwt signing keys
 
-	jwtSecret := make([]byte, 64)
-	_, err = rand.Read(jwtSecret)
+	if s.keysFile != "" {
+		s.keys, err = jwtkeys.New(jwtkeys.FileLoader(s.keysFile), logic.RetiredKeyTTL)
+	} else {
+		if _, err := logic.RotateSigningKey(s.keyAlgorithm, s.keyRotation, s.store.SigningKey); err != nil {
+			return nil, fmt.Errorf("rotating jwt signing key: %v", err)
+		}
+		s.keys, err = jwtkeys.New(s.store.SigningKey.GetAll, logic.RetiredKeyTTL)
+	}
 	if err != nil {
-		return s, fmt.Errorf("generating jwt secret: %v", err)
+		return nil, fmt.Errorf("loading jwt signing keys: %v", err)
 	}
 
 	return s, nil
@@ -73,13 +150,39 @@ func New(store *store.Service, consumer tba.Consumer, logWriter io.Writer, year
 
 // Run starts a running the server on the specified address
 func (s *Server) Run(httpAddr, httpsAddr string) error {
-	s.pollEvents()
+	s.pollEvents(context.Background())
 
 	eventTicker := time.NewTicker(time.Hour * 24)
 	defer eventTicker.Stop()
 	go func() {
 		for range eventTicker.C {
-			s.pollEvents()
+			s.pollEvents(context.Background())
+		}
+	}()
+
+	photoTicker := time.NewTicker(time.Hour)
+	defer photoTicker.Stop()
+	go func() {
+		for range photoTicker.C {
+			s.refreshPhotos(context.Background())
+		}
+	}()
+
+	keyTicker := time.NewTicker(time.Hour)
+	defer keyTicker.Stop()
+	go func() {
+		for range keyTicker.C {
+			s.rotateKeys()
+		}
+	}()
+
+	sessionTicker := time.NewTicker(time.Hour * 24)
+	defer sessionTicker.Stop()
+	go func() {
+		for range sessionTicker.C {
+			if err := s.store.Session.DeleteExpired(context.Background(), time.Now()); err != nil {
+				s.logger.LogJSON(map[string]interface{}{"error": fmt.Errorf("server: deleting expired sessions: %v", err).Error()})
+			}
 		}
 	}()
 
@@ -126,56 +229,137 @@ func (s *Server) Run(httpAddr, httpsAddr string) error {
 
 func (s *Server) newHandler(origin string) http.Handler {
 	router := mux.NewRouter()
+	router.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		respond.Error(w, http.StatusNotFound)
+	})
 
 	initRoutes(router, s)
 
-	return cors(limitBody(router), origin)
+	return cors(limitBody(timeout(router, s.timeout)), origin)
+}
+
+// getSchema returns the report schema of an organization, or the default
+// schema if the organization doesn't have one.
+func (s *Server) getSchema(ctx context.Context, org string) (analysis.Schema, error) {
+	schema, err := s.store.Schema.Get(ctx, org)
+	if err == store.ErrNoResults {
+		return s.schema, nil
+	}
+	return schema, err
 }
 
 func (s *Server) teamsAtEventHandler(w http.ResponseWriter, r *http.Request) {
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
 	eventKey := mux.Vars(r)["eventKey"]
 
-	teams, err := s.store.Report.GetReportedOn(eventKey)
+	teams, err := s.store.Report.GetReportedOn(r.Context(), org, eventKey)
 	if err != nil {
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		respond.Error(w, http.StatusInternalServerError)
 		s.logger.LogRequestError(r, fmt.Errorf("getting reported on: %v", err))
 		return
 	}
 
-	respond.JSON(w, teams)
+	respond.Negotiate(w, r, teams)
 }
 
 func (s *Server) photoHandler(w http.ResponseWriter, r *http.Request) {
 	team := mux.Vars(r)["team"]
 
-	url, err := logic.GetPhoto(team, s.year, s.store.Photo, s.consumer)
+	year := s.year
+	if yearStr := r.URL.Query().Get("year"); yearStr != "" {
+		var err error
+		if year, err = strconv.Atoi(yearStr); err != nil {
+			respond.Error(w, http.StatusBadRequest)
+			return
+		}
+	}
+
+	if s.isOffline() {
+		s.cachedPhotoHandler(w, r, team, year)
+		return
+	}
+
+	url, err := logic.GetPhoto(r.Context(), team, year, s.store.Photo, s.consumer)
 	if err != nil {
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		if _, ok := err.(logic.UpstreamError); ok {
+			respond.Error(w, http.StatusBadGateway)
+		} else {
+			respond.Error(w, http.StatusInternalServerError)
+		}
 		s.logger.LogRequestError(r, fmt.Errorf("getting team photo: %v", err))
 		return
 	}
 
 	if url == "" {
-		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		respond.Error(w, http.StatusNotFound)
+		return
+	}
+
+	entry, ok := s.photos.Get(url)
+	if !ok {
+		entry, err = logic.FetchImage(s.photoClient, url, s.photos)
+		if err != nil {
+			if _, ok := err.(logic.UpstreamError); ok {
+				respond.Error(w, http.StatusBadGateway)
+			} else {
+				respond.Error(w, http.StatusInternalServerError)
+			}
+			s.logger.LogRequestError(r, fmt.Errorf("getting team media: %v", err))
+			return
+		}
+	}
+
+	s.serveImage(w, r, entry)
+}
+
+// cachedPhotoHandler serves a team photo only if it is already in the image
+// cache, for when the TBA API and image hosts can't be reached.
+func (s *Server) cachedPhotoHandler(w http.ResponseWriter, r *http.Request, team string, year int) {
+	p, err := s.store.Photo.Get(r.Context(), team, year)
+	if err != nil && err != store.ErrNoResults {
+		respond.Error(w, http.StatusInternalServerError)
+		s.logger.LogRequestError(r, fmt.Errorf("getting team photo: %v", err))
 		return
 	}
 
-	resp, err := http.Get(url)
-	if err != nil || (resp.StatusCode < 200 || resp.StatusCode >= 300) {
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-		s.logger.LogRequestError(r, fmt.Errorf("getting team media: %v", err))
+	entry, ok := s.photos.Get(p.URL)
+	if err == store.ErrNoResults || p.URL == "" || !ok {
+		respond.ErrorCode(w, http.StatusServiceUnavailable, codeOffline, "photo isn't cached and the server is offline")
 		return
 	}
-	defer resp.Body.Close()
 
-	if _, err := io.Copy(w, resp.Body); err != nil {
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-		s.logger.LogRequestError(r, fmt.Errorf("copying team media to client: %v", err))
+	s.serveImage(w, r, entry)
+}
+
+// serveImage serves an image from the image cache.
+func (s *Server) serveImage(w http.ResponseWriter, r *http.Request, entry imagecache.Entry) {
+	f, err := s.photos.Open(entry)
+	if err != nil {
+		respond.Error(w, http.StatusInternalServerError)
+		s.logger.LogRequestError(r, fmt.Errorf("opening cached team media: %v", err))
 		return
 	}
+	defer f.Close()
+
+	w.Header().Set("Content-Type", entry.ContentType)
+	w.Header().Set("ETag", entry.ETag)
+	w.Header().Set("Cache-Control", "max-age=86400") // 24 hour max age
+
+	http.ServeContent(w, r, "", entry.Modified, f)
 }
 
 func (s *Server) leaderboardHandler(w http.ResponseWriter, r *http.Request) {
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
 	type stat struct {
 		Reporter string `json:"reporter"`
 		Reports  int    `json:"reports"`
@@ -183,9 +367,9 @@ func (s *Server) leaderboardHandler(w http.ResponseWriter, r *http.Request) {
 
 	var resp []stat
 
-	stats, err := s.store.Report.GetReporterStats()
+	stats, err := s.store.Report.GetReporterStats(r.Context(), org)
 	if err != nil {
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		respond.Error(w, http.StatusInternalServerError)
 		s.logger.LogRequestError(r, fmt.Errorf("getting reporter stats: %v", err))
 		return
 	}
@@ -194,11 +378,46 @@ func (s *Server) leaderboardHandler(w http.ResponseWriter, r *http.Request) {
 		resp = append(resp, stat{reporter, reports})
 	}
 
-	respond.JSON(w, resp)
+	respond.Negotiate(w, r, resp)
+}
+
+// loadSchedule loads the events and matches of a schedule file or event
+// bundle into the store.
+func (s *Server) loadSchedule(ctx context.Context, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	sch, err := schedule.Read(f)
+	if err != nil {
+		return err
+	}
+
+	events, matches, err := schedule.Load(ctx, s.store, sch)
+	if err != nil {
+		return err
+	}
+
+	loaded := make([]match.Match, 0, len(sch.Matches))
+	for _, m := range sch.Matches {
+		m.Match.EventKey = m.EventKey
+		loaded = append(loaded, m.Match)
+	}
+	s.recordEntered(sch.Events, loaded)
+
+	s.logger.LogJSON(map[string]interface{}{"schedule": path, "events": events, "matches": matches})
+
+	return nil
 }
 
-func (s *Server) pollEvents() {
-	bEvents, err := s.consumer.GetEvents(s.year)
+func (s *Server) pollEvents(ctx context.Context) {
+	if s.isOffline() {
+		return
+	}
+
+	bEvents, err := s.consumer.GetEvents(ctx, s.year)
 	if err == tba.ErrNotModified {
 		return
 	} else if err != nil {
@@ -206,13 +425,57 @@ func (s *Server) pollEvents() {
 		return
 	}
 
-	if err := s.store.Event.MassUpsert(bEvents); err != nil {
+	changed, err := s.store.Event.MassUpsert(ctx, bEvents)
+	if err != nil {
 		s.logger.LogJSON(map[string]interface{}{"error": fmt.Errorf("server: updating events: %v", err).Error()})
+		// the events have to be retrieved again, even though TBA says they
+		// weren't modified, since they weren't stored
+		if f, ok := s.consumer.(tba.Forgetter); ok {
+			f.ForgetEvents(s.year)
+		}
+	} else if changed > 0 {
+		s.logger.LogJSON(map[string]interface{}{"polled": "events", "events": changed})
 	}
 }
 
-func (s *Server) pollMatches(eventKey string) {
-	matches, err := s.consumer.GetMatches(eventKey)
+// refreshPhotos refreshes every stored photo URL older than logic.PhotoTTL.
+// It is the only place stale photos are refreshed.
+func (s *Server) refreshPhotos(ctx context.Context) {
+	if s.isOffline() {
+		return
+	}
+
+	photos, err := s.store.Photo.GetStale(ctx, time.Now().Add(-logic.PhotoTTL))
+	if err != nil {
+		s.logger.LogJSON(map[string]interface{}{"error": fmt.Errorf("server: getting stale photos: %v", err).Error()})
+		return
+	}
+
+	for _, p := range photos {
+		if _, err := logic.RefreshPhoto(ctx, p.Team, p.Year, s.store.Photo, s.consumer); err != nil && err != tba.ErrNotModified {
+			s.logger.LogJSON(map[string]interface{}{"error": fmt.Errorf("server: refreshing photo for team '%s' in %d: %v", p.Team, p.Year, err).Error()})
+		}
+	}
+}
+
+func (s *Server) rotateKeys() {
+	if s.keysFile == "" {
+		if _, err := logic.RotateSigningKey(s.keyAlgorithm, s.keyRotation, s.store.SigningKey); err != nil {
+			s.logger.LogJSON(map[string]interface{}{"error": fmt.Errorf("server: rotating jwt signing key: %v", err).Error()})
+		}
+	}
+
+	if err := s.keys.Reload(); err != nil {
+		s.logger.LogJSON(map[string]interface{}{"error": fmt.Errorf("server: reloading jwt signing keys: %v", err).Error()})
+	}
+}
+
+func (s *Server) pollMatches(ctx context.Context, eventKey string) {
+	if s.isOffline() {
+		return
+	}
+
+	matches, err := s.consumer.GetMatches(ctx, eventKey)
 	if err == tba.ErrNotModified {
 		return
 	} else if err != nil {
@@ -220,8 +483,21 @@ func (s *Server) pollMatches(eventKey string) {
 		return
 	}
 
-	if err := s.store.Match.MassUpsert(matches, s.store.Alliance); err != nil {
+	changes, err := s.store.Match.MassUpsert(ctx, matches)
+	if err != nil {
 		s.logger.LogJSON(map[string]interface{}{"error": fmt.Errorf("server: updating matches for event '%s': %v", eventKey, err).Error()})
+		s.forgetMatches(eventKey)
+		return
+	} else if changes != (match.Changes{}) {
+		s.logger.LogJSON(map[string]interface{}{"polled": eventKey, "matches": changes.Matches, "alliances": changes.Alliances, "removed": changes.Removed})
+	}
+}
+
+// forgetMatches makes the next poll retrieve the matches of an event again, even
+// if TBA says they weren't modified, for when they couldn't be stored.
+func (s *Server) forgetMatches(eventKey string) {
+	if f, ok := s.consumer.(tba.Forgetter); ok {
+		f.ForgetMatches(eventKey)
 	}
 }
 
@@ -230,7 +506,9 @@ func (s *Server) pollMatchMiddleware(next http.Handler) http.Handler {
 		eventKey := mux.Vars(r)["eventKey"]
 
 		if eventKey != "" {
-			s.pollMatches(eventKey)
+			ctx, cancel := context.WithTimeout(context.Background(), pollTimeout)
+			s.pollMatches(ctx, eventKey)
+			cancel()
 		}
 
 		next.ServeHTTP(w, r)
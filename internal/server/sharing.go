@@ -0,0 +1,116 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/Pigmice2733/scouting-backend/internal/respond"
+	"github.com/Pigmice2733/scouting-backend/internal/store"
+	"github.com/Pigmice2733/scouting-backend/internal/store/sharing"
+	"github.com/gorilla/mux"
+)
+
+var agreementIDRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+func (s *Server) sharingHandler(w http.ResponseWriter, r *http.Request) {
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	agreements, err := s.store.Sharing.GetByOrg(r.Context(), org)
+	if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("getting sharing agreements: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	respond.Negotiate(w, r, agreements)
+}
+
+func (s *Server) proposeSharingHandler(w http.ResponseWriter, r *http.Request) {
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	var a sharing.Agreement
+	if err := json.NewDecoder(r.Body).Decode(&a); err != nil {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+	a.Proposer = org
+
+	if a.EventKey == "" || a.Partner == "" || a.Partner == org {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	if _, err := s.store.Org.Get(r.Context(), a.Partner); err == store.ErrNoResults {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	} else if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("getting organization: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	id, err := s.store.Sharing.Propose(r.Context(), a)
+	if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("proposing sharing agreement: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	respond.Negotiate(w, r, id)
+}
+
+func (s *Server) acceptSharingHandler(w http.ResponseWriter, r *http.Request) {
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+	if !agreementIDRegex.MatchString(id) {
+		respond.Error(w, http.StatusNotFound)
+		return
+	}
+
+	if err := s.store.Sharing.Accept(r.Context(), id, org); err == store.ErrNoResults {
+		respond.Error(w, http.StatusNotFound)
+		return
+	} else if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("accepting sharing agreement: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+}
+
+func (s *Server) deleteSharingHandler(w http.ResponseWriter, r *http.Request) {
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+	if !agreementIDRegex.MatchString(id) {
+		respond.Error(w, http.StatusNotFound)
+		return
+	}
+
+	if err := s.store.Sharing.Delete(r.Context(), id, org); err == store.ErrNoResults {
+		respond.Error(w, http.StatusNotFound)
+		return
+	} else if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("deleting sharing agreement: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+}
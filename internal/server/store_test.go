@@ -0,0 +1,335 @@
+package server
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Pigmice2733/scouting-backend/internal/search"
+	"github.com/Pigmice2733/scouting-backend/internal/store"
+	"github.com/Pigmice2733/scouting-backend/internal/store/alliance"
+	"github.com/Pigmice2733/scouting-backend/internal/store/event"
+	"github.com/Pigmice2733/scouting-backend/internal/store/match"
+	"github.com/Pigmice2733/scouting-backend/internal/store/picklist"
+	"github.com/Pigmice2733/scouting-backend/internal/store/report"
+)
+
+// memEvents is an in-memory event store for tests.
+type memEvents struct {
+	mu     sync.Mutex
+	events map[string]event.BasicEvent
+}
+
+func newMemEvents() *memEvents {
+	return &memEvents{events: make(map[string]event.BasicEvent)}
+}
+
+func (s *memEvents) GetBasicEvents(ctx context.Context) ([]event.BasicEvent, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var bEvents []event.BasicEvent
+	for _, e := range s.events {
+		bEvents = append(bEvents, e)
+	}
+	sort.Slice(bEvents, func(i, j int) bool { return bEvents[i].Key < bEvents[j].Key })
+
+	return bEvents, nil
+}
+
+func (s *memEvents) Get(ctx context.Context, key string, ms match.Service) (event.Event, error) {
+	if err := ctx.Err(); err != nil {
+		return event.Event{}, err
+	}
+
+	s.mu.Lock()
+	e, ok := s.events[key]
+	s.mu.Unlock()
+	if !ok {
+		return event.Event{}, store.ErrNoResults
+	}
+
+	bMatches, err := ms.GetBasicMatches(ctx, key)
+	return event.Event{BasicEvent: e, Matches: bMatches}, err
+}
+
+func (s *memEvents) MassUpsert(ctx context.Context, bEvents []event.BasicEvent) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	changed := 0
+	for _, e := range bEvents {
+		if old, ok := s.events[e.Key]; ok && old.Manual && !e.Manual {
+			continue
+		}
+		s.events[e.Key] = e
+		changed++
+	}
+
+	return changed, nil
+}
+
+func (s *memEvents) SetManual(ctx context.Context, key string, manual bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.events[key]
+	if !ok {
+		return store.ErrNoResults
+	}
+	e.Manual = manual
+	s.events[key] = e
+
+	return nil
+}
+
+// memMatches is an in-memory match store for tests. It stores alliances
+// itself, so it doesn't need an alliance store.
+type memMatches struct {
+	mu      sync.Mutex
+	matches map[string]match.Match
+	changes []match.Change
+}
+
+func newMemMatches() *memMatches {
+	return &memMatches{matches: make(map[string]match.Match)}
+}
+
+func (s *memMatches) GetBasicMatches(ctx context.Context, eventKey string) ([]match.BasicMatch, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var bMatches []match.BasicMatch
+	for _, m := range s.matches {
+		if m.EventKey == eventKey {
+			bMatches = append(bMatches, m.BasicMatch)
+		}
+	}
+	sort.Slice(bMatches, func(i, j int) bool { return bMatches[i].Key < bMatches[j].Key })
+
+	return bMatches, nil
+}
+
+func (s *memMatches) Get(ctx context.Context, eventKey, matchKey string, as alliance.Service) (match.Match, error) {
+	if err := ctx.Err(); err != nil {
+		return match.Match{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m, ok := s.matches[matchKey]
+	if !ok || m.EventKey != eventKey {
+		return match.Match{}, store.ErrNoResults
+	}
+
+	return m, nil
+}
+
+func (s *memMatches) MassUpsert(ctx context.Context, matches []match.Match) (match.Changes, error) {
+	if err := ctx.Err(); err != nil {
+		return match.Changes{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var changes match.Changes
+	for _, m := range matches {
+		var stored []match.Match
+		if old, ok := s.matches[m.Key]; ok {
+			stored = append(stored, old)
+		}
+
+		for _, c := range match.Diff(stored, []match.Match{m}) {
+			c.ID = int64(len(s.changes) + 1)
+			s.changes = append(s.changes, c)
+		}
+
+		if old, ok := s.matches[m.Key]; ok && old.Manual && !m.Manual {
+			continue
+		}
+		s.matches[m.Key] = m
+		changes.Matches++
+	}
+
+	return changes, nil
+}
+
+func (s *memMatches) SetManual(ctx context.Context, eventKey string, manual bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, m := range s.matches {
+		if m.EventKey == eventKey {
+			m.Manual = manual
+			s.matches[key] = m
+		}
+	}
+
+	return nil
+}
+
+func (s *memMatches) SetManualByKey(ctx context.Context, matchKeys []string, manual bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, key := range matchKeys {
+		if m, ok := s.matches[key]; ok {
+			m.Manual = manual
+			s.matches[key] = m
+		}
+	}
+
+	return nil
+}
+
+func (s *memMatches) GetChanges(ctx context.Context, eventKey string, since int64) ([]match.Change, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	changes := []match.Change{}
+	for _, c := range s.changes {
+		if c.EventKey == eventKey && c.ID > since {
+			changes = append(changes, c)
+		}
+	}
+
+	return changes, nil
+}
+
+// memPicklists is an in-memory picklist store for tests. Picklists are only
+// ever accessed by their owners, so it doesn't keep shares.
+type memPicklists struct {
+	picklist.Service
+
+	mu        sync.Mutex
+	picklists map[string]picklist.Picklist
+}
+
+func newMemPicklists() *memPicklists {
+	return &memPicklists{picklists: make(map[string]picklist.Picklist)}
+}
+
+func (s *memPicklists) Get(ctx context.Context, org, id string) (picklist.Picklist, error) {
+	if err := ctx.Err(); err != nil {
+		return picklist.Picklist{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.picklists[id]
+	if !ok || p.Org != org {
+		return picklist.Picklist{}, store.ErrNoResults
+	}
+
+	return p, nil
+}
+
+func (s *memPicklists) GetOwner(ctx context.Context, org, id string) (string, error) {
+	p, err := s.Get(ctx, org, id)
+	return p.Owner, err
+}
+
+func (s *memPicklists) Update(ctx context.Context, p picklist.Picklist, editor string) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, ok := s.picklists[p.ID]
+	if !ok || current.Org != p.Org {
+		return 0, store.ErrNoResults
+	} else if p.Version != 0 && p.Version != current.Version {
+		return 0, store.ErrConflict
+	}
+
+	p.Version = current.Version + 1
+	s.picklists[p.ID] = p
+
+	return p.Version, nil
+}
+
+// memReport is a report stored in memReports, with when it was submitted.
+type memReport struct {
+	report.Report
+	created time.Time
+}
+
+// memReports is an in-memory report store for tests. Without full-text
+// indexes, it searches notes with the search package. Reports are only read by
+// the organization that submitted them.
+type memReports struct {
+	report.Service
+
+	mu      sync.Mutex
+	reports []memReport
+}
+
+func (s *memReports) SearchNotes(ctx context.Context, org string, q report.NoteQuery) ([]report.NoteResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	results := []report.NoteResult{}
+	for _, r := range s.reports {
+		if r.Org != org || r.Notes == nil || !search.Match(q.Text, *r.Notes) {
+			continue
+		}
+		if (q.EventKey != "" && r.EventKey != q.EventKey) || (q.Team != "" && r.Team != q.Team) || (q.Reporter != "" && r.Reporter != q.Reporter) {
+			continue
+		}
+		if (q.Since != nil && r.created.Before(*q.Since)) || (q.Until != nil && !r.created.Before(*q.Until)) {
+			continue
+		}
+
+		results = append(results, report.NoteResult{
+			Reporter: r.Reporter,
+			EventKey: r.EventKey,
+			MatchKey: r.MatchKey,
+			Team:     r.Team,
+			Snippet:  search.Highlight(q.Text, *r.Notes, 25),
+			Created:  r.created,
+		})
+		if len(results) == q.Limit {
+			break
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,100 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/Pigmice2733/scouting-backend/internal/respond"
+	"github.com/Pigmice2733/scouting-backend/internal/store"
+	"github.com/Pigmice2733/scouting-backend/internal/store/tag"
+)
+
+// getTags gets the report tags an organization uses in a season, falling back
+// to the default tags if the organization hasn't configured any.
+func (s *Server) getTags(ctx context.Context, org string, year int) ([]tag.Tag, error) {
+	tags, err := s.store.Tag.Get(ctx, org, year)
+	if err == store.ErrNoResults {
+		return tag.Defaults, nil
+	}
+	return tags, err
+}
+
+// eventYear gets the season of an event from its key, which starts with the
+// year. If the key doesn't start with a year, the current season is used.
+func (s *Server) eventYear(eventKey string) int {
+	if len(eventKey) >= 4 {
+		if year, err := strconv.Atoi(eventKey[:4]); err == nil {
+			return year
+		}
+	}
+	return s.year
+}
+
+// tagsYear gets the season from the year query parameter, defaulting to the
+// current season.
+func (s *Server) tagsYear(r *http.Request) (int, bool) {
+	yearStr := r.URL.Query().Get("year")
+	if yearStr == "" {
+		return s.year, true
+	}
+
+	year, err := strconv.Atoi(yearStr)
+	return year, err == nil
+}
+
+func (s *Server) tagsHandler(w http.ResponseWriter, r *http.Request) {
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	year, ok := s.tagsYear(r)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	tags, err := s.getTags(r.Context(), org, year)
+	if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("getting tags: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	respond.Negotiate(w, r, tags)
+}
+
+func (s *Server) updateTagsHandler(w http.ResponseWriter, r *http.Request) {
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	year, ok := s.tagsYear(r)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	var tags []tag.Tag
+	if err := json.NewDecoder(r.Body).Decode(&tags); err != nil || tags == nil {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	if err := tag.ValidateTags(tags); err != nil {
+		respond.ErrorCode(w, http.StatusBadRequest, codeInvalidTags, err.Error())
+		return
+	}
+
+	if err := s.store.Tag.Set(r.Context(), org, year, tags); err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("setting tags: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+}
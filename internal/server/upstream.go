@@ -0,0 +1,18 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/Pigmice2733/scouting-backend/internal/respond"
+	"github.com/Pigmice2733/scouting-backend/internal/tba"
+	"github.com/Pigmice2733/scouting-backend/internal/tba/client"
+)
+
+func (s *Server) upstreamHandler(w http.ResponseWriter, r *http.Request) {
+	upstreams := make(map[string]client.Stats)
+	if m, ok := s.consumer.(tba.Monitor); ok {
+		upstreams = m.Upstreams()
+	}
+
+	respond.Negotiate(w, r, upstreams)
+}
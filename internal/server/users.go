@@ -12,61 +12,111 @@ import (
 
 	"github.com/Pigmice2733/scouting-backend/internal/respond"
 	"github.com/Pigmice2733/scouting-backend/internal/server/logic"
+	"github.com/Pigmice2733/scouting-backend/internal/store/role"
 	"github.com/Pigmice2733/scouting-backend/internal/store/user"
 	"github.com/gorilla/mux"
 )
 
 type requestUser struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
-	IsAdmin  bool   `json:"isAdmin"`
+	Username string   `json:"username"`
+	Password string   `json:"password"`
+	Roles    []string `json:"roles"`
+	Org      string   `json:"org"`
 }
 
 type nullableRequestUser struct {
-	Username   *string `json:"username"`
-	Password   *string `json:"password"`
-	IsAdmin    *bool   `json:"isAdmin"`
-	IsVerified *bool   `json:"isVerified"`
+	Username   *string  `json:"username"`
+	Password   *string  `json:"password"`
+	IsVerified *bool    `json:"isVerified"`
+	Roles      []string `json:"roles"`
 }
 
 func (s *Server) authenticateHandler(w http.ResponseWriter, r *http.Request) {
 	var reqUser requestUser
 
 	if err := json.NewDecoder(r.Body).Decode(&reqUser); err != nil {
-		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		respond.Error(w, http.StatusBadRequest)
 		return
 	}
 
-	ss, err := logic.Authenticate(reqUser.Username, reqUser.Password, s.jwtSecret, s.store.User)
+	tokens, err := logic.Authenticate(r.Context(), reqUser.Username, reqUser.Password, s.keys, s.store.User, s.store.Session)
 	if err != nil {
 		if err == logic.ErrUnauthorized {
-			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			respond.Error(w, http.StatusUnauthorized)
 		} else {
 			s.logger.LogRequestError(r, fmt.Errorf("authenticating user: %v", err))
-			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			respond.Error(w, http.StatusInternalServerError)
 		}
 		return
 	}
 
-	respond.JSON(w, map[string]string{"jwt": ss})
+	respond.Negotiate(w, r, tokens)
+}
+
+func (s *Server) refreshHandler(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		RefreshToken string `json:"refreshToken"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	tokens, err := logic.Refresh(r.Context(), req.RefreshToken, s.keys, s.store.User, s.store.Session)
+	if err != nil {
+		if err == logic.ErrUnauthorized {
+			respond.Error(w, http.StatusUnauthorized)
+		} else {
+			s.logger.LogRequestError(r, fmt.Errorf("refreshing session: %v", err))
+			respond.Error(w, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	respond.Negotiate(w, r, tokens)
+}
+
+func (s *Server) logoutHandler(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := r.Context().Value(keySessionCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	if err := s.store.Session.Revoke(r.Context(), sessionID); err != nil && err != store.ErrNoResults {
+		s.logger.LogRequestError(r, fmt.Errorf("revoking session: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+}
+
+func (s *Server) jwksHandler(w http.ResponseWriter, r *http.Request) {
+	respond.Negotiate(w, r, s.keys.JWKS())
 }
 
 func (s *Server) getUsersHandler(w http.ResponseWriter, r *http.Request) {
-	users, err := s.store.User.GetUsers()
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
+	users, err := s.store.User.GetUsers(r.Context(), org)
 	if err == store.ErrNoResults {
 		users = []user.User{}
 	} else if err != nil {
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		respond.Error(w, http.StatusInternalServerError)
 		s.logger.LogRequestError(r, fmt.Errorf("getting all users: %v", err))
 		return
 	}
 
 	var resp []map[string]interface{}
 	for _, u := range users {
-		resp = append(resp, map[string]interface{}{"username": u.Username, "isAdmin": u.IsAdmin, "isVerified": u.IsVerified})
+		resp = append(resp, map[string]interface{}{"username": u.Username, "org": u.Org, "roles": u.Roles, "isVerified": u.IsVerified})
 	}
 
-	respond.JSON(w, resp)
+	respond.Negotiate(w, r, resp)
 }
 
 var usernameRegex = regexp.MustCompile(`^[0-9A-Za-z\s]+$`)
@@ -75,95 +125,210 @@ func (s *Server) createUserHandler(w http.ResponseWriter, r *http.Request) {
 	var reqUser requestUser
 
 	if err := json.NewDecoder(r.Body).Decode(&reqUser); err != nil {
-		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		respond.Error(w, http.StatusBadRequest)
 		return
 	}
 
 	if len(reqUser.Username) == 0 {
-		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		respond.Error(w, http.StatusBadRequest)
 		return
 	}
 
 	if !usernameRegex.Match([]byte(reqUser.Username)) {
-		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		respond.Error(w, http.StatusBadRequest)
 		return
 	}
 
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(reqUser.Password), bcrypt.DefaultCost)
 	if err != nil {
 		s.logger.LogRequestError(r, fmt.Errorf("generating bcrypt hash from password: %v", err))
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	creatorOrg, permissions := s.getPermissions(r)
+
+	// users created by someone who can edit all users are verified right away,
+	// put in the creator's organization and can be given roles, everyone else
+	// signs up to an organization as an unverified scout
+	isVerified := hasPermissions(permissions, role.UserEditAll)
+
+	org := reqUser.Org
+	if isVerified && org == "" {
+		org = creatorOrg
+	} else if isVerified && org != creatorOrg && !hasPermissions(permissions, role.OrgManage) {
+		respond.Error(w, http.StatusForbidden)
+		return
+	}
+
+	if org == "" {
+		respond.ErrorCode(w, http.StatusBadRequest, codeOrgRequired, "an organization to join is required")
+		return
+	}
+
+	if _, err := s.store.Org.Get(r.Context(), org); err == store.ErrNoResults {
+		respond.ErrorCode(w, http.StatusBadRequest, codeOrgRequired, fmt.Sprintf("organization '%s' doesn't exist", org))
+		return
+	} else if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("getting organization: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	roles := []string{role.Scout}
+	if isVerified && reqUser.Roles != nil {
+		roles = reqUser.Roles
+	}
+
+	if ok, err := logic.ValidRoles(r.Context(), roles, s.store.Role); err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("validating roles: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
+		return
+	} else if !ok {
+		respond.Error(w, http.StatusBadRequest)
 		return
 	}
 
-	user := user.User{Username: reqUser.Username, HashedPassword: string(hashedPassword), IsAdmin: reqUser.IsAdmin, IsVerified: s.getIsAdmin(r)}
-	if err := s.store.User.Create(user); err != nil {
+	if isVerified {
+		if ok, err := logic.CanGrant(r.Context(), permissions, roles, s.store.Role); err != nil {
+			s.logger.LogRequestError(r, fmt.Errorf("checking granted roles: %v", err))
+			respond.Error(w, http.StatusInternalServerError)
+			return
+		} else if !ok {
+			respond.Error(w, http.StatusForbidden)
+			return
+		}
+	}
+
+	user := user.User{Username: reqUser.Username, HashedPassword: string(hashedPassword), IsVerified: isVerified, Roles: roles, Org: org}
+	if err := s.store.User.Create(r.Context(), user); err != nil {
 		s.logger.LogRequestError(r, fmt.Errorf("creating user: %v", err))
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		respond.Error(w, http.StatusInternalServerError)
 		return
 	}
 }
 
 func (s *Server) updateUserHandler(w http.ResponseWriter, r *http.Request) {
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
+
 	usernameToUpdate := mux.Vars(r)["username"]
 
-	authenticatedUser, uOk := r.Context().Value(keyUsernameCtx).(string)
-	authenticatedIsAdmin, aOk := r.Context().Value(keyIsAdminCtx).(bool)
+	var reqUser nullableRequestUser
+	if err := json.NewDecoder(r.Body).Decode(&reqUser); err != nil {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
 
-	if !uOk || !aOk {
-		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+	if (reqUser.Roles != nil || reqUser.IsVerified != nil) && !hasPermission(r, role.UserEditAll) {
+		respond.Error(w, http.StatusForbidden)
 		return
 	}
 
-	var reqUser nullableRequestUser
-	if err := json.NewDecoder(r.Body).Decode(&reqUser); err != nil {
-		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+	if reqUser.Username != nil && !usernameRegex.MatchString(*reqUser.Username) {
+		respond.Error(w, http.StatusBadRequest)
 		return
 	}
 
-	if !authenticatedIsAdmin && (usernameToUpdate != authenticatedUser || (reqUser.IsAdmin != nil && *reqUser.IsAdmin) || (reqUser.IsVerified != nil && *reqUser.IsVerified)) {
-		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+	oldUser, err := s.store.User.Get(r.Context(), usernameToUpdate)
+	if err == store.ErrNoResults || (err == nil && oldUser.Org != org) {
+		respond.Error(w, http.StatusNotFound)
+		return
+	} else if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("getting user: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
 		return
 	}
 
-	updateUser := user.NullableUser{Username: reqUser.Username, IsAdmin: reqUser.IsAdmin, IsVerified: reqUser.IsVerified}
+	if reqUser.Roles != nil {
+		if ok, err := logic.ValidRoles(r.Context(), reqUser.Roles, s.store.Role); err != nil {
+			s.logger.LogRequestError(r, fmt.Errorf("validating roles: %v", err))
+			respond.Error(w, http.StatusInternalServerError)
+			return
+		} else if !ok {
+			respond.Error(w, http.StatusBadRequest)
+			return
+		}
+
+		permissions, _ := r.Context().Value(keyPermissionsCtx).([]string)
+		if ok, err := logic.CanGrant(r.Context(), permissions, reqUser.Roles, s.store.Role); err != nil {
+			s.logger.LogRequestError(r, fmt.Errorf("checking granted roles: %v", err))
+			respond.Error(w, http.StatusInternalServerError)
+			return
+		} else if !ok {
+			respond.Error(w, http.StatusForbidden)
+			return
+		}
+	}
+
+	updateUser := user.NullableUser{Username: reqUser.Username, IsVerified: reqUser.IsVerified, Roles: reqUser.Roles}
 
 	if reqUser.Password != nil {
 		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(*reqUser.Password), bcrypt.DefaultCost)
 		if err != nil {
-			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			respond.Error(w, http.StatusInternalServerError)
 			return
 		}
 		hashededPasswordStr := string(hashedPassword)
 		updateUser.HashedPassword = &hashededPasswordStr
 	}
 
-	if err := s.store.User.Update(usernameToUpdate, updateUser); err != nil {
+	if err := s.store.User.Update(r.Context(), org, usernameToUpdate, updateUser); err == store.ErrNoResults {
+		respond.Error(w, http.StatusNotFound)
+		return
+	} else if err != nil {
 		s.logger.LogRequestError(r, fmt.Errorf("updating user: %v", err))
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		respond.Error(w, http.StatusInternalServerError)
 		return
 	}
+
+	// changing credentials or taking away roles ends all existing sessions,
+	// since the roles of a user are in their jwts
+	demoted := reqUser.Roles != nil && logic.RolesRemoved(oldUser.Roles, reqUser.Roles)
+	if reqUser.Username != nil || reqUser.Password != nil || demoted || (reqUser.IsVerified != nil && !*reqUser.IsVerified) {
+		username := usernameToUpdate
+		if reqUser.Username != nil {
+			username = *reqUser.Username
+		}
+
+		if err := s.store.Session.RevokeAll(r.Context(), username); err != nil {
+			s.logger.LogRequestError(r, fmt.Errorf("revoking user sessions: %v", err))
+			respond.Error(w, http.StatusInternalServerError)
+			return
+		}
+	}
 }
 
 func (s *Server) deleteUserHandler(w http.ResponseWriter, r *http.Request) {
-	usernameToDelete := mux.Vars(r)["username"]
+	org, ok := r.Context().Value(keyOrgCtx).(string)
+	if !ok {
+		respond.Error(w, http.StatusBadRequest)
+		return
+	}
 
-	authenticatedUser, uOk := r.Context().Value(keyUsernameCtx).(string)
-	authenticatedIsAdmin, aOk := r.Context().Value(keyIsAdminCtx).(bool)
+	usernameToDelete := mux.Vars(r)["username"]
 
-	if !uOk || !aOk {
-		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+	if u, err := s.store.User.Get(r.Context(), usernameToDelete); err == store.ErrNoResults || (err == nil && u.Org != org) {
+		respond.Error(w, http.StatusNotFound)
+		return
+	} else if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("getting user: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
 		return
 	}
 
-	if !authenticatedIsAdmin && usernameToDelete != authenticatedUser {
-		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+	if err := s.store.Session.RevokeAll(r.Context(), usernameToDelete); err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("revoking user sessions: %v", err))
+		respond.Error(w, http.StatusInternalServerError)
 		return
 	}
 
-	if err := s.store.User.Delete(usernameToDelete); err != nil {
+	if err := s.store.User.Delete(r.Context(), org, usernameToDelete); err != nil {
 		s.logger.LogRequestError(r, fmt.Errorf("deleting user: %v", err))
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		respond.Error(w, http.StatusInternalServerError)
 		return
 	}
 }
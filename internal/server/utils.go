@@ -7,96 +7,216 @@ import (
 	"hash/crc32"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/NYTimes/gziphandler"
+	"github.com/Pigmice2733/scouting-backend/internal/mroute"
+	"github.com/Pigmice2733/scouting-backend/internal/respond"
 	"github.com/Pigmice2733/scouting-backend/internal/server/logic"
 	jwt "github.com/dgrijalva/jwt-go"
 	"github.com/fharding1/ezetag"
+	"github.com/gorilla/mux"
+)
+
+// Problem codes for errors more specific than their HTTP status.
+const (
+	codeInvalidToken      = "invalid-token"
+	codeMissingPermission = "missing-permission"
+	codeVersionConflict   = "version-conflict"
+	codeInvalidStats      = "invalid-report-stats"
+	codeInvalidTags       = "invalid-tags"
+	codeInvalidPicks      = "invalid-picks"
+	codeInvalidBundle     = "invalid-bundle"
+	codeUntrustedBundle   = "untrusted-bundle"
+	codeInvalidAlliances  = "invalid-alliances"
+	codeInvalidSchedule   = "invalid-schedule"
+	codeOffline           = "offline"
+	codeOrgRequired       = "org-required"
+	codeDefaultRole       = "default-role"
 )
 
 type key int
 
 const (
 	keyUsernameCtx key = iota
-	keyIsAdminCtx
+	keyRolesCtx
+	keyPermissionsCtx
+	keySessionCtx
+	keyOrgCtx
 )
 
-func (s *Server) getIsAdmin(r *http.Request) bool {
+// parseToken parses and validates the jwt of a request, and makes sure the
+// session it was issued for has not been revoked.
+func (s *Server) parseToken(r *http.Request) (jwt.MapClaims, error) {
 	ss := strings.TrimPrefix(r.Header.Get("Authentication"), "Bearer ")
-	token, err := jwt.Parse(ss, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	token, err := jwt.Parse(ss, s.keys.Keyfunc)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, fmt.Errorf("invalid token")
+	}
+
+	sessionID, ok := claims[logic.SessionClaim].(string)
+	if !ok {
+		return nil, fmt.Errorf("token has no session")
+	}
+
+	sess, err := s.store.Session.Get(r.Context(), sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("getting session: %v", err)
+	}
+
+	if !sess.Active() {
+		return nil, fmt.Errorf("session is no longer active")
+	}
+
+	return claims, nil
+}
+
+// rolesFromClaims gets the roles from the claims of a jwt.
+func rolesFromClaims(claims jwt.MapClaims) ([]string, bool) {
+	rawRoles, ok := claims[logic.RolesClaim].([]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	roles := make([]string, 0, len(rawRoles))
+	for _, rawRole := range rawRoles {
+		role, ok := rawRole.(string)
+		if !ok {
+			return nil, false
 		}
+		roles = append(roles, role)
+	}
 
-		return s.jwtSecret, nil
-	})
+	return roles, true
+}
 
+// getPermissions gets the organization and permissions of the user making a
+// request, if the request is authenticated. It is for routes that do not
+// require authentication, but behave differently for some users.
+func (s *Server) getPermissions(r *http.Request) (string, []string) {
+	claims, err := s.parseToken(r)
 	if err != nil {
-		return false
+		return "", nil
 	}
 
-	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		isAdmin, ok := claims[logic.IsAdminClaim].(bool)
-		return ok && isAdmin
-	} else {
-		return false
+	roles, rOk := rolesFromClaims(claims)
+	org, oOk := claims[logic.OrgClaim].(string)
+	if !rOk || !oOk {
+		return "", nil
+	}
+
+	permissions, err := s.store.Role.GetPermissions(r.Context(), roles)
+	if err != nil {
+		s.logger.LogRequestError(r, fmt.Errorf("getting permissions: %v", err))
+		return "", nil
 	}
+
+	return org, permissions
 }
 
 func (s *Server) authHandler(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ss := strings.TrimPrefix(r.Header.Get("Authentication"), "Bearer ")
-		token, err := jwt.Parse(ss, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-			}
-
-			return s.jwtSecret, nil
-		})
-
+		claims, err := s.parseToken(r)
 		if err != nil {
-			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			respond.ErrorCode(w, http.StatusUnauthorized, codeInvalidToken, err.Error())
 			return
 		}
 
-		var username string
-		var isAdmin bool
+		username, uOk := claims[logic.SubjectClaim].(string)
+		roles, rOk := rolesFromClaims(claims)
+		sessionID, sOk := claims[logic.SessionClaim].(string)
+		org, oOk := claims[logic.OrgClaim].(string)
 
-		if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-			var uOk, aOk bool
-			username, uOk = claims[logic.SubjectClaim].(string)
-			isAdmin, aOk = claims[logic.IsAdminClaim].(bool)
+		if !uOk || !rOk || !sOk || !oOk {
+			respond.ErrorCode(w, http.StatusUnauthorized, codeInvalidToken, "token is missing claims")
+			return
+		}
 
-			if !uOk || !aOk {
-				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
-				return
-			}
-		} else {
-			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		permissions, err := s.store.Role.GetPermissions(r.Context(), roles)
+		if err != nil {
+			s.logger.LogRequestError(r, fmt.Errorf("getting permissions: %v", err))
+			respond.Error(w, http.StatusInternalServerError)
 			return
 		}
 
 		ctx := context.WithValue(r.Context(), keyUsernameCtx, username)
-		ctx = context.WithValue(ctx, keyIsAdminCtx, isAdmin)
+		ctx = context.WithValue(ctx, keyRolesCtx, roles)
+		ctx = context.WithValue(ctx, keyPermissionsCtx, permissions)
+		ctx = context.WithValue(ctx, keySessionCtx, sessionID)
+		ctx = context.WithValue(ctx, keyOrgCtx, org)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
-func adminHandler(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		isAdmin, ok := r.Context().Value(keyIsAdminCtx).(bool)
-		if !ok {
-			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
-			return
+// hasPermissions returns whether all of the required permissions are in the
+// granted permissions.
+func hasPermissions(granted []string, required ...string) bool {
+outer:
+	for _, req := range required {
+		for _, g := range granted {
+			if g == req {
+				continue outer
+			}
 		}
+		return false
+	}
 
-		if !isAdmin {
-			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
-			return
-		}
+	return true
+}
 
-		next.ServeHTTP(w, r)
-	})
+// hasPermission returns whether the authenticated user of a request has a
+// permission.
+func hasPermission(r *http.Request, permission string) bool {
+	granted, ok := r.Context().Value(keyPermissionsCtx).([]string)
+	return ok && hasPermissions(granted, permission)
+}
+
+// require only lets requests through from users that have all of the given
+// permissions. It must be applied after authHandler.
+func require(permissions ...string) mroute.Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			granted, ok := r.Context().Value(keyPermissionsCtx).([]string)
+			if !ok {
+				respond.Error(w, http.StatusUnauthorized)
+				return
+			}
+
+			if !hasPermissions(granted, permissions...) {
+				respond.ErrorCode(w, http.StatusForbidden, codeMissingPermission, "requires "+strings.Join(permissions, ", "))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// requireSelfOr only lets requests through that are for the authenticated user
+// (given by the username route variable), or from users that have all of the
+// given permissions. It must be applied after authHandler.
+func requireSelfOr(permissions ...string) mroute.Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			username, ok := r.Context().Value(keyUsernameCtx).(string)
+			if !ok {
+				respond.Error(w, http.StatusUnauthorized)
+				return
+			}
+
+			if username == mux.Vars(r)["username"] {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			require(permissions...)(next).ServeHTTP(w, r)
+		})
+	}
 }
 
 func cors(next http.Handler, origin string) http.Handler {
@@ -116,6 +236,21 @@ func limitBody(next http.Handler) http.Handler {
 	})
 }
 
+// timeout sets a deadline on the context of requests, so that store queries and
+// TBA requests made to handle them are cancelled when it passes.
+func timeout(next http.Handler, d time.Duration) http.Handler {
+	if d <= 0 {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), d)
+		defer cancel()
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
 var castagoliTable = crc32.MakeTable(crc32.Castagnoli)
 
 func cache(next http.Handler) http.Handler {
@@ -127,3 +262,16 @@ func cache(next http.Handler) http.Handler {
 		return crc32.New(castagoliTable)
 	}))
 }
+
+// privateCache is cache for authenticated responses, which depend on the user's
+// org and roles, so they must only be cached by the user's own client.
+func privateCache(next http.Handler) http.Handler {
+	return gziphandler.GzipHandler(ezetag.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "private, max-age=180")
+		w.Header().Add("Vary", "Authentication")
+
+		next.ServeHTTP(w, r)
+	}), func() hash.Hash {
+		return crc32.New(castagoliTable)
+	}))
+}
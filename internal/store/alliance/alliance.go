@@ -1,11 +1,21 @@
 package alliance
 
+import "context"
+
 // Alliance holds all the team numbers in a given alliance.
 type Alliance []string
 
+// Appearance is a team playing on an alliance in a match.
+type Appearance struct {
+	EventKey string
+	MatchKey string
+	IsBlue   bool
+	Team     string
+}
+
 // Service is a store for alliances.
 type Service interface {
-	GetColor(matchKey string, number string) (bool, error)
-	Get(matchKey string, isBlue bool) (Alliance, error)
-	Upsert(matchKey string, isBlue bool, alliance Alliance) error
+	GetColor(ctx context.Context, matchKey string, number string) (bool, error)
+	Get(ctx context.Context, matchKey string, isBlue bool) (Alliance, error)
+	GetSharedMatches(ctx context.Context, eventKeys []string, teams []string) ([]Appearance, error)
 }
@@ -1,32 +1,34 @@
 package postgres
 
 import (
-	"database/sql"
+	"context"
 
 	"github.com/Pigmice2733/scouting-backend/internal/store/alliance"
+	"github.com/Pigmice2733/scouting-backend/internal/store/postgres/txdb"
+	"github.com/lib/pq"
 )
 
 // Service is used for getting information about an alliance from a postgres database.
 type Service struct {
-	db *sql.DB
+	db txdb.DB
 }
 
 // New creates a new alliance service.
-func New(db *sql.DB) alliance.Service {
+func New(db txdb.DB) alliance.Service {
 	return &Service{db: db}
 }
 
 // GetColor retrieves the color of the alliance given a matchKey and number.
-func (s *Service) GetColor(matchKey string, number string) (isBlue bool, err error) {
-	err = s.db.QueryRow("SELECT isBlue FROM alliances WHERE matchKey = $1 AND number = $2", matchKey, number).Scan(&isBlue)
+func (s *Service) GetColor(ctx context.Context, matchKey string, number string) (isBlue bool, err error) {
+	err = s.db.QueryRowContext(ctx, "SELECT isBlue FROM alliances WHERE matchKey = $1 AND number = $2", matchKey, number).Scan(&isBlue)
 	return
 }
 
 // Get gets a certain alliance given a matchKey and whether they were blue or red.
-func (s *Service) Get(matchKey string, isBlue bool) (alliance.Alliance, error) {
+func (s *Service) Get(ctx context.Context, matchKey string, isBlue bool) (alliance.Alliance, error) {
 	alliances := make(alliance.Alliance, 0)
 
-	rows, err := s.db.Query("SELECT number FROM alliances WHERE matchKey = $1 AND isBlue = $2", matchKey, isBlue)
+	rows, err := s.db.QueryContext(ctx, "SELECT number FROM alliances WHERE matchKey = $1 AND isBlue = $2", matchKey, isBlue)
 	if err != nil {
 		return alliances, err
 	}
@@ -43,27 +45,37 @@ func (s *Service) Get(matchKey string, isBlue bool) (alliance.Alliance, error) {
 	return alliances, rows.Err()
 }
 
-// Upsert upserts a whole alliance in the postgres database.
-func (s *Service) Upsert(matchKey string, isBlue bool, alliance alliance.Alliance) error {
-	stmt, err := s.db.Prepare(`
-		INSERT INTO alliances (matchKey, isBlue, number)
-		VALUES ($1, $2, $3)
-		ON CONFLICT (matchKey, number)
-		DO
-			UPDATE
-				SET
-					isBlue = $2
-		`)
+// GetSharedMatches gets the appearances of the given teams in every match at
+// the given events where at least two of them played, either together or
+// against each other.
+func (s *Service) GetSharedMatches(ctx context.Context, eventKeys []string, teams []string) ([]alliance.Appearance, error) {
+	appearances := []alliance.Appearance{}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT m.eventKey, a.matchKey, a.isBlue, a.number
+			FROM alliances a
+			INNER JOIN matches m ON m.key = a.matchKey
+			WHERE m.eventKey = ANY($1) AND a.number = ANY($2) AND a.matchKey IN (
+				SELECT matchKey
+					FROM alliances
+					WHERE number = ANY($2)
+					GROUP BY matchKey
+					HAVING COUNT(*) > 1
+			)
+			ORDER BY COALESCE(m.actualTime, m.predictedTime), a.matchKey, a.number
+		`, pq.Array(eventKeys), pq.Array(teams))
 	if err != nil {
-		return err
+		return appearances, err
 	}
-	defer stmt.Close()
+	defer rows.Close()
 
-	for _, team := range alliance {
-		if _, err := stmt.Exec(matchKey, isBlue, team); err != nil {
-			return err
+	for rows.Next() {
+		var a alliance.Appearance
+		if err := rows.Scan(&a.EventKey, &a.MatchKey, &a.IsBlue, &a.Team); err != nil {
+			return appearances, err
 		}
+		appearances = append(appearances, a)
 	}
 
-	return nil
+	return appearances, rows.Err()
 }
@@ -0,0 +1,23 @@
+package bundlekey
+
+import (
+	"context"
+	"golang.org/x/crypto/ed25519"
+)
+
+// Trusted is the public key a partner organization signs its event bundles
+// with, which an organization has chosen to trust.
+type Trusted struct {
+	Org       string            `json:"org"`
+	PublicKey ed25519.PublicKey `json:"publicKey"`
+}
+
+// Service is a store for the keys organizations sign event bundles with, and
+// the keys of partners they trust bundles from.
+type Service interface {
+	Get(ctx context.Context, org string) (ed25519.PrivateKey, error)
+	Create(ctx context.Context, org string, key ed25519.PrivateKey) error
+	GetTrusted(ctx context.Context, org string) ([]Trusted, error)
+	SetTrusted(ctx context.Context, org string, t Trusted) error
+	DeleteTrusted(ctx context.Context, org, partner string) error
+}
@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/Pigmice2733/scouting-backend/internal/store"
+	"github.com/Pigmice2733/scouting-backend/internal/store/bundlekey"
+	"github.com/Pigmice2733/scouting-backend/internal/store/postgres/txdb"
+	"golang.org/x/crypto/ed25519"
+)
+
+// Service is used for getting bundle signing keys from a postgres database.
+type Service struct {
+	db txdb.DB
+}
+
+// New creates a new bundle key service.
+func New(db txdb.DB) bundlekey.Service {
+	return &Service{db: db}
+}
+
+// Get gets the bundle signing key of an organization from the postgresql
+// database.
+func (s *Service) Get(ctx context.Context, org string) (ed25519.PrivateKey, error) {
+	var key []byte
+	err := s.db.QueryRowContext(ctx, "SELECT privateKey FROM bundleKeys WHERE org = $1", org).Scan(&key)
+	if err == sql.ErrNoRows {
+		return nil, store.ErrNoResults
+	}
+	return ed25519.PrivateKey(key), err
+}
+
+// Create stores the bundle signing key of an organization in the postgresql
+// database. If the organization already has a key, store.ErrConflict is
+// returned and the existing key is kept.
+func (s *Service) Create(ctx context.Context, org string, key ed25519.PrivateKey) error {
+	res, err := s.db.ExecContext(ctx, "INSERT INTO bundleKeys (org, privateKey) VALUES ($1, $2) ON CONFLICT (org) DO NOTHING", org, []byte(key))
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return store.ErrConflict
+	}
+
+	return nil
+}
+
+// GetTrusted gets the bundle keys of partners an organization trusts from the
+// postgresql database.
+func (s *Service) GetTrusted(ctx context.Context, org string) ([]bundlekey.Trusted, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT partner, publicKey FROM trustedBundleKeys WHERE org = $1 ORDER BY partner", org)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	trusted := []bundlekey.Trusted{}
+	for rows.Next() {
+		var t bundlekey.Trusted
+		var key []byte
+		if err := rows.Scan(&t.Org, &key); err != nil {
+			return nil, err
+		}
+		t.PublicKey = ed25519.PublicKey(key)
+		trusted = append(trusted, t)
+	}
+
+	return trusted, rows.Err()
+}
+
+// SetTrusted sets the bundle key of a partner an organization trusts in the
+// postgresql database.
+func (s *Service) SetTrusted(ctx context.Context, org string, t bundlekey.Trusted) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO trustedBundleKeys (org, partner, publicKey)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (org, partner)
+		DO
+			UPDATE
+				SET publicKey = $3
+	`, org, t.Org, []byte(t.PublicKey))
+	return err
+}
+
+// DeleteTrusted stops an organization from trusting the bundle key of a
+// partner in the postgresql database.
+func (s *Service) DeleteTrusted(ctx context.Context, org, partner string) error {
+	res, err := s.db.ExecContext(ctx, "DELETE FROM trustedBundleKeys WHERE org = $1 AND partner = $2", org, partner)
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return store.ErrNoResults
+	}
+
+	return nil
+}
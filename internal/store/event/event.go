@@ -1,6 +1,7 @@
 package event
 
 import (
+	"context"
 	"time"
 
 	"github.com/Pigmice2733/scouting-backend/internal/store/match"
@@ -16,6 +17,9 @@ type BasicEvent struct {
 	Long      *float64  `json:"long,omitempty"`
 	Date      time.Time `json:"date"`
 	EndDate   time.Time `json:"endDate"`
+	// Manual is whether the event was entered by hand rather than polled from
+	// TBA. Manual events aren't overwritten by TBA data.
+	Manual bool `json:"manual"`
 }
 
 // Event holds basic information about an event as well as matches.
@@ -26,7 +30,8 @@ type Event struct {
 
 // Service is a store for events.
 type Service interface {
-	GetBasicEvents() ([]BasicEvent, error)
-	Get(key string, ms match.Service) (Event, error)
-	MassUpsert([]BasicEvent) error
+	GetBasicEvents(ctx context.Context) ([]BasicEvent, error)
+	Get(ctx context.Context, key string, ms match.Service) (Event, error)
+	MassUpsert(ctx context.Context, bEvents []BasicEvent) (int, error)
+	SetManual(ctx context.Context, key string, manual bool) error
 }
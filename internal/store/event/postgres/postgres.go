@@ -1,28 +1,31 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 
 	"github.com/Pigmice2733/scouting-backend/internal/store"
 	"github.com/Pigmice2733/scouting-backend/internal/store/event"
 	"github.com/Pigmice2733/scouting-backend/internal/store/match"
+	"github.com/Pigmice2733/scouting-backend/internal/store/postgres/txdb"
+	"github.com/lib/pq"
 )
 
 // Service is used for getting information about an event from a postgres database.
 type Service struct {
-	db *sql.DB
+	db txdb.DB
 }
 
 // New creates a new event service.
-func New(db *sql.DB) event.Service {
+func New(db txdb.DB) event.Service {
 	return &Service{db: db}
 }
 
 // GetBasicEvents returns basic event information fetched from the postgres database.
-func (s *Service) GetBasicEvents() ([]event.BasicEvent, error) {
+func (s *Service) GetBasicEvents(ctx context.Context) ([]event.BasicEvent, error) {
 	var bEvents []event.BasicEvent
 
-	rows, err := s.db.Query("SELECT key, name, date, endDate, shortName, lat, long, eventType FROM events")
+	rows, err := s.db.QueryContext(ctx, "SELECT key, name, date, endDate, shortName, lat, long, eventType, manual FROM events")
 	if err != nil {
 		return bEvents, err
 	}
@@ -30,7 +33,7 @@ func (s *Service) GetBasicEvents() ([]event.BasicEvent, error) {
 
 	for rows.Next() {
 		var bEvent event.BasicEvent
-		if err := rows.Scan(&bEvent.Key, &bEvent.Name, &bEvent.Date, &bEvent.EndDate, &bEvent.ShortName, &bEvent.Lat, &bEvent.Long, &bEvent.EventType); err != nil {
+		if err := rows.Scan(&bEvent.Key, &bEvent.Name, &bEvent.Date, &bEvent.EndDate, &bEvent.ShortName, &bEvent.Lat, &bEvent.Long, &bEvent.EventType, &bEvent.Manual); err != nil {
 			return nil, err
 		}
 		bEvents = append(bEvents, bEvent)
@@ -40,42 +43,127 @@ func (s *Service) GetBasicEvents() ([]event.BasicEvent, error) {
 }
 
 // Get gets a full event from the postgres database.
-func (s *Service) Get(key string, ms match.Service) (e event.Event, err error) {
+func (s *Service) Get(ctx context.Context, key string, ms match.Service) (e event.Event, err error) {
 	e.Key = key
 
-	err = s.db.QueryRow("SELECT name, date, endDate, shortName, lat, long, eventType FROM events WHERE key = $1", key).Scan(
-		&e.Name, &e.Date, &e.EndDate, &e.ShortName, &e.Lat, &e.Long, &e.EventType)
+	err = s.db.QueryRowContext(ctx, "SELECT name, date, endDate, shortName, lat, long, eventType, manual FROM events WHERE key = $1", key).Scan(
+		&e.Name, &e.Date, &e.EndDate, &e.ShortName, &e.Lat, &e.Long, &e.EventType, &e.Manual)
 	if err == sql.ErrNoRows {
 		return e, store.ErrNoResults
 	} else if err != nil {
 		return e, err
 	}
 
-	bMatches, err := ms.GetBasicMatches(key)
+	bMatches, err := ms.GetBasicMatches(ctx, key)
 	e.Matches = bMatches
 
 	return e, err
 }
 
-// MassUpsert upserts multiple events in the postgres database.
-func (s *Service) MassUpsert(bEvents []event.BasicEvent) error {
-	stmt, err := s.db.Prepare(`
-		INSERT INTO events (key, name, shortName, date, endDate, lat, long, eventType)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+// MassUpsert upserts multiple events in the postgres database in a single
+// transaction, and returns how many events were added or changed. Manual events
+// are only updated by other manual events.
+func (s *Service) MassUpsert(ctx context.Context, bEvents []event.BasicEvent) (int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := tx.ExecContext(ctx, "CREATE TEMPORARY TABLE event_upserts (LIKE events) ON COMMIT DROP"); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	stmt, err := tx.PrepareContext(ctx, pq.CopyIn("event_upserts", "key", "name", "shortname", "date", "enddate", "lat", "long", "eventtype", "manual"))
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	for _, bEvent := range dedupe(bEvents) {
+		if _, err := stmt.ExecContext(ctx, bEvent.Key, bEvent.Name, bEvent.ShortName, bEvent.Date, bEvent.EndDate, bEvent.Lat, bEvent.Long, bEvent.EventType, bEvent.Manual); err != nil {
+			stmt.Close()
+			tx.Rollback()
+			return 0, err
+		}
+	}
+
+	if _, err := stmt.ExecContext(ctx); err != nil {
+		stmt.Close()
+		tx.Rollback()
+		return 0, err
+	}
+
+	if err := stmt.Close(); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO events (key, name, shortName, date, endDate, lat, long, eventType, manual)
+		SELECT key, name, shortName, date, endDate, lat, long, eventType, manual FROM event_upserts
 		ON CONFLICT (key)
 		DO
 			UPDATE
-				SET name = $2, shortName = $3, date = $4, endDate = $5, lat = $6, long = $7, eventType = $8
+				SET
+					name = EXCLUDED.name, shortName = EXCLUDED.shortName, date = EXCLUDED.date, endDate = EXCLUDED.endDate,
+					lat = EXCLUDED.lat, long = EXCLUDED.long, eventType = EXCLUDED.eventType, manual = EXCLUDED.manual
+				WHERE (EXCLUDED.manual OR NOT events.manual)
+					AND (events.name, events.shortName, events.date, events.endDate, events.lat, events.long, events.eventType, events.manual)
+						IS DISTINCT FROM
+						(EXCLUDED.name, EXCLUDED.shortName, EXCLUDED.date, EXCLUDED.endDate, EXCLUDED.lat, EXCLUDED.long, EXCLUDED.eventType, EXCLUDED.manual)
 		`)
 	if err != nil {
-		return err
+		tx.Rollback()
+		return 0, err
 	}
-	defer stmt.Close()
+
+	changed, err := res.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	// the table is dropped now rather than on commit, in case this is part of
+	// a larger transaction that upserts events again
+	if _, err := tx.ExecContext(ctx, "DROP TABLE event_upserts"); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	return int(changed), tx.Commit()
+}
+
+// dedupe removes events with the same key, keeping the last one, since an
+// upsert can't change the same row twice.
+func dedupe(bEvents []event.BasicEvent) []event.BasicEvent {
+	deduped := make([]event.BasicEvent, 0, len(bEvents))
+	indices := make(map[string]int)
 
 	for _, bEvent := range bEvents {
-		if _, err := stmt.Exec(bEvent.Key, bEvent.Name, bEvent.ShortName, bEvent.Date, bEvent.EndDate, bEvent.Lat, bEvent.Long, bEvent.EventType); err != nil {
-			return err
+		if i, ok := indices[bEvent.Key]; ok {
+			deduped[i] = bEvent
+			continue
 		}
+		indices[bEvent.Key] = len(deduped)
+		deduped = append(deduped, bEvent)
+	}
+
+	return deduped
+}
+
+// SetManual sets whether an event was entered by hand. Events that aren't
+// manual are overwritten by TBA data again.
+func (s *Service) SetManual(ctx context.Context, key string, manual bool) error {
+	res, err := s.db.ExecContext(ctx, "UPDATE events SET manual = $1 WHERE key = $2", manual, key)
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return store.ErrNoResults
 	}
 
 	return nil
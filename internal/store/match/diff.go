@@ -0,0 +1,118 @@
+package match
+
+import (
+	"sort"
+	"time"
+)
+
+// Kinds of changes to matches.
+const (
+	// ChangeAdded is a new match. Its new value is the whole match.
+	ChangeAdded = "added"
+	// ChangeScore is a change to the scores of a match. Its values are Scores.
+	ChangeScore = "score"
+	// ChangeSchedule is a change to the predicted time of a match. Its values
+	// are times, or nil if the match had no predicted time.
+	ChangeSchedule = "schedule"
+	// ChangeVideo is a video being added to a match, or changed. Its values are
+	// YouTube URLs.
+	ChangeVideo = "video"
+	// ChangeAlliances is a change to the teams on the alliances of a match. Its
+	// values are Alliances.
+	ChangeAlliances = "alliances"
+)
+
+// Change is a change to a match found when it is upserted.
+type Change struct {
+	// ID increases with every change recorded, so it can be used as a cursor.
+	ID       int64       `json:"id"`
+	EventKey string      `json:"-"`
+	MatchKey string      `json:"matchKey"`
+	Kind     string      `json:"kind"`
+	Old      interface{} `json:"old"`
+	New      interface{} `json:"new"`
+	Created  time.Time   `json:"created"`
+}
+
+// Scores are the scores of both alliances in a match.
+type Scores struct {
+	Red  int `json:"redScore"`
+	Blue int `json:"blueScore"`
+}
+
+// Alliances are the teams on both alliances in a match.
+type Alliances struct {
+	Red  []string `json:"redAlliance"`
+	Blue []string `json:"blueAlliance"`
+}
+
+// Diff compares matches being upserted with the matches already stored for an
+// event, and returns the changes upserting them would make. Stored manual
+// matches are only changed by other manual matches, like MassUpsert.
+func Diff(stored, matches []Match) []Change {
+	byKey := make(map[string]Match, len(stored))
+	for _, m := range stored {
+		byKey[m.Key] = m
+	}
+
+	var changes []Change
+	for _, m := range matches {
+		change := func(kind string, old, new interface{}) {
+			changes = append(changes, Change{EventKey: m.EventKey, MatchKey: m.Key, Kind: kind, Old: old, New: new})
+		}
+
+		old, ok := byKey[m.Key]
+		if !ok {
+			change(ChangeAdded, nil, m)
+			continue
+		} else if old.Manual && !m.Manual {
+			continue
+		}
+
+		if old.RedScore != m.RedScore || old.BlueScore != m.BlueScore {
+			change(ChangeScore, Scores{Red: old.RedScore, Blue: old.BlueScore}, Scores{Red: m.RedScore, Blue: m.BlueScore})
+		}
+
+		if !sameTime(old.PredictedTime, m.PredictedTime) {
+			change(ChangeSchedule, old.PredictedTime, m.PredictedTime)
+		}
+
+		if old.YoutubeURL != m.YoutubeURL {
+			change(ChangeVideo, old.YoutubeURL, m.YoutubeURL)
+		}
+
+		if !sameTeams(old.RedAlliance, m.RedAlliance) || !sameTeams(old.BlueAlliance, m.BlueAlliance) {
+			change(ChangeAlliances, Alliances{Red: old.RedAlliance, Blue: old.BlueAlliance}, Alliances{Red: m.RedAlliance, Blue: m.BlueAlliance})
+		}
+	}
+
+	return changes
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// sameTeams is whether two alliances have the same teams, in any order, since
+// stored alliances aren't ordered.
+func sameTeams(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	sortedA := append([]string(nil), a...)
+	sortedB := append([]string(nil), b...)
+	sort.Strings(sortedA)
+	sort.Strings(sortedB)
+
+	for i := range sortedA {
+		if sortedA[i] != sortedB[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,101 @@
+package match
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newMatch(key string, predicted time.Time, red, blue []string) Match {
+	return Match{
+		BasicMatch: BasicMatch{
+			Key:           key,
+			EventKey:      "2018orwil",
+			PredictedTime: &predicted,
+		},
+		RedScore:     -1,
+		BlueScore:    -1,
+		RedAlliance:  red,
+		BlueAlliance: blue,
+	}
+}
+
+func TestDiff(t *testing.T) {
+	start := time.Date(2018, 3, 2, 9, 0, 0, 0, time.UTC)
+	red, blue := []string{"frc2733", "frc1540", "frc4488"}, []string{"frc1425", "frc360", "frc1318"}
+
+	stored := []Match{
+		newMatch("2018orwil_qm1", start, red, blue),
+		newMatch("2018orwil_qm2", start.Add(time.Minute*7), blue, red),
+	}
+
+	// the same matches don't change anything, even with reordered alliances or
+	// times in another location
+	same := []Match{
+		newMatch("2018orwil_qm1", start.In(time.FixedZone("PST", -8*60*60)), []string{"frc4488", "frc2733", "frc1540"}, blue),
+		newMatch("2018orwil_qm2", start.Add(time.Minute*7), blue, red),
+	}
+	assert.Empty(t, Diff(stored, same))
+
+	qm1 := newMatch("2018orwil_qm1", start, red, blue)
+	qm1.RedScore, qm1.BlueScore = 120, 98
+	qm1.YoutubeURL = "https://www.youtube.com/watch?v=dTjzn4HCP-o"
+
+	shifted := start.Add(time.Minute * 10)
+	qm2 := newMatch("2018orwil_qm2", shifted, blue, []string{"frc2733", "frc1540", "frc2990"})
+
+	qm3 := newMatch("2018orwil_qm3", start.Add(time.Minute*14), red, blue)
+
+	changes := Diff(stored, []Match{qm1, qm2, qm3})
+	predicted := start.Add(time.Minute * 7)
+	assert.Equal(t, []Change{
+		{EventKey: "2018orwil", MatchKey: "2018orwil_qm1", Kind: ChangeScore, Old: Scores{Red: -1, Blue: -1}, New: Scores{Red: 120, Blue: 98}},
+		{EventKey: "2018orwil", MatchKey: "2018orwil_qm1", Kind: ChangeVideo, Old: "", New: "https://www.youtube.com/watch?v=dTjzn4HCP-o"},
+		{EventKey: "2018orwil", MatchKey: "2018orwil_qm2", Kind: ChangeSchedule, Old: &predicted, New: &shifted},
+		{
+			EventKey: "2018orwil", MatchKey: "2018orwil_qm2", Kind: ChangeAlliances,
+			Old: Alliances{Red: blue, Blue: red},
+			New: Alliances{Red: blue, Blue: []string{"frc2733", "frc1540", "frc2990"}},
+		},
+		{EventKey: "2018orwil", MatchKey: "2018orwil_qm3", Kind: ChangeAdded, New: qm3},
+	}, changes)
+}
+
+func TestDiffManual(t *testing.T) {
+	start := time.Date(2018, 3, 2, 9, 0, 0, 0, time.UTC)
+	red, blue := []string{"frc2733", "frc1540", "frc4488"}, []string{"frc1425", "frc360", "frc1318"}
+
+	manual := newMatch("2018orwil_qm1", start, red, blue)
+	manual.Manual = true
+
+	// tba data doesn't overwrite manual matches
+	polled := newMatch("2018orwil_qm1", start, blue, red)
+	polled.RedScore = 120
+	assert.Empty(t, Diff([]Match{manual}, []Match{polled}))
+
+	// but other manual matches do
+	polled.Manual = true
+	changes := Diff([]Match{manual}, []Match{polled})
+	if assert.Len(t, changes, 2) {
+		assert.Equal(t, ChangeScore, changes[0].Kind)
+		assert.Equal(t, ChangeAlliances, changes[1].Kind)
+	}
+}
+
+func TestDiffNoPredictedTime(t *testing.T) {
+	start := time.Date(2018, 3, 2, 9, 0, 0, 0, time.UTC)
+
+	m := newMatch("2018orwil_qm1", start, nil, nil)
+	unscheduled := m
+	unscheduled.PredictedTime = nil
+
+	changes := Diff([]Match{unscheduled}, []Match{m})
+	if assert.Len(t, changes, 1) {
+		assert.Equal(t, ChangeSchedule, changes[0].Kind)
+		assert.Equal(t, (*time.Time)(nil), changes[0].Old)
+		assert.Equal(t, &start, changes[0].New)
+	}
+
+	assert.Empty(t, Diff([]Match{unscheduled}, []Match{unscheduled}))
+}
@@ -1,6 +1,7 @@
 package match
 
 import (
+	"context"
 	"time"
 
 	"github.com/Pigmice2733/scouting-backend/internal/store/alliance"
@@ -13,6 +14,9 @@ type BasicMatch struct {
 	YoutubeURL    string     `json:"youtubeURL"`
 	PredictedTime *time.Time `json:"predictedTime,omitempty"`
 	ActualTime    *time.Time `json:"actualTime,omitempty"`
+	// Manual is whether the match was entered by hand rather than polled from
+	// TBA. Manual matches aren't overwritten by TBA data.
+	Manual bool `json:"manual"`
 }
 
 // Match holds basic match information and alliance info for the match.
@@ -25,9 +29,22 @@ type Match struct {
 	BlueAlliance []string `json:"blueAlliance"`
 }
 
+// Changes is how many rows were changed by upserting matches.
+type Changes struct {
+	// Matches is how many matches were added or changed.
+	Matches int `json:"matches"`
+	// Alliances is how many teams were added to alliances or moved between them.
+	Alliances int `json:"alliances"`
+	// Removed is how many teams were removed from alliances.
+	Removed int `json:"removed"`
+}
+
 // Service is a store for matches.
 type Service interface {
-	GetBasicMatches(eventKey string) ([]BasicMatch, error)
-	Get(eventKey, matchKey string, as alliance.Service) (m Match, err error)
-	MassUpsert([]Match, alliance.Service) error
+	GetBasicMatches(ctx context.Context, eventKey string) ([]BasicMatch, error)
+	Get(ctx context.Context, eventKey, matchKey string, as alliance.Service) (m Match, err error)
+	MassUpsert(ctx context.Context, matches []Match) (Changes, error)
+	SetManual(ctx context.Context, eventKey string, manual bool) error
+	SetManualByKey(ctx context.Context, matchKeys []string, manual bool) error
+	GetChanges(ctx context.Context, eventKey string, since int64) ([]Change, error)
 }
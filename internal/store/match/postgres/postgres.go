@@ -1,28 +1,33 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
+	"sort"
 
 	"github.com/Pigmice2733/scouting-backend/internal/store"
 	"github.com/Pigmice2733/scouting-backend/internal/store/alliance"
 	"github.com/Pigmice2733/scouting-backend/internal/store/match"
+	"github.com/Pigmice2733/scouting-backend/internal/store/postgres/txdb"
+	"github.com/lib/pq"
 )
 
 // Service is used for getting information about a match from a postgres database.
 type Service struct {
-	db *sql.DB
+	db txdb.DB
 }
 
 // New creates a new match service.
-func New(db *sql.DB) match.Service {
+func New(db txdb.DB) match.Service {
 	return &Service{db: db}
 }
 
 // GetBasicMatches fetches basic information about a match from the postgres database.
-func (s *Service) GetBasicMatches(eventKey string) ([]match.BasicMatch, error) {
+func (s *Service) GetBasicMatches(ctx context.Context, eventKey string) ([]match.BasicMatch, error) {
 	var bMatches []match.BasicMatch
 
-	rows, err := s.db.Query("SELECT key, predictedTime, actualTime, youtubeURL FROM matches WHERE eventKey = $1", eventKey)
+	rows, err := s.db.QueryContext(ctx, "SELECT key, predictedTime, actualTime, youtubeURL, manual FROM matches WHERE eventKey = $1", eventKey)
 	if err != nil {
 		return bMatches, err
 	}
@@ -30,7 +35,7 @@ func (s *Service) GetBasicMatches(eventKey string) ([]match.BasicMatch, error) {
 
 	for rows.Next() {
 		var bMatch match.BasicMatch
-		if err := rows.Scan(&bMatch.Key, &bMatch.PredictedTime, &bMatch.ActualTime, &bMatch.YoutubeURL); err != nil {
+		if err := rows.Scan(&bMatch.Key, &bMatch.PredictedTime, &bMatch.ActualTime, &bMatch.YoutubeURL, &bMatch.Manual); err != nil {
 			return nil, err
 		}
 
@@ -42,12 +47,12 @@ func (s *Service) GetBasicMatches(eventKey string) ([]match.BasicMatch, error) {
 }
 
 // Get gets a full match from the postgres database.
-func (s *Service) Get(eventKey, matchKey string, as alliance.Service) (m match.Match, err error) {
+func (s *Service) Get(ctx context.Context, eventKey, matchKey string, as alliance.Service) (m match.Match, err error) {
 	m.Key = matchKey
 	m.EventKey = eventKey
 
-	err = s.db.QueryRow("SELECT predictedTime, actualTime, redScore, blueScore, youtubeURL FROM matches WHERE eventKey = $1 AND key = $2", eventKey, matchKey).Scan(
-		&m.PredictedTime, &m.ActualTime, &m.RedScore, &m.BlueScore, &m.YoutubeURL)
+	err = s.db.QueryRowContext(ctx, "SELECT predictedTime, actualTime, redScore, blueScore, youtubeURL, manual FROM matches WHERE eventKey = $1 AND key = $2", eventKey, matchKey).Scan(
+		&m.PredictedTime, &m.ActualTime, &m.RedScore, &m.BlueScore, &m.YoutubeURL, &m.Manual)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return m, store.ErrNoResults
@@ -55,46 +60,356 @@ func (s *Service) Get(eventKey, matchKey string, as alliance.Service) (m match.M
 		return m, err
 	}
 
-	m.RedAlliance, err = as.Get(matchKey, false)
+	m.RedAlliance, err = as.Get(ctx, matchKey, false)
 	if err != nil {
 		return m, err
 	}
 
-	m.BlueAlliance, err = as.Get(matchKey, true)
+	m.BlueAlliance, err = as.Get(ctx, matchKey, true)
 
 	return m, err
 }
 
-// MassUpsert upserts multiple events in the postgres database.
-func (s *Service) MassUpsert(matches []match.Match, as alliance.Service) error {
-	stmt, err := s.db.Prepare(`
-		INSERT INTO matches (key, eventKey, predictedTime, actualTime, redScore, blueScore, youtubeURL)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+// MassUpsert upserts multiple matches and their alliances in the postgres
+// database in a single transaction. Teams no longer in a match's alliances are
+// removed from it. Manual matches, and their alliances, are only updated by
+// other manual matches. The changes the matches make are recorded in the same
+// transaction, so the IDs of the changes to an event are committed in order.
+func (s *Service) MassUpsert(ctx context.Context, matches []match.Match) (match.Changes, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return match.Changes{}, err
+	}
+
+	matches = dedupe(matches)
+
+	byEvent := make(map[string][]match.Match)
+	eventKeys := make([]string, 0, len(matches))
+	for _, m := range matches {
+		if _, ok := byEvent[m.EventKey]; !ok {
+			eventKeys = append(eventKeys, m.EventKey)
+		}
+		byEvent[m.EventKey] = append(byEvent[m.EventKey], m)
+	}
+
+	if err := lockEvents(ctx, tx, eventKeys); err != nil {
+		tx.Rollback()
+		return match.Changes{}, err
+	}
+
+	var diff []match.Change
+	for _, eventKey := range eventKeys {
+		stored, err := getMatches(ctx, tx, eventKey)
+		if err != nil {
+			tx.Rollback()
+			return match.Changes{}, err
+		}
+		diff = append(diff, match.Diff(stored, byEvent[eventKey])...)
+	}
+
+	changes, err := upsert(ctx, tx, matches)
+	if err != nil {
+		tx.Rollback()
+		return match.Changes{}, err
+	}
+
+	if err := addChanges(ctx, tx, diff); err != nil {
+		tx.Rollback()
+		return match.Changes{}, err
+	}
+
+	return changes, tx.Commit()
+}
+
+// lockEvents takes a lock on each of the events until the end of the
+// transaction, so matches of the same event are never changed concurrently.
+// The locks are taken in order so transactions can't deadlock.
+func lockEvents(ctx context.Context, tx txdb.Tx, eventKeys []string) error {
+	eventKeys = append([]string(nil), eventKeys...)
+	sort.Strings(eventKeys)
+
+	for i, eventKey := range eventKeys {
+		if i > 0 && eventKey == eventKeys[i-1] {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", eventKey); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getMatches gets the full matches of an event, with their alliances.
+func getMatches(ctx context.Context, tx txdb.Tx, eventKey string) ([]match.Match, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT key, predictedTime, actualTime, redScore, blueScore, youtubeURL, manual FROM matches WHERE eventKey = $1", eventKey)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var matches []match.Match
+	indices := make(map[string]int)
+	for rows.Next() {
+		m := match.Match{BasicMatch: match.BasicMatch{EventKey: eventKey}}
+		if err := rows.Scan(&m.Key, &m.PredictedTime, &m.ActualTime, &m.RedScore, &m.BlueScore, &m.YoutubeURL, &m.Manual); err != nil {
+			return nil, err
+		}
+		indices[m.Key] = len(matches)
+		matches = append(matches, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = tx.QueryContext(ctx, `
+		SELECT a.matchKey, a.isBlue, a.number
+			FROM alliances a
+			INNER JOIN matches m ON m.key = a.matchKey
+			WHERE m.eventKey = $1
+		`, eventKey)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var matchKey, team string
+		var isBlue bool
+		if err := rows.Scan(&matchKey, &isBlue, &team); err != nil {
+			return nil, err
+		}
+
+		m := &matches[indices[matchKey]]
+		if isBlue {
+			m.BlueAlliance = append(m.BlueAlliance, team)
+		} else {
+			m.RedAlliance = append(m.RedAlliance, team)
+		}
+	}
+
+	return matches, rows.Err()
+}
+
+func upsert(ctx context.Context, tx txdb.Tx, matches []match.Match) (changes match.Changes, err error) {
+	if _, err := tx.ExecContext(ctx, `
+		CREATE TEMPORARY TABLE match_upserts (LIKE matches) ON COMMIT DROP;
+		CREATE TEMPORARY TABLE alliance_upserts (LIKE alliances, seq INTEGER) ON COMMIT DROP;
+		`); err != nil {
+		return changes, err
+	}
+
+	if err := copyIn(ctx, tx, "match_upserts", []string{"key", "eventkey", "predictedtime", "actualtime", "redscore", "bluescore", "youtubeurl", "manual"}, func(row func(...interface{}) error) error {
+		for _, m := range matches {
+			if err := row(m.Key, m.EventKey, m.PredictedTime, m.ActualTime, m.RedScore, m.BlueScore, m.YoutubeURL, m.Manual); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return changes, err
+	}
+
+	if err := copyIn(ctx, tx, "alliance_upserts", []string{"matchkey", "isblue", "number", "seq"}, func(row func(...interface{}) error) error {
+		seq := 0
+		for _, m := range matches {
+			seen := make(map[string]bool)
+			for _, a := range []struct {
+				isBlue bool
+				teams  []string
+			}{{false, m.RedAlliance}, {true, m.BlueAlliance}} {
+				for _, team := range a.teams {
+					if seen[team] {
+						continue
+					}
+					seen[team] = true
+
+					if err := row(m.Key, a.isBlue, team, seq); err != nil {
+						return err
+					}
+					seq++
+				}
+			}
+		}
+		return nil
+	}); err != nil {
+		return changes, err
+	}
+
+	if changes.Matches, err = exec(ctx, tx, `
+		INSERT INTO matches (key, eventKey, predictedTime, actualTime, redScore, blueScore, youtubeURL, manual)
+		SELECT key, eventKey, predictedTime, actualTime, redScore, blueScore, youtubeURL, manual FROM match_upserts
 		ON CONFLICT (key)
 		DO
 			UPDATE
 				SET
-					eventKey = $2, predictedTime = $3, actualTime = $4,
-					redScore = $5, blueScore = $6, youtubeURL = $7
-		`)
+					eventKey = EXCLUDED.eventKey, predictedTime = EXCLUDED.predictedTime, actualTime = EXCLUDED.actualTime,
+					redScore = EXCLUDED.redScore, blueScore = EXCLUDED.blueScore, youtubeURL = EXCLUDED.youtubeURL, manual = EXCLUDED.manual
+				WHERE (EXCLUDED.manual OR NOT matches.manual)
+					AND (matches.eventKey, matches.predictedTime, matches.actualTime, matches.redScore, matches.blueScore, matches.youtubeURL, matches.manual)
+						IS DISTINCT FROM
+						(EXCLUDED.eventKey, EXCLUDED.predictedTime, EXCLUDED.actualTime, EXCLUDED.redScore, EXCLUDED.blueScore, EXCLUDED.youtubeURL, EXCLUDED.manual)
+		`); err != nil {
+		return changes, err
+	}
+
+	// Only the alliances of matches that were allowed to be updated change, so a
+	// manual match keeps its alliances when TBA data is upserted over it.
+	if changes.Removed, err = exec(ctx, tx, `
+		DELETE FROM alliances
+			USING match_upserts u, matches m
+			WHERE alliances.matchKey = u.key AND m.key = u.key
+				AND (u.manual OR NOT m.manual)
+				AND NOT EXISTS (
+					SELECT 1 FROM alliance_upserts a
+						WHERE a.matchKey = alliances.matchKey AND a.number = alliances.number
+				)
+		`); err != nil {
+		return changes, err
+	}
+
+	if changes.Alliances, err = exec(ctx, tx, `
+		INSERT INTO alliances (matchKey, isBlue, number)
+		SELECT a.matchKey, a.isBlue, a.number
+			FROM alliance_upserts a
+			INNER JOIN match_upserts u ON u.key = a.matchKey
+			INNER JOIN matches m ON m.key = a.matchKey
+			WHERE u.manual OR NOT m.manual
+			ORDER BY a.seq
+		ON CONFLICT (matchKey, number)
+		DO
+			UPDATE
+				SET
+					isBlue = EXCLUDED.isBlue
+				WHERE alliances.isBlue <> EXCLUDED.isBlue
+		`); err != nil {
+		return changes, err
+	}
+
+	// the tables are dropped now rather than on commit, in case this is part
+	// of a larger transaction that upserts matches again
+	_, err = tx.ExecContext(ctx, "DROP TABLE match_upserts, alliance_upserts")
+
+	return changes, err
+}
+
+// copyIn copies the rows written by fill into a table with COPY.
+func copyIn(ctx context.Context, tx txdb.Tx, table string, columns []string, fill func(row func(...interface{}) error) error) error {
+	stmt, err := tx.PrepareContext(ctx, pq.CopyIn(table, columns...))
+	if err != nil {
+		return err
+	}
+
+	err = fill(func(values ...interface{}) error {
+		_, err := stmt.ExecContext(ctx, values...)
+		return err
+	})
+	if err == nil {
+		_, err = stmt.ExecContext(ctx)
+	}
+
+	if cerr := stmt.Close(); err == nil {
+		err = cerr
+	}
+
+	return err
+}
+
+// exec executes a statement and returns how many rows it affected.
+func exec(ctx context.Context, tx txdb.Tx, query string) (int, error) {
+	res, err := tx.ExecContext(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+// dedupe removes matches with the same key, keeping the last one, since an
+// upsert can't change the same row twice.
+func dedupe(matches []match.Match) []match.Match {
+	deduped := make([]match.Match, 0, len(matches))
+	indices := make(map[string]int)
+
+	for _, m := range matches {
+		if i, ok := indices[m.Key]; ok {
+			deduped[i] = m
+			continue
+		}
+		indices[m.Key] = len(deduped)
+		deduped = append(deduped, m)
+	}
+
+	return deduped
+}
+
+// SetManual sets whether all of the matches of an event were entered by hand.
+// Matches that aren't manual are overwritten by TBA data again.
+func (s *Service) SetManual(ctx context.Context, eventKey string, manual bool) error {
+	_, err := s.db.ExecContext(ctx, "UPDATE matches SET manual = $1 WHERE eventKey = $2", manual, eventKey)
+	return err
+}
+
+// SetManualByKey sets whether the matches with the given keys were entered by
+// hand.
+func (s *Service) SetManualByKey(ctx context.Context, matchKeys []string, manual bool) error {
+	_, err := s.db.ExecContext(ctx, "UPDATE matches SET manual = $1 WHERE key = ANY($2)", manual, pq.Array(matchKeys))
+	return err
+}
+
+// addChanges records changes to matches.
+func addChanges(ctx context.Context, tx txdb.Tx, changes []match.Change) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	stmt, err := tx.PrepareContext(ctx, "INSERT INTO matchChanges (eventKey, matchKey, kind, old, new) VALUES ($1, $2, $3, $4, $5)")
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
 
-	for _, match := range matches {
-		if _, err := stmt.Exec(
-			match.Key, match.EventKey, match.PredictedTime, match.ActualTime,
-			match.RedScore, match.BlueScore, match.YoutubeURL); err != nil {
+	for _, c := range changes {
+		before, err := json.Marshal(c.Old)
+		if err != nil {
 			return err
 		}
-		if err := as.Upsert(match.Key, false, match.RedAlliance); err != nil {
+
+		after, err := json.Marshal(c.New)
+		if err != nil {
 			return err
 		}
-		if err := as.Upsert(match.Key, true, match.BlueAlliance); err != nil {
+
+		// the json is passed as a string since pq sends []byte as bytea
+		if _, err := stmt.ExecContext(ctx, c.EventKey, c.MatchKey, c.Kind, string(before), string(after)); err != nil {
 			return err
 		}
 	}
 
 	return nil
 }
+
+// GetChanges gets the changes to the matches of an event recorded after the
+// change with the given ID, oldest first.
+func (s *Service) GetChanges(ctx context.Context, eventKey string, since int64) ([]match.Change, error) {
+	changes := []match.Change{}
+
+	rows, err := s.db.QueryContext(ctx, "SELECT id, matchKey, kind, old, new, created FROM matchChanges WHERE eventKey = $1 AND id > $2 ORDER BY id", eventKey, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var before, after []byte
+		c := match.Change{EventKey: eventKey}
+		if err := rows.Scan(&c.ID, &c.MatchKey, &c.Kind, &before, &after, &c.Created); err != nil {
+			return nil, err
+		}
+		c.Old, c.New = json.RawMessage(before), json.RawMessage(after)
+		changes = append(changes, c)
+	}
+
+	return changes, rows.Err()
+}
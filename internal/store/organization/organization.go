@@ -0,0 +1,21 @@
+package organization
+
+import (
+	"context"
+	"time"
+)
+
+// Organization holds information about an organization, usually an FRC team,
+// that owns users, reports, picklists and a report schema.
+type Organization struct {
+	ID      string    `json:"id"`
+	Name    string    `json:"name"`
+	Created time.Time `json:"created"`
+}
+
+// Service is a store for organizations.
+type Service interface {
+	Create(ctx context.Context, o Organization) error
+	Get(ctx context.Context, id string) (Organization, error)
+	GetAll(ctx context.Context) ([]Organization, error)
+}
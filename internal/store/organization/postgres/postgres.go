@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/Pigmice2733/scouting-backend/internal/store"
+	"github.com/Pigmice2733/scouting-backend/internal/store/organization"
+	"github.com/Pigmice2733/scouting-backend/internal/store/postgres/txdb"
+)
+
+// Service is used for getting information about an organization from a postgres database.
+type Service struct {
+	db txdb.DB
+}
+
+// New creates a new organization service.
+func New(db txdb.DB) organization.Service {
+	return &Service{db: db}
+}
+
+// Create creates a new organization in the postgresql database.
+func (s *Service) Create(ctx context.Context, o organization.Organization) error {
+	_, err := s.db.ExecContext(ctx, "INSERT INTO organizations (id, name) VALUES ($1, $2)", o.ID, o.Name)
+	return err
+}
+
+// Get gets an organization with a given id from the postgresql database.
+func (s *Service) Get(ctx context.Context, id string) (o organization.Organization, err error) {
+	err = s.db.QueryRowContext(ctx, "SELECT id, name, created FROM organizations WHERE id = $1", id).Scan(&o.ID, &o.Name, &o.Created)
+	if err == sql.ErrNoRows {
+		err = store.ErrNoResults
+	}
+	return
+}
+
+// GetAll gets all organizations from the postgresql database.
+func (s *Service) GetAll(ctx context.Context) ([]organization.Organization, error) {
+	orgs := []organization.Organization{}
+
+	rows, err := s.db.QueryContext(ctx, "SELECT id, name, created FROM organizations ORDER BY id")
+	if err != nil {
+		return orgs, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var o organization.Organization
+		if err := rows.Scan(&o.ID, &o.Name, &o.Created); err != nil {
+			return orgs, err
+		}
+		orgs = append(orgs, o)
+	}
+
+	return orgs, rows.Err()
+}
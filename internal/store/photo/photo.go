@@ -1,7 +1,22 @@
 package photo
 
+import (
+	"context"
+	"time"
+)
+
+// Photo holds the URL of a team's photo for a certain year. An empty URL means
+// the team has no photo for that year.
+type Photo struct {
+	Team    string    `json:"team"`
+	Year    int       `json:"year"`
+	URL     string    `json:"url"`
+	Updated time.Time `json:"updated"`
+}
+
 // Service is a store for photos.
 type Service interface {
-	Get(team string) (string, error)
-	Create(team, url string) error
+	Get(ctx context.Context, team string, year int) (Photo, error)
+	Upsert(ctx context.Context, p Photo) error
+	GetStale(ctx context.Context, updatedBefore time.Time) ([]Photo, error)
 }
@@ -1,34 +1,67 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
+	"time"
 
 	"github.com/Pigmice2733/scouting-backend/internal/store"
 
 	"github.com/Pigmice2733/scouting-backend/internal/store/photo"
+	"github.com/Pigmice2733/scouting-backend/internal/store/postgres/txdb"
 )
 
 // Service is used for getting information about a photo from a postgres database.
 type Service struct {
-	db *sql.DB
+	db txdb.DB
 }
 
 // New creates a new photo service.
-func New(db *sql.DB) photo.Service {
+func New(db txdb.DB) photo.Service {
 	return &Service{db: db}
 }
 
-// Get gets the URL for a team photo from the database.
-func (s *Service) Get(team string) (url string, err error) {
-	err = s.db.QueryRow("SELECT url FROM photos WHERE team = $1", team).Scan(&url)
+// Get gets the photo record for a team in a certain year from the database.
+func (s *Service) Get(ctx context.Context, team string, year int) (p photo.Photo, err error) {
+	p.Team, p.Year = team, year
+
+	err = s.db.QueryRowContext(ctx, "SELECT url, updated FROM photos WHERE team = $1 AND year = $2", team, year).Scan(&p.URL, &p.Updated)
 	if err == sql.ErrNoRows {
 		err = store.ErrNoResults
 	}
 	return
 }
 
-// Create creates a photo URL for a team in the database.
-func (s *Service) Create(team, url string) error {
-	_, err := s.db.Exec("INSERT INTO photos VALUES ($1, $2)", team, url)
+// Upsert creates or updates the photo record for a team in a certain year in
+// the database.
+func (s *Service) Upsert(ctx context.Context, p photo.Photo) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO photos (team, year, url, updated)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (team, year)
+		DO
+			UPDATE
+				SET url = $3, updated = $4
+		`, p.Team, p.Year, p.URL, p.Updated)
 	return err
 }
+
+// GetStale gets all photo records that were last updated before a certain time.
+func (s *Service) GetStale(ctx context.Context, updatedBefore time.Time) ([]photo.Photo, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT team, year, url, updated FROM photos WHERE updated < $1", updatedBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var photos []photo.Photo
+	for rows.Next() {
+		var p photo.Photo
+		if err := rows.Scan(&p.Team, &p.Year, &p.URL, &p.Updated); err != nil {
+			return nil, err
+		}
+		photos = append(photos, p)
+	}
+
+	return photos, rows.Err()
+}
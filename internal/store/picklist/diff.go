@@ -0,0 +1,99 @@
+package picklist
+
+// Move is a team that changed position between two versions of a picklist.
+// From and To are indexes into the old and new lists.
+type Move struct {
+	Team string `json:"team"`
+	From int    `json:"from"`
+	To   int    `json:"to"`
+}
+
+// Changes holds the changes between two versions of a picklist.
+type Changes struct {
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+	Moved   []Move   `json:"moved"`
+}
+
+// Diff finds the teams that were added, removed and moved between two lists.
+// A team is only counted as moved if its order relative to the other teams
+// changed, so inserting a team doesn't count every team after it as moved. The
+// moves found are the fewest needed to reorder the old list into the new one.
+func Diff(old, updated []string) Changes {
+	d := Changes{Added: []string{}, Removed: []string{}, Moved: []Move{}}
+
+	oldIndex := indexes(old)
+	newIndex := indexes(updated)
+
+	var oldCommon, newCommon []string
+	for _, team := range old {
+		if _, ok := newIndex[team]; ok {
+			oldCommon = append(oldCommon, team)
+		} else {
+			d.Removed = append(d.Removed, team)
+		}
+	}
+	for _, team := range updated {
+		if _, ok := oldIndex[team]; ok {
+			newCommon = append(newCommon, team)
+		} else {
+			d.Added = append(d.Added, team)
+		}
+	}
+
+	kept := longestCommonSubsequence(oldCommon, newCommon)
+	for _, team := range newCommon {
+		if !kept[team] {
+			d.Moved = append(d.Moved, Move{Team: team, From: oldIndex[team], To: newIndex[team]})
+		}
+	}
+
+	return d
+}
+
+func indexes(list []string) map[string]int {
+	index := make(map[string]int, len(list))
+	for i, team := range list {
+		if _, ok := index[team]; !ok {
+			index[team] = i
+		}
+	}
+	return index
+}
+
+// longestCommonSubsequence returns the teams in the longest subsequence of a
+// that is also a subsequence of b.
+func longestCommonSubsequence(a, b []string) map[string]bool {
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else if lengths[i+1][j] >= lengths[i][j+1] {
+				lengths[i][j] = lengths[i+1][j]
+			} else {
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+
+	kept := make(map[string]bool)
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		if a[i] == b[j] {
+			kept[a[i]] = true
+			i++
+			j++
+		} else if lengths[i+1][j] > lengths[i][j+1] {
+			i++
+		} else {
+			// prefer keeping teams that were higher on the old list in place
+			j++
+		}
+	}
+
+	return kept
+}
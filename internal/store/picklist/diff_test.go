@@ -0,0 +1,62 @@
+package picklist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	testCases := []struct {
+		old, updated []string
+		changes      Changes
+	}{
+		{
+			[]string{},
+			[]string{},
+			Changes{Added: []string{}, Removed: []string{}, Moved: []Move{}},
+		},
+		{
+			[]string{"frc2733", "frc254", "frc118"},
+			[]string{"frc2733", "frc254", "frc118"},
+			Changes{Added: []string{}, Removed: []string{}, Moved: []Move{}},
+		},
+		{
+			[]string{"frc2733", "frc254"},
+			[]string{"frc1678", "frc2733", "frc254"},
+			Changes{Added: []string{"frc1678"}, Removed: []string{}, Moved: []Move{}},
+		},
+		{
+			[]string{"frc2733", "frc254", "frc118"},
+			[]string{"frc2733", "frc118"},
+			Changes{Added: []string{}, Removed: []string{"frc254"}, Moved: []Move{}},
+		},
+		{
+			[]string{"frc2733", "frc254", "frc118", "frc1678"},
+			[]string{"frc2733", "frc1678", "frc254", "frc118"},
+			Changes{Added: []string{}, Removed: []string{}, Moved: []Move{{Team: "frc1678", From: 3, To: 1}}},
+		},
+		{
+			[]string{"frc2733", "frc254", "frc118"},
+			[]string{"frc118", "frc1678", "frc2733"},
+			Changes{
+				Added:   []string{"frc1678"},
+				Removed: []string{"frc254"},
+				Moved:   []Move{{Team: "frc118", From: 2, To: 0}},
+			},
+		},
+		{
+			[]string{"frc1", "frc2", "frc3"},
+			[]string{"frc3", "frc2", "frc1"},
+			Changes{
+				Added:   []string{},
+				Removed: []string{},
+				Moved:   []Move{{Team: "frc3", From: 2, To: 0}, {Team: "frc2", From: 1, To: 1}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.changes, Diff(tc.old, tc.updated))
+	}
+}
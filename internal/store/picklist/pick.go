@@ -0,0 +1,111 @@
+package picklist
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Pick is an entry in a picklist. It is either a team, along with the notes,
+// tags and flags strategists have added to it, or a separator that starts a
+// new group of teams in the list.
+type Pick struct {
+	Team            string   `json:"team,omitempty"`
+	Separator       string   `json:"separator,omitempty"`
+	Tier            int      `json:"tier,omitempty"`
+	Notes           string   `json:"notes,omitempty"`
+	Tags            []string `json:"tags,omitempty"`
+	DoNotPick       bool     `json:"doNotPick,omitempty"`
+	DoNotPickReason string   `json:"doNotPickReason,omitempty"`
+}
+
+// UnmarshalJSON unmarshals a pick from either an object, or a string holding
+// just the team, which is how picks were sent before they could be annotated.
+func (p *Pick) UnmarshalJSON(data []byte) error {
+	var team string
+	if err := json.Unmarshal(data, &team); err == nil {
+		*p = Pick{Team: team}
+		return nil
+	}
+
+	type pick Pick
+	return json.Unmarshal(data, (*pick)(p))
+}
+
+// Valid returns whether a pick is either a team or a separator, and only has
+// annotations that make sense for it.
+func (p Pick) Valid() bool {
+	if p.Separator != "" {
+		return p.Team == "" && p.Tier == 0 && p.Notes == "" && len(p.Tags) == 0 && !p.DoNotPick && p.DoNotPickReason == ""
+	}
+
+	return p.Team != "" && p.Tier >= 0 && (p.DoNotPick || p.DoNotPickReason == "")
+}
+
+// Teams returns the teams in a list of picks in order, leaving out separators.
+func Teams(picks []Pick) []string {
+	teams := []string{}
+	for _, p := range picks {
+		if p.Team != "" {
+			teams = append(teams, p.Team)
+		}
+	}
+	return teams
+}
+
+// PlainTeams returns the teams in a list of picks if every pick in it is a
+// plain team string, which is how clients from before picks could be annotated
+// send picklists. Empty lists aren't plain, since they have no teams to tell.
+func PlainTeams(list []json.RawMessage) ([]string, bool) {
+	if len(list) == 0 {
+		return nil, false
+	}
+
+	teams := make([]string, 0, len(list))
+	for _, data := range list {
+		var team string
+		if !bytes.HasPrefix(bytes.TrimSpace(data), []byte(`"`)) || json.Unmarshal(data, &team) != nil {
+			return nil, false
+		}
+		teams = append(teams, team)
+	}
+
+	return teams, true
+}
+
+// MergeTeams puts teams in order into the places of the teams in a list of
+// picks, keeping the separators where they are and the annotations of teams
+// that were already in the list. Teams past the end of the list are added to
+// it, and places left over are removed. This lets clients that only know about
+// teams reorder a picklist without losing what others added to it.
+func MergeTeams(picks []Pick, teams []string) []Pick {
+	annotated := make(map[string]Pick)
+	for _, p := range picks {
+		if p.Team != "" {
+			annotated[p.Team] = p
+		}
+	}
+
+	pick := func(team string) Pick {
+		if p, ok := annotated[team]; ok {
+			return p
+		}
+		return Pick{Team: team}
+	}
+
+	merged := make([]Pick, 0, len(picks)+len(teams))
+	next := 0
+	for _, p := range picks {
+		if p.Separator != "" {
+			merged = append(merged, p)
+		} else if next < len(teams) {
+			merged = append(merged, pick(teams[next]))
+			next++
+		}
+	}
+
+	for _, team := range teams[next:] {
+		merged = append(merged, pick(team))
+	}
+
+	return merged
+}
@@ -0,0 +1,112 @@
+package picklist
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPickUnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		data  string
+		picks []Pick
+		err   bool
+	}{
+		{`[]`, []Pick{}, false},
+		{`["frc2733", "frc254"]`, []Pick{{Team: "frc2733"}, {Team: "frc254"}}, false},
+		{
+			`[{"team": "frc2733", "tier": 1, "notes": "fast", "tags": ["climber"]}, "frc254", {"separator": "backups"}]`,
+			[]Pick{{Team: "frc2733", Tier: 1, Notes: "fast", Tags: []string{"climber"}}, {Team: "frc254"}, {Separator: "backups"}},
+			false,
+		},
+		{
+			`[{"team": "frc118", "doNotPick": true, "doNotPickReason": "brownouts"}]`,
+			[]Pick{{Team: "frc118", DoNotPick: true, DoNotPickReason: "brownouts"}},
+			false,
+		},
+		{`[2733]`, nil, true},
+	}
+
+	for _, tc := range testCases {
+		var picks []Pick
+		err := json.Unmarshal([]byte(tc.data), &picks)
+		if tc.err {
+			assert.Error(t, err)
+			continue
+		}
+
+		assert.NoError(t, err)
+		assert.Equal(t, tc.picks, picks)
+	}
+}
+
+func TestPickValid(t *testing.T) {
+	assert.True(t, Pick{Team: "frc2733"}.Valid())
+	assert.True(t, Pick{Team: "frc2733", Tier: 2, Tags: []string{"defense"}, Notes: "good driver"}.Valid())
+	assert.True(t, Pick{Team: "frc2733", DoNotPick: true, DoNotPickReason: "tips over"}.Valid())
+	assert.True(t, Pick{Separator: "second round"}.Valid())
+
+	assert.False(t, Pick{}.Valid())
+	assert.False(t, Pick{Team: "frc2733", Separator: "second round"}.Valid())
+	assert.False(t, Pick{Separator: "second round", Notes: "notes"}.Valid())
+	assert.False(t, Pick{Team: "frc2733", Tier: -1}.Valid())
+	assert.False(t, Pick{Team: "frc2733", DoNotPickReason: "tips over"}.Valid())
+}
+
+func TestTeams(t *testing.T) {
+	assert.Equal(t, []string{}, Teams(nil))
+	assert.Equal(t, []string{"frc2733", "frc254"}, Teams([]Pick{{Team: "frc2733"}, {Separator: "backups"}, {Team: "frc254"}}))
+}
+
+func TestPlainTeams(t *testing.T) {
+	testCases := []struct {
+		data  string
+		teams []string
+		plain bool
+	}{
+		{`[]`, nil, false},
+		{`["frc2733", "frc254"]`, []string{"frc2733", "frc254"}, true},
+		{`["frc2733", {"team": "frc254"}]`, nil, false},
+		{`[{"separator": "backups"}]`, nil, false},
+	}
+
+	for _, tc := range testCases {
+		var list []json.RawMessage
+		assert.NoError(t, json.Unmarshal([]byte(tc.data), &list))
+
+		teams, plain := PlainTeams(list)
+		assert.Equal(t, tc.plain, plain, tc.data)
+		assert.Equal(t, tc.teams, teams, tc.data)
+	}
+}
+
+func TestMergeTeams(t *testing.T) {
+	picks := []Pick{
+		{Team: "frc2733", Tier: 1, Tags: []string{"climber"}},
+		{Team: "frc2471", Notes: "fast"},
+		{Separator: "backups"},
+		{Team: "frc118", DoNotPick: true, DoNotPickReason: "brownouts"},
+	}
+
+	// reordering keeps annotations and separators
+	assert.Equal(t, []Pick{
+		{Team: "frc2471", Notes: "fast"},
+		{Team: "frc2733", Tier: 1, Tags: []string{"climber"}},
+		{Separator: "backups"},
+		{Team: "frc118", DoNotPick: true, DoNotPickReason: "brownouts"},
+	}, MergeTeams(picks, []string{"frc2471", "frc2733", "frc118"}))
+
+	// new teams are added plain, and removed teams leave the separators
+	assert.Equal(t, []Pick{
+		{Team: "frc254"},
+		{Team: "frc118", DoNotPick: true, DoNotPickReason: "brownouts"},
+		{Separator: "backups"},
+		{Team: "frc1678"},
+	}, MergeTeams(picks, []string{"frc254", "frc118", "frc1678"}))
+
+	assert.Equal(t, []Pick{
+		{Team: "frc2733", Tier: 1, Tags: []string{"climber"}},
+		{Separator: "backups"},
+	}, MergeTeams(picks, []string{"frc2733"}))
+}
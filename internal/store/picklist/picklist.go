@@ -1,5 +1,10 @@
 package picklist
 
+import (
+	"context"
+	"time"
+)
+
 // BasicPicklist defines a picklist with only it's ID, eventKey, and name.
 type BasicPicklist struct {
 	ID       string `json:"id"`
@@ -7,20 +12,64 @@ type BasicPicklist struct {
 	Name     string `json:"name"`
 }
 
-// Picklist holds all information about a picklist.
+// Picklist holds all information about a picklist. The version starts at 1 and
+// is incremented on every update. Updates can give the version they were based
+// on to be rejected if the picklist changed since, or 0 to skip the check.
 type Picklist struct {
 	BasicPicklist
-	List  []string `json:"list"`
-	Owner string   `json:"owner"`
+	List    []Pick `json:"list"`
+	Owner   string `json:"owner"`
+	Org     string `json:"org"`
+	Version int    `json:"version"`
+}
+
+// Permissions that can be given when sharing a picklist.
+const (
+	Read = "read"
+	Edit = "edit"
+)
+
+// Share gives either a user or everyone with a role read or edit permission
+// on a picklist.
+type Share struct {
+	Username   string `json:"username,omitempty"`
+	Role       string `json:"role,omitempty"`
+	Permission string `json:"permission"`
+}
+
+// SharedPicklist is a basic picklist that was shared with a user, along with
+// its owner and the permission the user has on it.
+type SharedPicklist struct {
+	BasicPicklist
+	Owner      string `json:"owner"`
+	Permission string `json:"permission"`
+}
+
+// Revision is a saved snapshot of a picklist. A revision is saved every time a
+// picklist is created or updated, with the version it was given.
+type Revision struct {
+	Version  int       `json:"version"`
+	EventKey string    `json:"eventKey"`
+	Name     string    `json:"name"`
+	List     []Pick    `json:"list"`
+	Editor   string    `json:"editor"`
+	Created  time.Time `json:"created"`
 }
 
-// Service is a store for picklists.
+// Service is a store for picklists. Picklists belong to an organization, and
+// can only be accessed through it.
 type Service interface {
-	GetBasicPicklists(username string) (bPicklists []BasicPicklist, err error)
-	Get(id string) (p Picklist, err error)
-	Insert(p Picklist) (id string, err error)
-	Update(p Picklist) error
-	Delete(id string) error
-	GetOwner(id string) (username string, err error)
-	GetByEvent(username, eventKey string) (bPicklists []BasicPicklist, err error)
+	GetBasicPicklists(ctx context.Context, org, username string) (bPicklists []BasicPicklist, err error)
+	Get(ctx context.Context, org, id string) (p Picklist, err error)
+	Insert(ctx context.Context, p Picklist) (id string, err error)
+	Update(ctx context.Context, p Picklist, editor string) (version int, err error)
+	Delete(ctx context.Context, org, id string) error
+	GetOwner(ctx context.Context, org, id string) (username string, err error)
+	GetByEvent(ctx context.Context, org, username, eventKey string) (bPicklists []BasicPicklist, err error)
+	GetShares(ctx context.Context, org, id string) (shares []Share, err error)
+	SetShares(ctx context.Context, org, id string, shares []Share) error
+	GetShared(ctx context.Context, org, username string, roles []string) (sPicklists []SharedPicklist, err error)
+	GetPermission(ctx context.Context, org, id, username string, roles []string) (permission string, err error)
+	GetRevisions(ctx context.Context, org, id string) (revisions []Revision, err error)
+	GetRevision(ctx context.Context, org, id string, version int) (r Revision, err error)
 }
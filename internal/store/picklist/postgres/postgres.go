@@ -1,27 +1,32 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
+
+	"github.com/lib/pq"
 
 	"github.com/Pigmice2733/scouting-backend/internal/store"
 
 	"github.com/Pigmice2733/scouting-backend/internal/store/picklist"
+	"github.com/Pigmice2733/scouting-backend/internal/store/postgres/txdb"
 )
 
 // Service is used for getting information about a picklist from a postgres database.
 type Service struct {
-	db *sql.DB
+	db txdb.DB
 }
 
 // New creates a new picklist service.
-func New(db *sql.DB) picklist.Service {
+func New(db txdb.DB) picklist.Service {
 	return &Service{db: db}
 }
 
 // Get retrieves a picklist from the postgresql database given an id.
-func (s *Service) Get(id string) (p picklist.Picklist, err error) {
-	err = s.db.QueryRow("SELECT id, eventKey, name, owner FROM picklists WHERE id = $1", id).Scan(
-		&p.ID, &p.EventKey, &p.Name, &p.Owner)
+func (s *Service) Get(ctx context.Context, org, id string) (p picklist.Picklist, err error) {
+	err = s.db.QueryRowContext(ctx, "SELECT id, eventKey, name, owner, org, version FROM picklists WHERE id = $1 AND org = $2", id, org).Scan(
+		&p.ID, &p.EventKey, &p.Name, &p.Owner, &p.Org, &p.Version)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			err = store.ErrNoResults
@@ -29,27 +34,32 @@ func (s *Service) Get(id string) (p picklist.Picklist, err error) {
 		return p, err
 	}
 
-	rows, err := s.db.Query("SELECT team FROM picks WHERE picklistId = $1", id)
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT COALESCE(team, ''), COALESCE(separator, ''), tier, notes, tags, doNotPick, doNotPickReason
+			FROM picks
+			WHERE picklistId = $1
+			ORDER BY position
+		`, id)
 	if err != nil {
 		return p, err
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		var team string
-		if err := rows.Scan(&team); err != nil {
+		var pick picklist.Pick
+		if err := rows.Scan(&pick.Team, &pick.Separator, &pick.Tier, &pick.Notes, pq.Array(&pick.Tags), &pick.DoNotPick, &pick.DoNotPickReason); err != nil {
 			return p, err
 		}
 
-		p.List = append(p.List, team)
+		p.List = append(p.List, pick)
 	}
 
 	return p, rows.Err()
 }
 
 // GetBasicPicklists gets all basic picklists that belong to a certain user from the postgresql database.
-func (s *Service) GetBasicPicklists(username string) (bPicklists []picklist.BasicPicklist, err error) {
-	rows, err := s.db.Query("SELECT id, eventKey, name FROM picklists WHERE owner = $1", username)
+func (s *Service) GetBasicPicklists(ctx context.Context, org, username string) (bPicklists []picklist.BasicPicklist, err error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT id, eventKey, name FROM picklists WHERE org = $1 AND owner = $2", org, username)
 	if err != nil {
 		return nil, err
 	}
@@ -68,100 +78,139 @@ func (s *Service) GetBasicPicklists(username string) (bPicklists []picklist.Basi
 }
 
 // Insert inserts a picklist into the postgresql database.
-func (s *Service) Insert(p picklist.Picklist) (string, error) {
-	tx, err := s.db.Begin()
+func (s *Service) Insert(ctx context.Context, p picklist.Picklist) (string, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return p.ID, err
 	}
 
-	err = tx.QueryRow(`
+	err = tx.QueryRowContext(ctx, `
 		INSERT 
 			INTO
-				picklists(eventKey, name, owner)
-			VALUES ($1, $2, $3)
-			RETURNING id
-		`, p.EventKey, p.Name, p.Owner).Scan(&p.ID)
+				picklists(eventKey, name, owner, org)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id, version
+		`, p.EventKey, p.Name, p.Owner, p.Org).Scan(&p.ID, &p.Version)
 	if err != nil {
 		tx.Rollback()
 		return p.ID, err
 	}
 
-	stmt, err := tx.Prepare("INSERT INTO picks(picklistId, team) VALUES ($1, $2)")
-	if err != nil {
+	if err := insertPicks(ctx, tx, p, p.Owner); err != nil {
 		tx.Rollback()
 		return p.ID, err
 	}
-	defer stmt.Close()
-
-	for _, pick := range p.List {
-		if _, err := stmt.Exec(p.ID, pick); err != nil {
-			return p.ID, err
-		}
-	}
 
 	return p.ID, tx.Commit()
 }
 
 // GetOwner retrieves the owner of a picklist in the postgresql database.
-func (s *Service) GetOwner(id string) (username string, err error) {
-	err = s.db.QueryRow("SELECT owner FROM picklists WHERE id = $1", id).Scan(&username)
+func (s *Service) GetOwner(ctx context.Context, org, id string) (username string, err error) {
+	err = s.db.QueryRowContext(ctx, "SELECT owner FROM picklists WHERE id = $1 AND org = $2", id, org).Scan(&username)
 	if err == sql.ErrNoRows {
 		err = store.ErrNoResults
 	}
 	return
 }
 
-// Update updates a picklist in the postgresql database.
-func (s *Service) Update(p picklist.Picklist) error {
-	tx, err := s.db.Begin()
+// Update updates a picklist in the postgresql database, returning its new
+// version, and saves the update as a revision. If the picklist was updated
+// since p.Version store.ErrConflict is returned. A p.Version of 0 updates the
+// picklist whatever its version is.
+func (s *Service) Update(ctx context.Context, p picklist.Picklist, editor string) (int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	_, err = tx.Exec(`
+	err = tx.QueryRowContext(ctx, `
 		UPDATE picklists
-			SET eventKey = $1, name = $2
-			WHERE OWNER = $3 AND id = $4
-		`, p.EventKey, p.Name, p.Owner, p.ID)
-	if err != nil {
+			SET eventKey = $1, name = $2, version = version + 1
+			WHERE id = $3 AND org = $4 AND ($5 = 0 OR version = $5)
+			RETURNING version
+		`, p.EventKey, p.Name, p.ID, p.Org, p.Version).Scan(&p.Version)
+	if err == sql.ErrNoRows {
 		tx.Rollback()
-		return err
+
+		if _, err := s.GetOwner(ctx, p.Org, p.ID); err != nil {
+			return 0, err
+		}
+		return 0, store.ErrConflict
+	} else if err != nil {
+		tx.Rollback()
+		return 0, err
 	}
 
-	if _, err = tx.Exec("DELETE FROM picks WHERE picklistId = $1", p.ID); err != nil {
+	if _, err = tx.ExecContext(ctx, "DELETE FROM picks WHERE picklistId = $1", p.ID); err != nil {
 		tx.Rollback()
-		return err
+		return 0, err
 	}
 
-	stmt, err := tx.Prepare("INSERT INTO picks(picklistId, team) VALUES ($1, $2)")
-	if err != nil {
+	if err := insertPicks(ctx, tx, p, editor); err != nil {
 		tx.Rollback()
+		return 0, err
+	}
+
+	return p.Version, tx.Commit()
+}
+
+// insertPicks inserts the picks of a picklist in order, and saves the picklist
+// as a revision with its current version.
+func insertPicks(ctx context.Context, tx txdb.Tx, p picklist.Picklist, editor string) error {
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT
+			INTO
+				picks(picklistId, position, team, separator, tier, notes, tags, doNotPick, doNotPickReason)
+			VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), $5, $6, $7, $8, $9)
+		`)
+	if err != nil {
 		return err
 	}
 	defer stmt.Close()
 
-	for _, pick := range p.List {
-		if _, err := stmt.Exec(p.ID, pick); err != nil {
+	for i, pick := range p.List {
+		tags := pick.Tags
+		if tags == nil {
+			tags = []string{}
+		}
+
+		if _, err := stmt.ExecContext(ctx, p.ID, i, pick.Team, pick.Separator, pick.Tier, pick.Notes, pq.Array(tags), pick.DoNotPick, pick.DoNotPickReason); err != nil {
 			return err
 		}
 	}
 
-	return tx.Commit()
+	list := p.List
+	if list == nil {
+		list = []picklist.Pick{}
+	}
+
+	listJSON, err := json.Marshal(list)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT
+			INTO
+				picklistRevisions(picklistId, version, eventKey, name, list, editor)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`, p.ID, p.Version, p.EventKey, p.Name, string(listJSON), editor)
+	return err
 }
 
 // Delete deletes a picklist from the postgresql database.
-func (s *Service) Delete(id string) error {
-	tx, err := s.db.Begin()
+func (s *Service) Delete(ctx context.Context, org, id string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
 
-	if _, err := tx.Exec("DELETE FROM picks WHERE picklistId = $1", id); err != nil {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM picks WHERE picklistId = (SELECT id FROM picklists WHERE id = $1 AND org = $2)", id, org); err != nil {
 		tx.Rollback()
 		return err
 	}
 
-	if _, err := tx.Exec("DELETE FROM picklists WHERE id = $1", id); err != nil {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM picklists WHERE id = $1 AND org = $2", id, org); err != nil {
 		tx.Rollback()
 		return err
 	}
@@ -170,8 +219,8 @@ func (s *Service) Delete(id string) error {
 }
 
 // GetByEvent gets basic picklists from the postgresql database by username and eventKey.
-func (s *Service) GetByEvent(username, eventKey string) (bPicklists []picklist.BasicPicklist, err error) {
-	rows, err := s.db.Query("SELECT id, eventKey, name FROM picklists WHERE owner = $1 AND eventKey = $2", username, eventKey)
+func (s *Service) GetByEvent(ctx context.Context, org, username, eventKey string) (bPicklists []picklist.BasicPicklist, err error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT id, eventKey, name FROM picklists WHERE org = $1 AND owner = $2 AND eventKey = $3", org, username, eventKey)
 	if err != nil {
 		return nil, err
 	}
@@ -188,3 +237,165 @@ func (s *Service) GetByEvent(username, eventKey string) (bPicklists []picklist.B
 
 	return bPicklists, rows.Err()
 }
+
+// GetShares gets who a picklist is shared with from the postgresql database.
+func (s *Service) GetShares(ctx context.Context, org, id string) ([]picklist.Share, error) {
+	shares := []picklist.Share{}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT COALESCE(s.username, ''), COALESCE(s.role, ''), s.permission
+			FROM picklistShares s
+			INNER JOIN picklists p ON p.id = s.picklistId
+			WHERE s.picklistId = $1 AND p.org = $2
+		`, id, org)
+	if err != nil {
+		return shares, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var share picklist.Share
+		if err := rows.Scan(&share.Username, &share.Role, &share.Permission); err != nil {
+			return shares, err
+		}
+		shares = append(shares, share)
+	}
+
+	return shares, rows.Err()
+}
+
+// SetShares replaces who a picklist is shared with in the postgresql database.
+func (s *Service) SetShares(ctx context.Context, org, id string, shares []picklist.Share) error {
+	if _, err := s.GetOwner(ctx, org, id); err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM picklistShares WHERE picklistId = $1", id); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	stmt, err := tx.PrepareContext(ctx, "INSERT INTO picklistShares(picklistId, username, role, permission) VALUES ($1, NULLIF($2, ''), NULLIF($3, ''), $4)")
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+
+	for _, share := range shares {
+		if _, err := stmt.ExecContext(ctx, id, share.Username, share.Role, share.Permission); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetShared gets the basic picklists that are shared with a user, either
+// directly or through one of their roles, from the postgresql database. If a
+// picklist is shared with the user more than once they get the highest
+// permission.
+func (s *Service) GetShared(ctx context.Context, org, username string, roles []string) ([]picklist.SharedPicklist, error) {
+	sPicklists := []picklist.SharedPicklist{}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT DISTINCT ON (p.id) p.id, p.eventKey, p.name, p.owner, s.permission
+			FROM picklists p
+			INNER JOIN picklistShares s ON s.picklistId = p.id
+			WHERE p.org = $1 AND p.owner <> $2 AND (s.username = $2 OR s.role = ANY($3))
+			ORDER BY p.id, s.permission = 'edit' DESC
+		`, org, username, pq.Array(roles))
+	if err != nil {
+		return sPicklists, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sp picklist.SharedPicklist
+		if err := rows.Scan(&sp.ID, &sp.EventKey, &sp.Name, &sp.Owner, &sp.Permission); err != nil {
+			return sPicklists, err
+		}
+		sPicklists = append(sPicklists, sp)
+	}
+
+	return sPicklists, rows.Err()
+}
+
+// GetPermission gets the highest permission a user has on a picklist shared
+// with them, either directly or through one of their roles, from the postgresql
+// database. If the picklist isn't shared with the user store.ErrNoResults is
+// returned.
+func (s *Service) GetPermission(ctx context.Context, org, id, username string, roles []string) (permission string, err error) {
+	err = s.db.QueryRowContext(ctx, `
+		SELECT s.permission
+			FROM picklistShares s
+			INNER JOIN picklists p ON p.id = s.picklistId
+			WHERE s.picklistId = $1 AND p.org = $2 AND (s.username = $3 OR s.role = ANY($4))
+			ORDER BY s.permission = 'edit' DESC
+			LIMIT 1
+		`, id, org, username, pq.Array(roles)).Scan(&permission)
+	if err == sql.ErrNoRows {
+		err = store.ErrNoResults
+	}
+	return
+}
+
+// GetRevisions gets all saved revisions of a picklist from the postgresql
+// database, newest first.
+func (s *Service) GetRevisions(ctx context.Context, org, id string) ([]picklist.Revision, error) {
+	revisions := []picklist.Revision{}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT r.version, r.eventKey, COALESCE(r.name, ''), r.list, COALESCE(r.editor, ''), r.created
+			FROM picklistRevisions r
+			INNER JOIN picklists p ON p.id = r.picklistId
+			WHERE r.picklistId = $1 AND p.org = $2
+			ORDER BY r.version DESC
+		`, id, org)
+	if err != nil {
+		return revisions, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r picklist.Revision
+		var listJSON string
+		if err := rows.Scan(&r.Version, &r.EventKey, &r.Name, &listJSON, &r.Editor, &r.Created); err != nil {
+			return revisions, err
+		}
+
+		if err := json.Unmarshal([]byte(listJSON), &r.List); err != nil {
+			return revisions, err
+		}
+
+		revisions = append(revisions, r)
+	}
+
+	return revisions, rows.Err()
+}
+
+// GetRevision gets a revision of a picklist with a given version from the
+// postgresql database.
+func (s *Service) GetRevision(ctx context.Context, org, id string, version int) (r picklist.Revision, err error) {
+	var listJSON string
+	err = s.db.QueryRowContext(ctx, `
+		SELECT r.version, r.eventKey, COALESCE(r.name, ''), r.list, COALESCE(r.editor, ''), r.created
+			FROM picklistRevisions r
+			INNER JOIN picklists p ON p.id = r.picklistId
+			WHERE r.picklistId = $1 AND p.org = $2 AND r.version = $3
+		`, id, org, version).Scan(&r.Version, &r.EventKey, &r.Name, &listJSON, &r.Editor, &r.Created)
+	if err == sql.ErrNoRows {
+		return r, store.ErrNoResults
+	} else if err != nil {
+		return r, err
+	}
+
+	err = json.Unmarshal([]byte(listJSON), &r.List)
+	return r, err
+}
@@ -0,0 +1,9 @@
+DROP TABLE photos;
+
+CREATE TABLE IF NOT EXISTS photos (
+	team TEXT NOT NULL,
+	year INTEGER NOT NULL,
+	url TEXT NOT NULL,
+	updated TIMESTAMPTZ NOT NULL DEFAULT now(),
+	UNIQUE(team, year)
+)
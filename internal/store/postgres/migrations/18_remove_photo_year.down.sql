@@ -0,0 +1,7 @@
+DROP TABLE photos;
+
+CREATE TABLE IF NOT EXISTS photos (
+	team TEXT NOT NULL,
+	url TEXT NOT NULL,
+	UNIQUE(team, url)
+)
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS sessions (
+	id uuid UNIQUE NOT NULL DEFAULT uuid_generate_v4(),
+	username TEXT NOT NULL,
+	hashedRefreshToken TEXT NOT NULL,
+	created TIMESTAMPTZ NOT NULL DEFAULT now(),
+	expires TIMESTAMPTZ NOT NULL,
+	revoked BOOLEAN NOT NULL DEFAULT false,
+	FOREIGN KEY(username) REFERENCES users(username) ON UPDATE CASCADE ON DELETE CASCADE
+)
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS signingKeys (
+	kid TEXT PRIMARY KEY,
+	algorithm TEXT NOT NULL,
+	privateKey BYTEA NOT NULL,
+	created TIMESTAMPTZ NOT NULL DEFAULT now(),
+	retired TIMESTAMPTZ
+)
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE signingKeys
\ No newline at end of file
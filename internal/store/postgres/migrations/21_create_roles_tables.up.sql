@@ -0,0 +1,46 @@
+CREATE TABLE IF NOT EXISTS roles (
+	name TEXT PRIMARY KEY,
+	description TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS rolePermissions (
+	role TEXT NOT NULL,
+	permission TEXT NOT NULL,
+	UNIQUE(role, permission),
+	FOREIGN KEY(role) REFERENCES roles(name) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS userRoles (
+	username TEXT NOT NULL,
+	role TEXT NOT NULL,
+	UNIQUE(username, role),
+	FOREIGN KEY(username) REFERENCES users(username) ON UPDATE CASCADE ON DELETE CASCADE,
+	FOREIGN KEY(role) REFERENCES roles(name) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+INSERT INTO roles (name, description) VALUES
+	('scout', 'Submits reports and keeps their own picklists'),
+	('analyst', 'Scouts, and can read every picklist'),
+	('drive-coach', 'Keeps their own picklists and can read every picklist'),
+	('admin', 'Manages users, roles and the report schema');
+
+INSERT INTO rolePermissions (role, permission) VALUES
+	('scout', 'report:write'),
+	('scout', 'picklist:write'),
+	('analyst', 'report:write'),
+	('analyst', 'picklist:write'),
+	('analyst', 'picklist:read-all'),
+	('drive-coach', 'picklist:write'),
+	('drive-coach', 'picklist:read-all'),
+	('admin', 'report:write'),
+	('admin', 'picklist:write'),
+	('admin', 'picklist:read-all'),
+	('admin', 'user:read-all'),
+	('admin', 'user:edit-all'),
+	('admin', 'role:edit'),
+	('admin', 'schema:edit');
+
+INSERT INTO userRoles (username, role) SELECT username, 'scout' FROM users;
+INSERT INTO userRoles (username, role) SELECT username, 'admin' FROM users WHERE isAdmin;
+
+ALTER TABLE users DROP COLUMN isAdmin;
\ No newline at end of file
@@ -0,0 +1,7 @@
+ALTER TABLE users ADD COLUMN isAdmin BOOLEAN NOT NULL DEFAULT false;
+
+UPDATE users SET isAdmin = true WHERE username IN (SELECT username FROM userRoles WHERE role = 'admin');
+
+DROP TABLE userRoles;
+DROP TABLE rolePermissions;
+DROP TABLE roles;
\ No newline at end of file
@@ -0,0 +1,50 @@
+CREATE TABLE IF NOT EXISTS organizations (
+	id TEXT PRIMARY KEY,
+	name TEXT NOT NULL,
+	created TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+INSERT INTO organizations (id, name) VALUES ('default', 'Default');
+
+ALTER TABLE users ADD COLUMN org TEXT NOT NULL DEFAULT 'default' REFERENCES organizations(id) ON UPDATE CASCADE;
+ALTER TABLE users ALTER COLUMN org DROP DEFAULT;
+
+ALTER TABLE reports ADD COLUMN org TEXT NOT NULL DEFAULT 'default' REFERENCES organizations(id) ON UPDATE CASCADE ON DELETE CASCADE;
+ALTER TABLE reports ALTER COLUMN org DROP DEFAULT;
+ALTER TABLE reports DROP CONSTRAINT reports_eventkey_matchkey_team_key;
+ALTER TABLE reports ADD UNIQUE(org, eventKey, matchKey, team);
+
+ALTER TABLE picklists ADD COLUMN org TEXT NOT NULL DEFAULT 'default' REFERENCES organizations(id) ON UPDATE CASCADE ON DELETE CASCADE;
+ALTER TABLE picklists ALTER COLUMN org DROP DEFAULT;
+
+CREATE TABLE IF NOT EXISTS schemas (
+	org TEXT PRIMARY KEY,
+	schema TEXT NOT NULL,
+	FOREIGN KEY(org) REFERENCES organizations(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS sharingAgreements (
+	id uuid UNIQUE NOT NULL DEFAULT uuid_generate_v4(),
+	eventKey TEXT NOT NULL,
+	proposer TEXT NOT NULL,
+	partner TEXT NOT NULL,
+	accepted BOOLEAN NOT NULL DEFAULT false,
+	created TIMESTAMPTZ NOT NULL DEFAULT now(),
+	UNIQUE(eventKey, proposer, partner),
+	CHECK(proposer <> partner),
+	FOREIGN KEY(eventKey) REFERENCES events(key),
+	FOREIGN KEY(proposer) REFERENCES organizations(id) ON UPDATE CASCADE ON DELETE CASCADE,
+	FOREIGN KEY(partner) REFERENCES organizations(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+INSERT INTO roles (name, description) VALUES ('superadmin', 'Manages organizations and roles for the whole deployment');
+
+INSERT INTO rolePermissions (role, permission)
+	SELECT 'superadmin', permission FROM rolePermissions WHERE role = 'admin';
+INSERT INTO rolePermissions (role, permission) VALUES
+	('admin', 'sharing:edit'),
+	('superadmin', 'sharing:edit'),
+	('superadmin', 'org:manage');
+DELETE FROM rolePermissions WHERE role = 'admin' AND permission = 'role:edit';
+
+INSERT INTO userRoles (username, role) SELECT username, 'superadmin' FROM userRoles WHERE role = 'admin';
\ No newline at end of file
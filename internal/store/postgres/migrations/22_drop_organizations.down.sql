@@ -0,0 +1,16 @@
+INSERT INTO rolePermissions (role, permission) VALUES ('admin', 'role:edit') ON CONFLICT DO NOTHING;
+DELETE FROM rolePermissions WHERE permission IN ('sharing:edit', 'org:manage');
+DELETE FROM roles WHERE name = 'superadmin';
+
+DROP TABLE sharingAgreements;
+DROP TABLE schemas;
+
+ALTER TABLE picklists DROP COLUMN org;
+
+DELETE FROM reports WHERE org <> 'default';
+ALTER TABLE reports DROP COLUMN org;
+ALTER TABLE reports ADD UNIQUE(eventKey, matchKey, team);
+
+ALTER TABLE users DROP COLUMN org;
+
+DROP TABLE organizations;
\ No newline at end of file
@@ -0,0 +1,15 @@
+ALTER TABLE picklists ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+CREATE TABLE IF NOT EXISTS picklistShares (
+	picklistId uuid NOT NULL,
+	username TEXT,
+	role TEXT,
+	permission TEXT NOT NULL,
+	UNIQUE(picklistId, username),
+	UNIQUE(picklistId, role),
+	CHECK((username IS NULL) <> (role IS NULL)),
+	CHECK(permission IN ('read', 'edit')),
+	FOREIGN KEY(picklistId) REFERENCES picklists(id) ON DELETE CASCADE,
+	FOREIGN KEY(username) REFERENCES users(username) ON UPDATE CASCADE ON DELETE CASCADE,
+	FOREIGN KEY(role) REFERENCES roles(name) ON UPDATE CASCADE ON DELETE CASCADE
+);
\ No newline at end of file
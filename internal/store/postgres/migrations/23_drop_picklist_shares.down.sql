@@ -0,0 +1,3 @@
+DROP TABLE picklistShares;
+
+ALTER TABLE picklists DROP COLUMN version;
\ No newline at end of file
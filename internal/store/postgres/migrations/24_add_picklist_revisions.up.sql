@@ -0,0 +1,28 @@
+ALTER TABLE picks ADD COLUMN position INTEGER;
+
+UPDATE picks SET position = ordered.position
+	FROM (
+		SELECT ctid, row_number() OVER (PARTITION BY picklistId ORDER BY ctid) - 1 AS position
+			FROM picks
+	) AS ordered
+	WHERE picks.ctid = ordered.ctid;
+
+ALTER TABLE picks ALTER COLUMN position SET NOT NULL;
+ALTER TABLE picks ADD UNIQUE(picklistId, position);
+
+CREATE TABLE IF NOT EXISTS picklistRevisions (
+	picklistId uuid NOT NULL,
+	version INTEGER NOT NULL,
+	eventKey TEXT NOT NULL,
+	name TEXT,
+	list TEXT[] NOT NULL,
+	editor TEXT,
+	created TIMESTAMPTZ NOT NULL DEFAULT now(),
+	UNIQUE(picklistId, version),
+	FOREIGN KEY(picklistId) REFERENCES picklists(id) ON DELETE CASCADE,
+	FOREIGN KEY(editor) REFERENCES users(username) ON UPDATE CASCADE ON DELETE SET NULL
+);
+
+INSERT INTO picklistRevisions (picklistId, version, eventKey, name, list, editor)
+	SELECT p.id, p.version, p.eventKey, p.name, ARRAY(SELECT team FROM picks WHERE picklistId = p.id ORDER BY position), p.owner
+		FROM picklists p;
\ No newline at end of file
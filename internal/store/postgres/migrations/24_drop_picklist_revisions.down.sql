@@ -0,0 +1,3 @@
+DROP TABLE picklistRevisions;
+
+ALTER TABLE picks DROP COLUMN position;
\ No newline at end of file
@@ -0,0 +1,15 @@
+ALTER TABLE picks ALTER COLUMN team DROP NOT NULL;
+ALTER TABLE picks ADD COLUMN separator TEXT;
+ALTER TABLE picks ADD COLUMN tier INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE picks ADD COLUMN notes TEXT NOT NULL DEFAULT '';
+ALTER TABLE picks ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE picks ADD COLUMN doNotPick BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE picks ADD COLUMN doNotPickReason TEXT NOT NULL DEFAULT '';
+ALTER TABLE picks ADD CHECK((team IS NULL) <> (separator IS NULL));
+
+ALTER TABLE picklistRevisions ADD COLUMN picks TEXT NOT NULL DEFAULT '[]';
+UPDATE picklistRevisions
+	SET picks = (SELECT COALESCE(json_agg(json_build_object('team', team)), '[]')::text FROM unnest(list) AS team);
+ALTER TABLE picklistRevisions ALTER COLUMN picks DROP DEFAULT;
+ALTER TABLE picklistRevisions DROP COLUMN list;
+ALTER TABLE picklistRevisions RENAME COLUMN picks TO list;
\ No newline at end of file
@@ -0,0 +1,15 @@
+ALTER TABLE picklistRevisions ADD COLUMN teams TEXT[] NOT NULL DEFAULT '{}';
+UPDATE picklistRevisions
+	SET teams = ARRAY(SELECT pick->>'team' FROM json_array_elements(list::json) AS pick WHERE pick->>'team' IS NOT NULL);
+ALTER TABLE picklistRevisions ALTER COLUMN teams DROP DEFAULT;
+ALTER TABLE picklistRevisions DROP COLUMN list;
+ALTER TABLE picklistRevisions RENAME COLUMN teams TO list;
+
+DELETE FROM picks WHERE team IS NULL;
+ALTER TABLE picks DROP COLUMN separator;
+ALTER TABLE picks DROP COLUMN tier;
+ALTER TABLE picks DROP COLUMN notes;
+ALTER TABLE picks DROP COLUMN tags;
+ALTER TABLE picks DROP COLUMN doNotPick;
+ALTER TABLE picks DROP COLUMN doNotPickReason;
+ALTER TABLE picks ALTER COLUMN team SET NOT NULL;
\ No newline at end of file
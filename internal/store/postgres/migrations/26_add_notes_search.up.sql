@@ -0,0 +1,7 @@
+ALTER TABLE reports ADD COLUMN created TIMESTAMPTZ NOT NULL DEFAULT now();
+
+UPDATE reports SET created = COALESCE(matches.actualTime, matches.predictedTime, reports.created)
+	FROM matches
+	WHERE matches.key = reports.matchKey;
+
+CREATE INDEX IF NOT EXISTS reports_notes_search ON reports USING GIN (to_tsvector('english', COALESCE(notes, '')));
\ No newline at end of file
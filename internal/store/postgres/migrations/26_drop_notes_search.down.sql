@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS reports_notes_search;
+
+ALTER TABLE reports DROP COLUMN created;
\ No newline at end of file
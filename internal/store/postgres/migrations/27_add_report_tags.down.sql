@@ -0,0 +1,5 @@
+ALTER TABLE reports DROP COLUMN tags;
+
+DROP TABLE IF EXISTS reportTags;
+
+DROP TABLE IF EXISTS reportTagSets;
\ No newline at end of file
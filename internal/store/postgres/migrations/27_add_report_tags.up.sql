@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS reportTagSets (
+	org TEXT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+	year INTEGER NOT NULL,
+	PRIMARY KEY (org, year)
+);
+
+CREATE TABLE IF NOT EXISTS reportTags (
+	org TEXT NOT NULL,
+	year INTEGER NOT NULL,
+	name TEXT NOT NULL,
+	description TEXT NOT NULL,
+	position INTEGER NOT NULL,
+	PRIMARY KEY (org, year, name),
+	FOREIGN KEY (org, year) REFERENCES reportTagSets (org, year) ON DELETE CASCADE
+);
+
+ALTER TABLE reports ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}';
\ No newline at end of file
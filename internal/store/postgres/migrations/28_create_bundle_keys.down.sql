@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS trustedBundleKeys;
+
+DROP TABLE IF EXISTS bundleKeys;
\ No newline at end of file
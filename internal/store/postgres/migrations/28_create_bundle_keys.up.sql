@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS bundleKeys (
+	org TEXT PRIMARY KEY REFERENCES organizations(id) ON UPDATE CASCADE ON DELETE CASCADE,
+	privateKey BYTEA NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS trustedBundleKeys (
+	org TEXT NOT NULL REFERENCES organizations(id) ON UPDATE CASCADE ON DELETE CASCADE,
+	partner TEXT NOT NULL,
+	publicKey BYTEA NOT NULL,
+	PRIMARY KEY (org, partner)
+);
\ No newline at end of file
@@ -0,0 +1 @@
+INSERT INTO rolePermissions (role, permission) VALUES ('superadmin', 'schedule:edit');
\ No newline at end of file
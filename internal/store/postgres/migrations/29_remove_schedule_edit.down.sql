@@ -0,0 +1 @@
+DELETE FROM rolePermissions WHERE permission = 'schedule:edit';
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE events ADD COLUMN manual BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE matches ADD COLUMN manual BOOLEAN NOT NULL DEFAULT false;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE matches DROP COLUMN manual;
+ALTER TABLE events DROP COLUMN manual;
\ No newline at end of file
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS matchChanges (
+	id BIGSERIAL PRIMARY KEY,
+	eventKey TEXT NOT NULL REFERENCES events(key) ON DELETE CASCADE,
+	matchKey TEXT NOT NULL,
+	kind TEXT NOT NULL,
+	old JSONB NOT NULL,
+	new JSONB NOT NULL,
+	created TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX ON matchChanges (eventKey, id);
\ No newline at end of file
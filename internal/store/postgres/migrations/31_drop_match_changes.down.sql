@@ -0,0 +1 @@
+DROP TABLE matchChanges;
\ No newline at end of file
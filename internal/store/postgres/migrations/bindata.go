@@ -18,10 +18,38 @@
 // 16_drop_picklist_tables.down.sql
 // 17_add_is_verified.up.sql
 // 17_remove_is_verified.down.sql
+// 18_add_photo_year.up.sql
+// 18_remove_photo_year.down.sql
+// 19_create_sessions_table.up.sql
+// 19_drop_sessions_table.down.sql
 // 1_create_events_table.up.sql
 // 1_drop_events_table.down.sql
+// 20_create_signing_keys_table.up.sql
+// 20_drop_signing_keys_table.down.sql
+// 21_create_roles_tables.up.sql
+// 21_drop_roles_tables.down.sql
+// 22_create_organizations.up.sql
+// 22_drop_organizations.down.sql
+// 23_create_picklist_shares.up.sql
+// 23_drop_picklist_shares.down.sql
+// 24_add_picklist_revisions.up.sql
+// 24_drop_picklist_revisions.down.sql
+// 25_add_pick_annotations.up.sql
+// 25_drop_pick_annotations.down.sql
+// 26_add_notes_search.up.sql
+// 26_drop_notes_search.down.sql
+// 27_add_report_tags.down.sql
+// 27_add_report_tags.up.sql
+// 28_create_bundle_keys.down.sql
+// 28_create_bundle_keys.up.sql
+// 29_add_schedule_edit.up.sql
+// 29_remove_schedule_edit.down.sql
 // 2_create_matches_table.up.sql
 // 2_drop_matches_table.down.sql
+// 30_add_manual_schedule.up.sql
+// 30_drop_manual_schedule.down.sql
+// 31_create_match_changes.up.sql
+// 31_drop_match_changes.down.sql
 // 3_create_alliances_table.up.sql
 // 3_drop_alliances_table.down.sql
 // 4_create_reports_table.up.sql
@@ -36,7 +64,6 @@
 // 8_remove_isadmin.down.sql
 // 9_add_reports_cascade.up.sql
 // 9_remove_reports_cascade.down.sql
-// bindata.go
 // DO NOT EDIT!
 
 package migrations
@@ -464,6 +491,86 @@ func _17_remove_is_verifiedDownSql() (*asset, error) {
 	return a, nil
 }
 
+var __18_add_photo_yearUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x64\x8d\x41\x0a\xc2\x30\x10\x00\xcf\xdd\x57\xec\xb1\x85\xfe\xc0\x53\xb4\x5b\x09\xa4\x69\x4d\x36\x50\xbc\x05\x1a\xf0\xa0\xa6\xc4\x14\xe9\xef\x45\x29\x22\x78\x9d\x19\x98\xc6\xf4\x03\xb2\xd8\x2b\xc2\xf9\x12\x73\x7c\xec\x00\x0e\x86\x04\xd3\x46\x65\x8b\xba\x67\xa4\x51\x5a\xb6\x5b\x83\x25\x14\x39\xf8\x1b\x32\x8d\xfc\xd1\xda\x29\x55\x43\xb1\x06\x9f\x50\x6a\xa6\x23\x99\x5f\xbe\xa4\xeb\x5f\xbb\xcc\x93\xcf\x61\x42\x96\x1d\x59\x16\xdd\xc0\xe7\xaf\xc6\x86\x5a\xe1\x14\xe3\x3d\x3e\xcb\xaa\x86\xc2\x69\x79\x72\x54\xbe\xa7\x35\xae\xc1\xa7\x0a\x2a\x78\x0d\x00\x08\x6b\x5d\x9c\xbc\x00\x00\x00")
+
+func _18_add_photo_yearUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__18_add_photo_yearUpSql,
+		"18_add_photo_year.up.sql",
+	)
+}
+
+func _18_add_photo_yearUpSql() (*asset, error) {
+	bytes, err := _18_add_photo_yearUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "18_add_photo_year.up.sql", size: 188, mode: os.FileMode(436), modTime: time.Unix(1792371328, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __18_remove_photo_yearDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x76\x00\x89\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x70\x68\x6f\x74\x6f\x73\x3b\x0a\x0a\x43\x52\x45\x41\x54\x45\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x4e\x4f\x54\x20\x45\x58\x49\x53\x54\x53\x20\x70\x68\x6f\x74\x6f\x73\x20\x28\x0a\x09\x74\x65\x61\x6d\x20\x54\x45\x58\x54\x20\x4e\x4f\x54\x20\x4e\x55\x4c\x4c\x2c\x0a\x09\x75\x72\x6c\x20\x54\x45\x58\x54\x20\x4e\x4f\x54\x20\x4e\x55\x4c\x4c\x2c\x0a\x09\x55\x4e\x49\x51\x55\x45\x28\x74\x65\x61\x6d\x2c\x20\x75\x72\x6c\x29\x0a\x29\x0a\x03\x00\x0f\xfb\x52\xff\x76\x00\x00\x00")
+
+func _18_remove_photo_yearDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__18_remove_photo_yearDownSql,
+		"18_remove_photo_year.down.sql",
+	)
+}
+
+func _18_remove_photo_yearDownSql() (*asset, error) {
+	bytes, err := _18_remove_photo_yearDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "18_remove_photo_year.down.sql", size: 118, mode: os.FileMode(436), modTime: time.Unix(1792371328, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __19_create_sessions_tableUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\x90\xcf\x6e\x83\x30\x0c\xc6\xcf\xe4\x29\x7c\x04\x89\xe3\x5e\x20\x05\x33\xa1\xa5\x49\x07\x46\xea\x76\xa9\xd0\xe2\x0e\xd4\x2d\x4c\x71\xe9\xf6\xf8\x53\x91\xf6\x47\xa2\xd7\xef\xfb\xd9\x3f\xcb\x45\x83\x9a\x10\x48\x6f\x0c\x42\x5d\x81\x75\x04\xb8\xaf\x5b\x6a\x41\x58\x64\x9c\x82\x40\xaa\x92\xd1\xc3\x3c\x8f\x1e\x3a\x5b\x3f\x76\xb8\x50\xb6\x33\x06\x4a\xac\x74\x67\x68\x29\x0f\xaf\x1c\x38\xf6\x67\x3e\x5c\xee\xd2\x2c\x57\xc9\x2c\x1c\x43\xff\xce\x40\xb8\xa7\xdf\x99\x5c\x25\x43\x2f\x03\xfb\x86\x8f\x91\x65\xa0\xe9\xc4\x61\x85\xbc\x44\xee\xcf\xec\x81\xea\x2d\xb6\xa4\xb7\x3b\x7a\x5e\x5b\xc3\xf4\xb9\x88\xf8\xeb\x63\x8c\x2c\x37\xe1\x5c\x25\x91\x2f\xd3\x89\x3d\x6c\x9c\x33\xa8\xed\x7a\xcf\xb1\x7f\x13\xce\x55\x52\xb9\x06\xeb\x7b\x0b\x0f\xf8\x94\xfe\x1c\x9f\x41\x83\x15\x36\x68\x0b\x6c\xe1\x1a\xca\xbf\xca\x59\xe8\x76\xe5\xf5\x81\x85\x6e\x0b\x5d\x22\x38\x0b\x25\x1a\xfc\x4b\x54\xa6\xbe\x07\x00\xc5\x8a\x7c\xd2\x64\x01\x00\x00")
+
+func _19_create_sessions_tableUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__19_create_sessions_tableUpSql,
+		"19_create_sessions_table.up.sql",
+	)
+}
+
+func _19_create_sessions_tableUpSql() (*asset, error) {
+	bytes, err := _19_create_sessions_tableUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "19_create_sessions_table.up.sql", size: 356, mode: os.FileMode(436), modTime: time.Unix(1792371424, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __19_drop_sessions_tableDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x13\x00\xec\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x73\x65\x73\x73\x69\x6f\x6e\x73\x03\x00\x57\x6d\x5c\x6f\x13\x00\x00\x00")
+
+func _19_drop_sessions_tableDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__19_drop_sessions_tableDownSql,
+		"19_drop_sessions_table.down.sql",
+	)
+}
+
+func _19_drop_sessions_tableDownSql() (*asset, error) {
+	bytes, err := _19_drop_sessions_tableDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "19_drop_sessions_table.down.sql", size: 19, mode: os.FileMode(436), modTime: time.Unix(1792371424, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var __1_create_events_tableUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x3c\x8b\x4d\x0a\xc2\x30\x10\x85\xd7\x99\x53\xbc\xa5\x42\x2f\x11\x65\x84\x60\x12\x4b\xf2\x84\xd6\x5d\xc1\x01\x41\xac\x60\x83\xe0\xed\x45\x44\xb7\xdf\xcf\xb6\xa8\xa7\x82\x7e\x13\x15\x61\x87\x7c\x20\x74\x08\x95\x15\xf6\xb4\xb9\x2d\x58\x89\xbb\xda\x0b\xd4\x81\xe8\x4b\x48\xbe\x8c\xd8\xeb\xd8\x89\x9b\xa7\x9b\x7d\xf9\xe7\xca\xc7\x18\x3b\x71\xcb\xe5\xfe\x68\xf9\x67\x3a\x71\xe7\xa9\x19\x18\x92\x56\xfa\xd4\xf3\xf4\x8f\x65\xfd\x0e\x00\x00\xff\xff\x50\xda\x81\x7d\x7d\x00\x00\x00")
 
 func _1_create_events_tableUpSqlBytes() ([]byte, error) {
@@ -504,6 +611,406 @@ func _1_drop_events_tableDownSql() (*asset, error) {
 	return a, nil
 }
 
+var __20_create_signing_keys_tableUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x54\xcb\xd1\x0a\x82\x30\x14\x87\xf1\xeb\xed\x29\xfe\x97\x0a\xbe\xc4\xac\x23\x0c\xa7\x89\x1e\x41\xbb\x93\x1c\x36\x2a\x8d\x39\x0a\xdf\x3e\x22\x88\xba\xfe\x7d\xdf\xae\x26\xc5\x04\x56\xa9\x21\xe8\x0c\xe5\x81\x41\x9d\x6e\xb8\xc1\xea\xa6\xd9\xcd\x53\x6e\xb7\x15\x91\x14\x17\x37\x82\xa9\x63\x54\xb5\x2e\x54\xdd\x23\xa7\x3e\x91\x62\xb8\x4e\x8b\x77\xe1\x7c\xfb\xe0\xfb\x2f\x5b\x63\x12\x29\xee\xde\x3d\x86\x60\x73\xbb\x21\xed\x99\xd4\xaf\x9d\xbc\x1d\x82\x1d\xc1\xba\xa0\x86\x55\x51\xf1\xf1\xcb\xd8\x53\xa6\x5a\xc3\x98\x97\x67\x14\x27\x52\x78\x1b\x9c\xff\x8f\x65\xfc\x1a\x00\xcb\xf0\xcc\xa4\xb9\x00\x00\x00")
+
+func _20_create_signing_keys_tableUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20_create_signing_keys_tableUpSql,
+		"20_create_signing_keys_table.up.sql",
+	)
+}
+
+func _20_create_signing_keys_tableUpSql() (*asset, error) {
+	bytes, err := _20_create_signing_keys_tableUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20_create_signing_keys_table.up.sql", size: 185, mode: os.FileMode(436), modTime: time.Unix(1792371728, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20_drop_signing_keys_tableDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x16\x00\xe9\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x73\x69\x67\x6e\x69\x6e\x67\x4b\x65\x79\x73\x03\x00\x1c\xb4\xac\xa2\x16\x00\x00\x00")
+
+func _20_drop_signing_keys_tableDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20_drop_signing_keys_tableDownSql,
+		"20_drop_signing_keys_table.down.sql",
+	)
+}
+
+func _20_drop_signing_keys_tableDownSql() (*asset, error) {
+	bytes, err := _20_drop_signing_keys_tableDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20_drop_signing_keys_table.down.sql", size: 22, mode: os.FileMode(436), modTime: time.Unix(1792371728, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __21_create_roles_tablesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x54\x4d\x6f\x9b\x40\x10\x3d\x9b\x5f\x31\x37\x6c\x89\xfc\x81\xfa\x44\xf1\xb8\x45\xc1\xe0\x2e\x4b\x9b\x1c\xb7\xb0\xaa\x57\x31\x8b\xb5\x8b\x13\xe5\xdf\x57\xc3\x87\x8d\x31\xae\xa3\x4a\x39\x7a\xde\xbe\x37\xf3\xde\x8c\x09\x18\xfa\x1c\x81\xfb\x5f\x23\x84\x70\x0d\x71\xc2\x01\x9f\xc2\x94\xa7\x60\xaa\xbd\xb4\x30\x77\x66\x5a\x94\x12\x38\x3e\x71\xd8\xb2\x70\xe3\xb3\x67\x78\xc4\x67\xcf\x99\x15\xd2\xe6\x46\x1d\x6a\x55\xe9\x16\x26\x72\x9c\x45\x11\xac\x70\xed\x67\x11\x07\xd7\x75\x16\x4b\xc7\xb9\xd3\x64\x2b\x4d\xa9\xac\x55\x95\x6e\xda\x51\xdf\x4b\x3d\xcf\x99\x1d\x4e\x6f\xae\xa0\x2c\x0e\x7f\x64\x38\x27\x9a\x07\xe7\x77\x0b\xcf\x99\xad\x13\x86\xe1\xb7\x98\xe6\x6d\xf0\x05\x30\x5c\x23\xc3\x38\xc0\xce\xdf\x9c\xcc\x2d\x20\x89\x21\xdb\xae\x28\x89\xc0\x4f\x03\x7f\x85\x54\x59\x61\x84\xe7\xca\x3d\x27\x47\x2b\x0d\xeb\x23\xa3\x1f\xe7\xd8\x06\xc3\x4e\x9a\xeb\x1c\xf4\x24\xaf\x19\x6d\x3c\x7f\x8f\x5e\x78\xa0\xa2\x1d\x40\x1f\xf0\xf1\x19\xb1\x84\x71\x8a\x8c\x43\x18\xf3\xa4\x3f\x1b\x12\xf0\x60\x70\x22\x0b\xf8\xe9\x47\x19\xa6\xce\x6c\xee\xda\xbc\x3a\xd6\xae\x07\x6e\x7a\xfc\x5d\xaa\xda\x82\x91\x87\xca\xd4\x16\x84\x2e\xe0\x45\xca\x83\x85\x7a\x27\x95\x81\xea\x4d\xc3\x41\xe5\x2f\x7b\x65\x6b\xeb\x52\x24\x73\x57\x68\xb1\x7f\xb7\x2d\x9d\x74\xac\xd7\xd0\x72\xa1\xc1\x48\x51\x80\x7c\x95\xe6\xfd\xc4\xea\x48\x85\x51\xaf\xf2\x21\xaf\x44\xbe\x23\xe2\xe3\xad\x1e\x1f\x90\x12\x45\xa9\x34\x89\x6c\x84\x16\x7f\xa4\x05\x8a\xdf\x7a\x9d\x71\xe2\xd7\x3b\xd9\x39\x02\x9b\xef\x64\x29\xdc\xa9\x90\x2e\xce\xfe\xea\x7a\xa7\xd2\x6a\x35\xbf\xbc\x19\x55\xcb\x6e\x98\x13\xd6\x0f\x79\x81\x0e\xa2\x9a\xe0\x0e\xd0\x7b\xec\x13\x4e\x09\x3f\x88\xfd\x7e\x3a\xd6\x49\x99\x5b\x6f\x46\x52\xa7\x58\xa7\x26\xed\xb1\xc9\x06\xd7\xe8\x2d\x69\xda\xd4\xbf\x41\x59\xa8\x7a\x0a\xa4\xfd\x34\xe0\x18\x68\x17\xdc\x41\xa3\x2d\x0f\x3e\x09\xa3\xff\x36\xa4\x18\x61\xc0\xe1\x5c\xee\xb6\x0c\x6b\x96\x6c\x9a\xb2\x5d\xfe\xbf\x56\xeb\x69\xa0\x05\xbf\xbe\x23\x43\x50\xd6\x27\x64\xe9\x38\x7e\xc4\x91\x75\xdf\xb1\xf6\xc5\x8a\x25\x5b\x08\x92\x28\xdb\xc4\xa0\xac\x5f\x94\x4a\x2f\xff\x0e\x00\x83\x9a\x94\x49\x1e\x06\x00\x00")
+
+func _21_create_roles_tablesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__21_create_roles_tablesUpSql,
+		"21_create_roles_tables.up.sql",
+	)
+}
+
+func _21_create_roles_tablesUpSql() (*asset, error) {
+	bytes, err := _21_create_roles_tablesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "21_create_roles_tables.up.sql", size: 1566, mode: os.FileMode(436), modTime: time.Unix(1792371895, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __21_drop_roles_tablesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x64\x8e\x4b\x6a\xc4\x30\x10\x44\xf7\x3a\x45\xed\x26\x39\x83\x98\x85\x6c\xb5\x49\xa0\x2d\x19\x59\x22\x6b\x43\x14\x10\xf8\x03\xea\xf8\xfe\xc1\xce\xcf\x30\xcb\xae\xea\xf7\x28\xc3\x91\x02\xa2\x69\x98\xb0\x4b\xae\x02\x63\x2d\x5a\xcf\xa9\x77\x28\x62\xde\x97\xb2\xa2\xf1\x9e\xc9\x38\x38\x1f\xe1\x12\x33\x2c\x75\x26\x71\xc4\xc7\x34\x4b\xd6\x4a\xa5\xc1\x9a\xf8\x2b\x18\x29\xfe\x91\x77\x7c\xd6\x3d\xe3\xed\x85\xc2\x77\xbd\x4e\x4b\xc6\xab\xc3\xd3\x48\x4c\x6d\xfc\xcf\xba\xe0\xfb\xf3\x0a\xdb\x9c\xe5\x87\xa8\xdb\x9c\x71\xc7\x6d\x3a\x64\xb7\x67\xad\x94\x0d\x7e\xb8\xcc\x3d\x9f\xf5\x35\x3d\x90\x21\xd7\xa5\x88\x94\x6d\x7d\xec\x44\x7f\x0d\x00\xd9\x8f\xa3\x44\xf3\x00\x00\x00")
+
+func _21_drop_roles_tablesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__21_drop_roles_tablesDownSql,
+		"21_drop_roles_tables.down.sql",
+	)
+}
+
+func _21_drop_roles_tablesDownSql() (*asset, error) {
+	bytes, err := _21_drop_roles_tablesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "21_drop_roles_tables.down.sql", size: 243, mode: os.FileMode(436), modTime: time.Unix(1792371895, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __22_create_organizationsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x95\xcf\x6f\xa3\x3a\x10\xc7\xcf\xf0\x57\xcc\x2d\x20\x71\x7c\xa7\xe6\xbd\x27\xb9\xe0\x6c\x51\x09\x64\xc1\xec\xb6\x7b\x89\x2c\x98\x12\x94\xf0\x43\x36\x69\x95\xfd\xeb\x57\x26\x40\x08\x49\xdb\xad\xaa\xd5\xde\xac\xb1\xfd\x9d\xcf\xcc\x17\x0f\x76\x48\x09\xa3\xc0\xc8\xad\x47\xc1\x5d\x80\x1f\x30\xa0\x0f\x6e\xc4\x22\xa8\x44\xc6\xcb\xfc\x27\x6f\xf2\xaa\x94\x60\xe8\x5a\x9e\x02\xa3\x0f\x0c\x56\xa1\xbb\x24\xe1\x23\xdc\xd3\x47\x4b\xd7\x4a\x5e\xe0\x31\xae\xee\xfa\xb1\xe7\x59\xba\x96\x08\xe4\x0d\xa6\xc0\xdc\x25\x8d\x18\x59\xae\xd8\x8f\x61\x1b\x1c\xba\x20\xb1\xc7\xa0\xac\x5e\x0c\x53\x37\xe7\xba\xee\xfa\x11\x0d\x19\xb8\x3e\x0b\xa6\x69\xf3\xd4\x02\x95\xc2\x84\x6f\xc4\x8b\x69\x04\xc6\x2c\xc5\x27\xbe\xdf\x35\x33\x0b\x66\x4e\xb7\x54\x22\xc4\x63\x34\xec\x2a\xd9\x4b\x14\x12\x88\xe3\x80\x1d\x78\xf1\xd2\x57\xaa\xe7\x90\x03\xc5\x20\x07\x21\x5d\xd0\x90\xfa\x36\x9d\xd4\x6e\xe4\xa9\x09\x81\x0f\xf1\xca\x51\xbd\xb2\x49\x64\x13\x87\xce\xaf\x25\x6c\x23\xa3\x94\x4e\x18\xac\xfa\x4c\x13\x44\x81\x75\x25\x9a\x3f\x0c\xa9\xb0\x1d\xea\xd1\xd7\xb0\x07\x88\xb7\xc1\xaf\x5d\x69\x4f\xd8\x81\x1f\xb1\x90\xb8\x3e\xeb\xe3\x6b\x7c\xc6\xb2\xd9\xe2\x61\x5d\xf0\x26\xd9\xa8\x45\x83\xbc\x58\x6f\xf1\xf0\x4a\x6a\xc7\x81\xd8\x77\xbf\xc6\xd4\xa8\x44\x66\x41\x7b\xff\x1e\x0f\x16\xb4\x02\xed\x4a\x29\x4c\x2d\xae\xf3\x64\xbb\xcb\xe5\xdf\xee\xe0\x08\xe3\x1d\xf3\xdf\x78\x6a\x32\xd9\x60\xc1\xdb\x47\x36\x54\x70\xfe\xca\x8e\x27\xce\x6b\xb3\x74\x6d\x11\x84\xd4\xfd\xe2\xab\xa7\xa8\xba\x67\x7e\xba\x38\xdd\x7c\x87\x74\xc3\x45\x5e\x66\x24\x13\x88\x05\x96\x4d\x3f\x18\xf6\xfb\x3c\xed\x6c\xbc\xec\xbd\xda\x5c\x67\x58\xa2\xe0\x0d\xae\x9f\xff\x31\x4c\x4b\xd7\x7a\x9f\x2f\x6a\xaa\x45\x55\x57\x12\xc5\xe5\x06\x17\x4d\x79\x25\xce\x93\x04\x6b\x35\x6d\x6e\x83\xc0\xa3\xc4\xbf\x04\x78\xe2\x3b\x89\x1f\x1a\x4b\x96\xae\x75\x1f\xe5\xe9\x7b\xec\xc1\x2c\xe8\x48\x54\x19\xf6\x1d\xb5\xef\x8d\x81\xf9\xdf\xff\xc7\x9b\x63\x7b\x7a\x9d\x33\x8f\xda\xa0\x34\xb6\x78\x98\x1e\xef\x15\x3f\x6f\xe9\x54\xb8\xc3\xfb\xb4\xee\xc5\xe4\x16\xd5\x0e\x25\x18\x6a\x5a\x5b\x90\xa2\x4c\x44\x5e\x2b\xc5\xd1\xe8\x96\xfb\x1a\x05\x4f\x8b\xbc\x54\xd3\x7b\xc9\x4b\x9e\xa1\x3c\x4f\x0f\xbc\x4c\x3b\xa9\xa7\x4a\x40\xb3\x41\x78\xd9\x54\x3b\x84\x14\xeb\x5d\x75\x50\x1f\xdd\xec\x5a\xe6\x15\x8a\x22\x97\xb2\x95\x30\x54\xc0\x82\x7a\x08\x99\xba\x16\x51\x8f\xda\x0c\xce\x11\x4e\x27\x60\x11\x06\xcb\x0b\xa1\xef\x77\x34\xa4\x6d\x14\xfe\x83\xd9\x11\x7c\xfe\xc1\xd4\x5d\xf5\xba\x66\x74\x02\x16\xcc\xba\x67\x74\x83\x69\xde\xcc\x94\xf5\xd3\xd6\xbc\x7b\xa0\x12\xd9\x4d\xd1\xf6\x4f\x75\xa3\x73\xe6\xb7\x6b\x00\xe2\x3b\x23\x46\x55\x9c\xe2\x3e\xf2\x4c\x9a\xab\xfe\xa5\x61\xeb\x87\xa1\x96\x47\x7b\xd5\x69\x13\xba\x9e\x9e\xc2\x63\xc8\x63\x47\x4f\xb7\xaf\x71\xcc\x7f\x0d\x00\x81\xc6\x0b\x19\x80\x08\x00\x00")
+
+func _22_create_organizationsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__22_create_organizationsUpSql,
+		"22_create_organizations.up.sql",
+	)
+}
+
+func _22_create_organizationsUpSql() (*asset, error) {
+	bytes, err := _22_create_organizationsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "22_create_organizations.up.sql", size: 2176, mode: os.FileMode(436), modTime: time.Unix(1792372102, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __22_drop_organizationsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\x8f\x4d\x4e\xeb\x30\x14\x85\xe7\x59\xc5\x9d\x39\x91\xb2\x82\xfa\x3d\xa4\xd0\xb8\x34\x22\xb5\x4b\xea\xc0\xd8\x6a\x2f\xae\x45\x6d\x47\xb6\x8b\x04\xab\x47\xfd\x03\x4a\x3a\xb3\x8e\x8f\xbe\xf3\xdd\x86\xaf\x58\x27\xa1\xe1\x52\x40\xf0\x3b\x5c\x62\xb0\x26\x46\xe3\x5d\x84\xfc\x10\x94\x30\x7c\x47\x05\x3c\x57\x6d\xcf\x56\x90\x13\xb5\xb1\xc6\x91\x12\xc8\xa1\x33\xc1\x8d\x49\xa4\x00\xc1\x61\x2a\xf8\xac\x6d\xa6\x12\x6a\x01\x5c\xc8\x79\xc3\x1f\x68\x56\xb3\x96\x49\x06\xb3\x4e\x2c\x46\x23\x2f\x73\xd6\xb1\x5f\x1b\xd0\x70\xc8\x49\xdc\xaa\x60\x9c\x3e\x81\x4b\x20\x3e\xe8\x89\x55\x4e\x69\x24\xc5\x98\x77\xa1\x38\x65\x11\xfe\x03\x89\xfb\x01\xc3\xc9\x90\x66\x59\xdd\x89\x25\xc8\xea\xbe\x65\x70\xc6\x56\x3a\x20\x5a\x74\x29\xd2\xab\xdf\xf5\x16\xad\x8a\x34\xcb\xaa\x56\xb2\xee\x9c\x0e\x66\xfd\xb6\x33\x31\x45\x38\x56\xa7\xa2\xed\x17\x1c\x7c\xd0\x34\xbb\x16\xc1\xc1\x87\x74\x51\xf1\x41\xc3\xbf\x3b\x20\x1b\x7c\x55\xfb\x5d\x22\xf4\x8a\x79\xe9\x8e\x88\xb7\x4a\x55\x5d\x43\xcf\x9b\xa7\x9e\xe5\xf8\x8e\x2e\x3d\xe2\x47\x09\x56\xa5\xf5\xf6\xf8\x4a\xa8\x6c\xf1\xc7\x79\x1f\x31\xdc\xf4\xfd\x39\xd6\x07\xad\x9c\xf9\x54\xc9\x78\x17\xe9\xd7\x00\xb1\xde\x4f\xbe\x07\x02\x00\x00")
+
+func _22_drop_organizationsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__22_drop_organizationsDownSql,
+		"22_drop_organizations.down.sql",
+	)
+}
+
+func _22_drop_organizationsDownSql() (*asset, error) {
+	bytes, err := _22_drop_organizationsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "22_drop_organizations.down.sql", size: 519, mode: os.FileMode(436), modTime: time.Unix(1792372102, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __23_create_picklist_sharesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\xd0\xcf\x4f\xc3\x20\x14\x07\xf0\xf3\xf8\x2b\xde\xad\x34\xe9\xc5\xf3\x8c\x09\xc2\xeb\x24\x43\xaa\x14\x92\x79\x5c\x2c\x89\xc4\xfd\x0a\x6c\xfe\xfd\x86\xc6\xd1\x6a\x4c\xf4\xd6\x7c\x79\xfd\xf0\x7d\x30\x65\xd1\x80\x65\xf7\x0a\xe1\x14\x5e\xdf\x77\x21\x9d\x13\x30\x21\x80\x77\xca\x3d\x6a\xf8\xf0\x31\x85\xe3\x01\xa4\xb6\xb8\x42\x03\xba\xb3\xa0\x9d\x52\x20\xb0\x65\x4e\x59\xb8\x59\x12\xc2\x0d\x32\x8b\x5f\x8c\x6c\xc7\x21\xdc\xc8\xde\xf6\x05\xed\xdf\xb6\xd1\x27\xa0\x64\x71\x4d\xe4\x00\x97\x4b\x18\x8a\xd8\x90\xc5\x25\xf9\x78\xd8\xee\x3d\x58\xdc\xd8\x86\x2c\xe2\x71\x57\xbe\x4f\x3e\xee\x43\x1a\xbb\xe4\x64\xfe\x9b\xd3\xf2\xd9\x21\x9d\xe0\x06\xae\x52\xfd\xfb\x71\x86\xf3\x11\x7f\x40\xbe\xa6\xb4\xdc\x2b\xfb\xd1\xac\xe1\xf6\x0e\x68\x1e\x2a\xc9\x34\x3d\x2b\x22\x35\xd0\x2a\xfa\xed\x50\x35\x50\xf9\x21\x9c\xab\x71\xae\xed\x0c\xca\x95\x86\x35\xbe\xcc\x6e\xad\xc1\x60\x8b\x06\x35\xc7\xe9\x59\x12\x0d\x43\x0d\x9d\x06\x81\x0a\x2d\x02\x67\x3d\x67\x02\x7f\x20\x65\x9b\x39\x91\xc3\x54\xaa\x8f\x88\x7b\x12\x6c\x42\xfe\x64\xf3\x82\xdf\x5a\xe5\x20\xd1\x7f\x73\xa4\x5e\x7e\x0e\x00\x6f\x44\x00\x85\x40\x02\x00\x00")
+
+func _23_create_picklist_sharesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__23_create_picklist_sharesUpSql,
+		"23_create_picklist_shares.up.sql",
+	)
+}
+
+func _23_create_picklist_sharesUpSql() (*asset, error) {
+	bytes, err := _23_create_picklist_sharesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "23_create_picklist_shares.up.sql", size: 576, mode: os.FileMode(436), modTime: time.Unix(1792373663, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __23_drop_picklist_sharesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x46\x00\xb9\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x70\x69\x63\x6b\x6c\x69\x73\x74\x53\x68\x61\x72\x65\x73\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x70\x69\x63\x6b\x6c\x69\x73\x74\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x76\x65\x72\x73\x69\x6f\x6e\x3b\x03\x00\x3a\x38\xd3\x9c\x46\x00\x00\x00")
+
+func _23_drop_picklist_sharesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__23_drop_picklist_sharesDownSql,
+		"23_drop_picklist_shares.down.sql",
+	)
+}
+
+func _23_drop_picklist_sharesDownSql() (*asset, error) {
+	bytes, err := _23_drop_picklist_sharesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "23_drop_picklist_shares.down.sql", size: 70, mode: os.FileMode(436), modTime: time.Unix(1792373663, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __24_add_picklist_revisionsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\x53\xc1\x8e\x9b\x30\x10\x3d\xdb\x5f\x31\x47\x90\x28\x52\xcf\xd1\x1e\xbc\x30\xd9\xa2\x75\xcc\xd6\x98\x76\xd3\xaa\xaa\xd2\xe0\x83\xd5\x0d\x20\x4c\x12\xf5\xef\xab\x21\x04\xd8\x15\x97\x28\xd8\xf3\xde\xbc\x37\xf3\x2c\xa4\x41\x0d\x46\x3c\x4a\x84\xd6\x1d\xff\x7a\x10\x69\x0a\x49\x2e\xcb\x9d\x82\xb6\xf1\xae\x77\x4d\x0d\x99\x32\xf8\x84\x7a\xc3\x79\xf9\x92\x0a\x73\x2f\x2d\xd0\xcc\x35\x0f\xd0\x74\x95\xed\x6c\x15\xdf\x8f\x38\xdb\xea\x7c\x07\x01\x67\xac\x40\x89\x89\x81\x63\xef\xaa\x08\xba\xe6\xfa\xbb\x3e\x9f\xfe\xd8\x2e\x08\x21\xff\x86\x1a\x82\x17\xa1\x4d\x66\xb2\x5c\xc1\xe3\x7e\x20\x7f\x73\xbe\xcf\x2a\xc8\x75\x8a\x9a\xce\x08\x19\xc2\x27\xf8\x0c\xa2\x98\x7a\x72\xc6\x6e\x2d\x08\xe1\x39\x0b\xe9\x72\x54\xc1\xd9\xf7\x2f\xa8\x47\xa5\x31\xc1\x17\x0a\xe9\x73\xc3\xf9\x8a\xf9\xe1\xe4\xa3\x7d\xf2\xa9\x72\x03\xaa\x94\x72\xb3\x86\x4a\x53\x28\x55\xf6\xb5\xc4\x60\xd6\x1e\x4d\xf8\x70\xc3\x79\xa2\x91\xe6\x76\xeb\x95\x6d\x07\x3a\x7c\xcd\x0a\x53\x4c\x76\xb5\xbd\x38\xef\x9a\xda\xd3\xc4\x66\x1e\x38\x9f\x5d\x35\xb5\x8f\x38\xbb\xd8\xce\x2f\x96\xb2\xbc\xb2\x17\x5b\xf7\xcf\xf6\x1f\x18\x7c\x9d\x25\x47\x9c\xd5\x87\x93\x1d\x0e\x23\xce\x88\x76\xf8\xff\xf3\xd7\x3b\x6c\xe5\xfa\xa6\xbb\x17\x1d\x3b\x7b\xe8\x6d\x05\x26\xdb\x61\x61\xc4\xee\xc5\xfc\x98\x8a\x21\xc5\xad\x28\xa5\x81\xba\xb9\x06\x61\xc4\xd9\x8a\xf7\x51\x24\xdd\x6e\x73\x8d\xd9\x93\x82\x67\xdc\x2f\x4a\x42\xd0\xb8\x45\x8d\x2a\xc1\x79\x04\x3e\xa0\x2d\xe7\x0a\x52\x94\x68\x10\x12\x51\x24\x22\xc5\x0f\x24\x37\xa5\xef\x08\xce\xde\x76\x3e\xa0\x5f\x72\x3a\x50\x8c\x49\x1d\x29\x16\xa4\xc3\x36\x4b\x29\x39\xed\x25\x53\x05\x6a\x43\xb3\xcc\xd7\x16\xb1\x62\x29\x82\xfb\x94\x23\xa0\x66\x11\x50\x41\x04\xa3\x2a\x7e\xcf\x7a\x1b\x53\xd6\xdb\x78\x82\xb5\xf1\x0c\x6c\xe3\x1b\x54\x68\x2d\xf6\xc1\x88\xe8\xed\xe1\x04\x73\x9e\x61\x0e\xf0\x98\x84\x87\x81\x74\x7e\x14\x53\xc0\x88\xb0\xb9\xd6\xb6\xe3\x8b\x07\xf1\xe6\x7c\xef\xa1\xdd\xfc\x1f\x00\x1b\x29\x49\x5a\xe3\x03\x00\x00")
+
+func _24_add_picklist_revisionsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__24_add_picklist_revisionsUpSql,
+		"24_add_picklist_revisions.up.sql",
+	)
+}
+
+func _24_add_picklist_revisionsUpSql() (*asset, error) {
+	bytes, err := _24_add_picklist_revisionsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "24_add_picklist_revisions.up.sql", size: 995, mode: os.FileMode(436), modTime: time.Unix(1792373761, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __24_drop_picklist_revisionsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x46\x00\xb9\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x70\x69\x63\x6b\x6c\x69\x73\x74\x52\x65\x76\x69\x73\x69\x6f\x6e\x73\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x70\x69\x63\x6b\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x70\x6f\x73\x69\x74\x69\x6f\x6e\x3b\x03\x00\x33\xbc\xcc\xe7\x46\x00\x00\x00")
+
+func _24_drop_picklist_revisionsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__24_drop_picklist_revisionsDownSql,
+		"24_drop_picklist_revisions.down.sql",
+	)
+}
+
+func _24_drop_picklist_revisionsDownSql() (*asset, error) {
+	bytes, err := _24_drop_picklist_revisionsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "24_drop_picklist_revisions.down.sql", size: 70, mode: os.FileMode(436), modTime: time.Unix(1792373761, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __25_add_pick_annotationsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x92\xdf\x6b\xea\x30\x14\xc7\x9f\x6f\xff\x8a\xf3\xd6\x16\x7c\xb8\xcf\xf6\xde\x41\x6c\x8f\x9b\x2c\x36\x92\xa6\x30\x10\x91\xa8\x99\x44\xbb\x46\x4c\x1c\x83\xb1\xff\x7d\xb4\xd5\xfd\xd2\x91\xed\xad\x24\xe7\xf3\xfd\xd1\x1c\x42\x05\x72\x10\x64\x40\x11\x76\x7a\xb9\xb5\xd0\x9d\xa4\x8c\x96\xe3\x1c\x9c\x92\x0f\x90\x71\x36\x81\x9c\x09\xc8\x4b\x4a\x93\xe0\x02\x92\x65\x27\xc0\xaa\x9d\xdc\x4b\x67\xf6\x20\xf0\x4e\x78\x86\x9d\x56\x7b\x18\xe5\x02\xaf\x91\xbf\x19\x40\x86\x43\x52\x52\x01\x7f\x3d\x74\x6d\x9c\xb2\xad\xcd\x39\x1b\x86\x3e\x6b\xb9\xee\xd8\xe9\xec\x02\xfd\xfc\xe2\xe3\x57\x26\x37\x6e\xa2\x97\x5b\x18\x30\x46\x91\xe4\xe7\x2a\xf7\xb2\xb2\xea\xa7\x32\x5c\x49\x6b\xea\xdf\xb7\xb9\xc1\xf4\x36\x8a\xda\x67\x1a\x15\xad\x7f\x0c\xff\xae\x20\x7a\x7f\x87\xd3\x71\x9c\x04\x67\x22\x95\xb6\x8e\xab\x47\x6d\xb5\xa9\x3f\xe5\xea\x82\x7e\x93\x66\x3a\x0b\x93\xa0\x9c\x64\x44\x5c\x50\x09\xfe\x14\x28\x8e\x09\xff\x43\x54\x20\xc5\x54\x40\xca\x08\xc5\x22\xc5\x68\x63\x4d\x3d\x97\xeb\x75\xf7\xb1\x38\xe8\x6a\x35\x37\x8b\x8d\x5a\xba\x28\x6c\x5a\x84\xbd\x76\xe7\xe2\xb8\x07\xe1\x74\x16\xc6\xfd\xbe\x53\x4f\x0e\x86\x9c\x8d\xe1\x50\xd7\xca\xba\xa8\xb1\x8b\x81\x14\xdd\x60\xe2\x2b\xf5\x71\x9d\x9b\x6b\xdb\xed\xf3\xb1\x8d\x0f\x6f\x67\x8f\x74\x73\xe3\x9b\xe7\x98\x93\x31\x7e\xf9\x8d\x0c\x2a\x6d\x5d\xf2\x3a\x00\xe2\x5e\x92\xad\x6c\x03\x00\x00")
+
+func _25_add_pick_annotationsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__25_add_pick_annotationsUpSql,
+		"25_add_pick_annotations.up.sql",
+	)
+}
+
+func _25_add_pick_annotationsUpSql() (*asset, error) {
+	bytes, err := _25_add_pick_annotationsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "25_add_pick_annotations.up.sql", size: 876, mode: os.FileMode(436), modTime: time.Unix(1792373873, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __25_drop_pick_annotationsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x92\xdf\x4a\x33\x31\x10\xc5\xaf\xbf\x7d\x8a\xb9\xdb\xf6\xe2\x7b\x81\x2e\x16\x62\x33\x45\x61\x9a\x94\x6c\x82\x8a\x48\x09\x35\x48\x6c\xbb\x29\x9b\x20\x88\xf8\xee\x92\xb5\x0a\xfd\x03\xf1\xfa\xfc\xce\xc9\x9c\xc9\x30\xd2\xa8\x40\xb3\x6b\x42\xd8\xfb\xf5\x66\xeb\x63\x52\xee\xcd\x47\x1f\xba\x08\x8c\x73\x98\x49\x32\x0b\x01\xc9\xd9\x5d\x04\x8d\xf7\xfa\xf1\x09\x84\xd4\x20\x0c\x11\x70\x9c\x33\x43\x1a\xea\x8f\xcf\xba\xa9\xcc\x92\x33\x7d\x21\xa7\xfa\xd7\xa2\x3e\x24\x5c\x01\x53\x8a\x3d\x8c\x5a\x24\x9c\xe9\x81\xfd\x3f\x9d\xd6\x59\xac\x61\xae\xe4\x02\x5e\x63\xe8\x56\xb6\xef\xed\xfb\xca\x6d\xdd\xce\x75\x29\x8e\x72\xdc\x64\x92\x95\x31\xb0\x76\x70\xc1\xdd\x0d\x2a\x3c\x09\xb8\x6d\x7f\x67\x1b\x37\x55\xa1\xdc\xa0\x1e\xd5\xe3\x4a\x2e\x7f\x3a\x95\xec\x03\x7b\x70\x67\xa5\xc4\x2b\x14\x6c\x81\x27\xeb\x94\x90\xa1\xa6\xaa\x38\x12\x6a\xfc\x5e\x40\xf6\xc6\x43\xbd\xcc\x0d\xad\x0c\xd1\xf9\x0b\xc7\x53\x44\xb7\xb7\xbd\x4d\xa1\x2f\x81\xc9\xbb\x22\xd3\x85\xe4\x62\x31\xc8\xbe\x14\x99\xe7\x20\x42\x5a\xfa\xf5\xe6\xcf\xa0\x72\x36\x86\xee\x12\x7e\xf6\x65\x90\x0f\x4b\x48\x0d\xc2\x10\x35\x5f\x03\x00\x8c\xdd\x38\x6b\xcd\x02\x00\x00")
+
+func _25_drop_pick_annotationsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__25_drop_pick_annotationsDownSql,
+		"25_drop_pick_annotations.down.sql",
+	)
+}
+
+func _25_drop_pick_annotationsDownSql() (*asset, error) {
+	bytes, err := _25_drop_pick_annotationsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "25_drop_pick_annotations.down.sql", size: 717, mode: os.FileMode(436), modTime: time.Unix(1792373873, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __26_add_notes_searchUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x44\x8f\x41\x6e\xb3\x30\x10\x46\xd7\xf1\x29\x66\x07\x48\x51\x2e\x10\x65\xe1\x1f\x86\xfc\x56\x8d\x89\xb0\x51\xa3\x6e\x10\x32\xa3\x82\x9a\x40\x64\xbb\xad\x72\xfb\xaa\x34\x90\xed\xd3\xbc\xa7\xf9\xb8\x34\x58\x81\xe1\xff\x24\x82\xa3\xdb\xe4\x82\x07\x9e\x65\x90\x96\xb2\x2e\x14\x58\x47\x6d\xa0\x0e\x8c\x28\x50\x1b\x5e\x9c\xcc\x1b\xa8\xd2\x80\xaa\xa5\x84\x0c\x73\x5e\x4b\x03\xe3\xf4\x1d\x27\x7b\xc6\xea\x53\xc6\xcd\x33\xa3\xd1\xac\xfe\x01\xd2\x92\x4b\xd4\x29\xc6\xd7\x36\xd8\x9e\xfc\xae\xb5\xe1\xb3\xbd\x98\xe1\x4a\x5b\x58\xd8\xcd\x51\x37\xd8\x40\xdd\x1f\x7e\x94\x76\x8f\x4a\xc2\x36\x79\x55\x16\xcb\x35\xdb\xbc\xfe\xc7\x0a\x57\xf9\x83\xee\x70\x58\x9d\x99\xbe\xd0\x7d\xcf\x58\x5a\xe1\xef\x5f\x42\x65\x78\x06\x91\xcf\x03\xf0\x2c\xb4\xd1\xcb\x75\x33\x4e\x81\x7c\xe3\xa9\x75\xb6\x87\x52\x2d\x1c\x6a\x2d\xd4\x11\x8e\x42\x41\x1c\xa6\x26\xf8\x2f\xb2\x61\x72\x71\x44\xe3\xfb\x65\xf0\x7d\xb4\x7d\xee\x9a\x13\x5b\x88\xa2\x24\x49\xf6\x3f\x03\x00\x54\xeb\x49\x06\x57\x01\x00\x00")
+
+func _26_add_notes_searchUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__26_add_notes_searchUpSql,
+		"26_add_notes_search.up.sql",
+	)
+}
+
+func _26_add_notes_searchUpSql() (*asset, error) {
+	bytes, err := _26_add_notes_searchUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "26_add_notes_search.up.sql", size: 343, mode: os.FileMode(436), modTime: time.Unix(1792374167, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __26_drop_notes_searchDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x54\x00\xab\xff\x44\x52\x4f\x50\x20\x49\x4e\x44\x45\x58\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x72\x65\x70\x6f\x72\x74\x73\x5f\x6e\x6f\x74\x65\x73\x5f\x73\x65\x61\x72\x63\x68\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x72\x65\x70\x6f\x72\x74\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x63\x72\x65\x61\x74\x65\x64\x3b\x03\x00\x9f\xab\x88\xb8\x54\x00\x00\x00")
+
+func _26_drop_notes_searchDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__26_drop_notes_searchDownSql,
+		"26_drop_notes_search.down.sql",
+	)
+}
+
+func _26_drop_notes_searchDownSql() (*asset, error) {
+	bytes, err := _26_drop_notes_searchDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "26_drop_notes_search.down.sql", size: 84, mode: os.FileMode(436), modTime: time.Unix(1792374167, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __27_add_report_tagsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x6c\x00\x93\xff\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x72\x65\x70\x6f\x72\x74\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x74\x61\x67\x73\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x72\x65\x70\x6f\x72\x74\x54\x61\x67\x73\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x72\x65\x70\x6f\x72\x74\x54\x61\x67\x53\x65\x74\x73\x3b\x03\x00\xf5\x5f\xab\x41\x6c\x00\x00\x00")
+
+func _27_add_report_tagsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__27_add_report_tagsDownSql,
+		"27_add_report_tags.down.sql",
+	)
+}
+
+func _27_add_report_tagsDownSql() (*asset, error) {
+	bytes, err := _27_add_report_tagsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "27_add_report_tags.down.sql", size: 108, mode: os.FileMode(436), modTime: time.Unix(1792375035, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __27_add_report_tagsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x90\xb1\x6a\xc3\x30\x14\x45\x67\xeb\x2b\xee\x96\x18\xfc\x07\x99\x54\xfb\x39\x98\x2a\x72\x91\x9f\x21\xa1\x74\x30\x8d\x31\x1a\x6a\x19\xc9\x4b\x5b\xfa\xef\x45\x6e\x09\x21\x2e\x25\xf3\xbb\xd2\x3d\xf7\xe4\x86\x24\x13\x58\x3e\x28\x42\x55\x42\xd7\x0c\x3a\x56\x0d\x37\xf0\xfd\xe4\xfc\xcc\xdd\xd0\xf4\x73\xc0\x56\x24\xce\x0f\x60\x3a\xf2\x12\xd2\xad\x52\x30\x54\x92\x21\x9d\x53\x03\xe7\x87\x6e\xb4\x1f\xdd\x6c\xdd\x18\xb6\xf6\x9c\xa2\xd6\x28\x48\x11\x13\x72\xd9\xe4\xb2\xa0\x4c\x24\xef\x7d\xe7\x51\x69\xa6\x3d\x99\xcb\x37\x99\x48\x9e\x4c\x75\x90\xe6\x84\x47\x3a\x61\xeb\xfc\x90\x21\x26\x53\x91\xee\x84\xb8\x07\xf1\x6f\xbe\x7f\x0a\xc7\xee\xad\x5f\x85\xcf\x7d\x78\xf5\x76\x8a\x13\x56\xb7\xc9\x05\xbb\x1c\xee\xa6\xcf\x10\x4b\xd2\x4c\x24\x65\x6d\xa8\xda\xeb\xdb\x75\xd7\xfa\x6e\x64\x5f\x85\x56\x1a\x17\x29\x52\x31\x99\x5f\x27\x3f\x6f\x03\x64\x51\x20\xaf\x55\x7b\xd0\x98\xa3\x92\x38\xe1\xf9\xe5\x02\x8a\x82\x4a\xd9\x2a\xc6\xe6\xf3\x6b\xb3\xfb\x1e\x00\x6f\x2f\x65\x25\xf9\x01\x00\x00")
+
+func _27_add_report_tagsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__27_add_report_tagsUpSql,
+		"27_add_report_tags.up.sql",
+	)
+}
+
+func _27_add_report_tagsUpSql() (*asset, error) {
+	bytes, err := _27_add_report_tagsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "27_add_report_tags.up.sql", size: 505, mode: os.FileMode(436), modTime: time.Unix(1792375035, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __28_create_bundle_keysDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x49\x00\xb6\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x74\x72\x75\x73\x74\x65\x64\x42\x75\x6e\x64\x6c\x65\x4b\x65\x79\x73\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x62\x75\x6e\x64\x6c\x65\x4b\x65\x79\x73\x3b\x03\x00\xed\x9b\x06\x26\x49\x00\x00\x00")
+
+func _28_create_bundle_keysDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__28_create_bundle_keysDownSql,
+		"28_create_bundle_keys.down.sql",
+	)
+}
+
+func _28_create_bundle_keysDownSql() (*asset, error) {
+	bytes, err := _28_create_bundle_keysDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "28_create_bundle_keys.down.sql", size: 73, mode: os.FileMode(436), modTime: time.Unix(1792375507, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __28_create_bundle_keysUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\xd0\xb1\x6a\xc3\x30\x10\xc6\xf1\xd9\x7a\x8a\x1b\x2d\xf0\x1b\x64\x92\xe5\x0b\x98\xa8\x4a\x90\x14\x88\x47\xa5\x16\x46\x10\xa4\x70\x96\x0b\xed\xd3\x17\x15\x4a\x5b\x5a\x3a\x65\xfd\x86\xff\xfd\x38\x69\x50\x38\x04\x27\x7a\x85\x30\xee\x41\x1f\x1d\xe0\x65\xb4\xce\xc2\x75\x4b\xf3\x2d\x1c\xc2\xeb\x0a\x2d\x6b\x32\x2d\xe0\xf0\xe2\xe0\x64\xc6\x27\x61\x26\x38\xe0\x04\x06\xf7\x68\x50\x4b\xb4\x90\x69\xf1\x29\xbe\xf9\x12\x73\x5a\xdb\x38\x73\x38\x6a\x38\x9f\x86\x5a\x97\xc2\x4a\x31\x60\x5d\x06\x54\xf8\xb5\x74\xac\xb9\x53\x7c\xf1\xa5\x9e\x81\x7e\x72\x28\x3e\x04\xfa\xac\x14\xe3\x3b\xc6\xfe\xe1\x15\xda\xd6\x12\xe6\xfe\x6f\xe5\x67\xe5\x21\x44\x4f\x25\x05\xfa\x19\xae\xf4\xed\x7a\x8b\xcf\xbf\xe5\x1d\x6b\xbe\x3f\xa9\xcd\xb4\x74\x70\xf7\x54\x52\x20\xce\xf8\xee\x7d\x00\xc5\x9f\xcf\x97\x74\x01\x00\x00")
+
+func _28_create_bundle_keysUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__28_create_bundle_keysUpSql,
+		"28_create_bundle_keys.up.sql",
+	)
+}
+
+func _28_create_bundle_keysUpSql() (*asset, error) {
+	bytes, err := _28_create_bundle_keysUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "28_create_bundle_keys.up.sql", size: 372, mode: os.FileMode(436), modTime: time.Unix(1792375507, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __29_add_schedule_editUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x56\x00\xa9\xff\x49\x4e\x53\x45\x52\x54\x20\x49\x4e\x54\x4f\x20\x72\x6f\x6c\x65\x50\x65\x72\x6d\x69\x73\x73\x69\x6f\x6e\x73\x20\x28\x72\x6f\x6c\x65\x2c\x20\x70\x65\x72\x6d\x69\x73\x73\x69\x6f\x6e\x29\x20\x56\x41\x4c\x55\x45\x53\x20\x28\x27\x73\x75\x70\x65\x72\x61\x64\x6d\x69\x6e\x27\x2c\x20\x27\x73\x63\x68\x65\x64\x75\x6c\x65\x3a\x65\x64\x69\x74\x27\x29\x3b\x03\x00\xcf\x6d\x7c\x85\x56\x00\x00\x00")
+
+func _29_add_schedule_editUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__29_add_schedule_editUpSql,
+		"29_add_schedule_edit.up.sql",
+	)
+}
+
+func _29_add_schedule_editUpSql() (*asset, error) {
+	bytes, err := _29_add_schedule_editUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "29_add_schedule_edit.up.sql", size: 86, mode: os.FileMode(436), modTime: time.Unix(1792378592, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __29_remove_schedule_editDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x3f\x00\xc0\xff\x44\x45\x4c\x45\x54\x45\x20\x46\x52\x4f\x4d\x20\x72\x6f\x6c\x65\x50\x65\x72\x6d\x69\x73\x73\x69\x6f\x6e\x73\x20\x57\x48\x45\x52\x45\x20\x70\x65\x72\x6d\x69\x73\x73\x69\x6f\x6e\x20\x3d\x20\x27\x73\x63\x68\x65\x64\x75\x6c\x65\x3a\x65\x64\x69\x74\x27\x3b\x03\x00\xa5\xf1\x8c\xc8\x3f\x00\x00\x00")
+
+func _29_remove_schedule_editDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__29_remove_schedule_editDownSql,
+		"29_remove_schedule_edit.down.sql",
+	)
+}
+
+func _29_remove_schedule_editDownSql() (*asset, error) {
+	bytes, err := _29_remove_schedule_editDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "29_remove_schedule_edit.down.sql", size: 63, mode: os.FileMode(436), modTime: time.Unix(1792378592, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var __2_create_matches_tableUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\x8e\x4d\x6a\xc3\x30\x10\x46\xd7\xd2\x29\x66\x69\x83\x2e\xa1\x84\x71\x10\x91\xa5\x20\x4d\x69\xd2\x9d\x2b\x0f\x34\xf8\xaf\xd8\x72\xc1\xb7\x2f\x2e\xf5\xa6\x74\xfb\xde\xf7\xc1\x3b\x07\xd4\x84\x40\xfa\x64\x11\x4c\x05\xce\x13\xe0\xdd\x44\x8a\x30\x34\x39\x7d\xf0\x02\x85\x14\x1d\x6f\x40\x78\x27\xb8\x05\x53\xeb\xf0\x80\x2b\x3e\x94\x14\xfc\xc5\x63\xbe\x1e\x6e\xbf\xba\x17\x6b\x95\x14\x9f\x33\xb7\xcf\x94\xb9\xa5\xe7\xc0\x40\xa6\xc6\x48\xba\xbe\xd1\x9b\x92\xa2\x49\x79\x6d\xfa\x7f\xc4\x7b\xbf\xf2\xeb\x34\xc2\xc9\x7b\x8b\xda\x29\x29\x66\x6e\x63\x9a\x66\x06\xe3\x08\x2f\x18\x7e\x47\x7f\x59\xe5\x03\x9a\x8b\xdb\xa3\x8a\x23\xa9\x84\x80\x15\x06\x74\x67\x8c\xf0\x03\x97\xa2\xe3\xad\x94\xe5\x77\x00\x00\x00\xff\xff\x95\xc6\xa5\x34\xf2\x00\x00\x00")
 
 func _2_create_matches_tableUpSqlBytes() ([]byte, error) {
@@ -544,6 +1051,86 @@ func _2_drop_matches_tableDownSql() (*asset, error) {
 	return a, nil
 }
 
+var __30_add_manual_scheduleUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\xcc\xb1\x0d\x02\x31\x0c\x05\xd0\x9e\x29\xfe\x1e\x54\x0e\x31\x95\xb1\x25\xe4\x0c\x60\x21\x23\x8a\x24\x4d\x02\xf3\xd3\x5e\x7d\x0b\x3c\x12\xe7\x27\x9c\x8a\x30\xf2\x97\x73\x2f\x50\xad\xb8\x99\xb4\x87\x62\xc4\xfc\x46\x47\x31\x13\x26\x85\x9a\x43\x9b\x08\x2a\xdf\xa9\x89\xe3\x1d\x7d\xe5\xf5\x72\x44\x46\xec\xd7\x27\x4f\x28\xff\x01\x00\x01\x1e\x51\x0f\x8a\x00\x00\x00")
+
+func _30_add_manual_scheduleUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__30_add_manual_scheduleUpSql,
+		"30_add_manual_schedule.up.sql",
+	)
+}
+
+func _30_add_manual_scheduleUpSql() (*asset, error) {
+	bytes, err := _30_add_manual_scheduleUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "30_add_manual_schedule.up.sql", size: 138, mode: os.FileMode(436), modTime: time.Unix(1792378749, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __30_drop_manual_scheduleDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x4e\x00\xb1\xff\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x6d\x61\x74\x63\x68\x65\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x6d\x61\x6e\x75\x61\x6c\x3b\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x65\x76\x65\x6e\x74\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x6d\x61\x6e\x75\x61\x6c\x3b\x03\x00\x4f\x8b\x9b\xb4\x4e\x00\x00\x00")
+
+func _30_drop_manual_scheduleDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__30_drop_manual_scheduleDownSql,
+		"30_drop_manual_schedule.down.sql",
+	)
+}
+
+func _30_drop_manual_scheduleDownSql() (*asset, error) {
+	bytes, err := _30_drop_manual_scheduleDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "30_drop_manual_schedule.down.sql", size: 78, mode: os.FileMode(436), modTime: time.Unix(1792378749, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __31_create_match_changesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x64\x90\x41\x6a\xc3\x30\x10\x45\xd7\xd6\x29\xfe\xd2\x06\xdf\x20\x2b\x59\x1a\x17\x35\xb2\x1c\xa4\x09\x38\xdd\x99\x48\x34\x26\xad\x02\x8d\x69\xc8\xed\x4b\x4d\x5b\x1a\xbc\x7d\x33\xfc\x99\xff\x94\x27\xc9\x04\x96\x8d\x25\x98\x16\xae\x67\xd0\x60\x02\x07\xbc\x8f\xf3\xf1\xa4\x4e\x63\x7e\x4d\x57\x94\xa2\x98\x22\x1a\xf3\x14\xc8\x1b\x69\xb1\xf3\xa6\x93\xfe\x80\x2d\x1d\x6a\x51\xa4\xcf\x94\xe7\x6d\xba\x83\x69\xe0\x25\xc3\xed\xad\x85\xa7\x96\x3c\x39\x45\x01\xcb\xc6\xb5\x3c\xa7\x7b\x85\xde\x41\x93\x25\x26\x28\x19\x94\xd4\x54\x8b\x62\x39\xb6\x4a\xa8\x45\x71\x9e\x72\x5c\xc1\xcb\x5b\xc4\x73\xe8\x5d\xf3\x1f\xe6\x74\x5b\xc3\xe3\x47\x1a\xe7\x14\xc1\xa6\xa3\xc0\xb2\xdb\xf1\xcb\xdf\x18\x9a\x5a\xb9\xb7\x8c\x7c\xb9\x95\x95\xa8\x36\x42\xfc\xe8\x30\x4e\xd3\xf0\xfd\xe7\xa3\x83\xdf\x9a\x35\xa6\x58\x6d\xbe\x06\x00\x05\x45\xdb\xbb\x3b\x01\x00\x00")
+
+func _31_create_match_changesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__31_create_match_changesUpSql,
+		"31_create_match_changes.up.sql",
+	)
+}
+
+func _31_create_match_changesUpSql() (*asset, error) {
+	bytes, err := _31_create_match_changesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "31_create_match_changes.up.sql", size: 315, mode: os.FileMode(436), modTime: time.Unix(1792380339, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __31_drop_match_changesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x18\x00\xe7\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x6d\x61\x74\x63\x68\x43\x68\x61\x6e\x67\x65\x73\x3b\x03\x00\x66\x57\x76\xae\x18\x00\x00\x00")
+
+func _31_drop_match_changesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__31_drop_match_changesDownSql,
+		"31_drop_match_changes.down.sql",
+	)
+}
+
+func _31_drop_match_changesDownSql() (*asset, error) {
+	bytes, err := _31_drop_match_changesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "31_drop_match_changes.down.sql", size: 24, mode: os.FileMode(436), modTime: time.Unix(1792380339, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var __3_create_alliances_tableUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x64\x8c\x41\x0a\x83\x30\x14\x44\xd7\xe6\x14\xb3\x34\xe0\x25\x54\xc6\x12\x0c\x09\x8d\x11\xec\xd2\xca\x87\x4a\xd5\x45\xad\x0b\x6f\x5f\xb0\xb4\x14\xba\x9d\x79\xef\x95\x81\x79\x24\x62\x5e\x58\xc2\x54\x70\x3e\x82\x9d\x69\x62\x83\x7e\x9a\xc6\x7e\x19\x64\x45\xaa\x92\xb9\x7f\x0e\xb7\x5a\x76\x44\x76\xf1\xa0\x5c\x6b\x6d\xa6\x92\x71\x2d\xa6\x4d\x50\x78\x6f\x99\xbb\xdf\x67\xd9\xe6\xab\x3c\xfe\x84\xca\x07\x9a\x93\x43\xcd\x4b\xfa\xa9\x6a\x04\x56\x0c\x74\x25\x1b\x1c\xa3\xac\xe9\x5d\x76\x9d\xa9\xa4\x75\xe6\xdc\xf2\x8b\x66\x78\x77\xb5\xd2\xaf\x00\x00\x00\xff\xff\x80\x51\xbd\xfc\xbc\x00\x00\x00")
 
 func _3_create_alliances_tableUpSqlBytes() ([]byte, error) {
@@ -824,26 +1411,6 @@ func _9_remove_reports_cascadeDownSql() (*asset, error) {
 	return a, nil
 }
 
-var _bindataGo = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd4\x5d\x5b\x6f\x1b\x39\x96\x7e\xb6\x7e\x45\x4d\x80\x19\xd8\x8b\xac\x53\xf7\x4b\x80\x7e\x99\xee\x5e\xa0\x1f\xb6\x07\xd8\xe9\x79\x5a\x2e\x0c\x16\x8b\x95\x16\x46\x96\xdc\x92\xdc\xcd\xa4\x91\xff\xbe\xf8\x78\x0e\x6d\x5d\x8a\x54\xb9\x22\x58\xc9\x83\x12\x4b\x55\x3c\xe4\x61\x9d\xef\xdc\x78\xc8\x7a\xf7\x2e\xfa\x7e\xd5\xe9\xe8\x83\x5e\xea\xb5\xdc\xea\x2e\x6a\x3f\x46\x1f\x56\xff\xd9\xce\x97\x9d\xdc\xca\xdb\xd9\xbb\x77\xd1\x66\xf5\xb8\x56\x7a\xf3\x1e\x7f\xc7\x77\x6a\xad\xe5\x56\xdf\x3d\x6e\xf4\x7a\x73\xb7\x95\xed\x42\xdf\x3e\x3e\xdc\x6e\x7e\x5b\xd0\xe5\x6e\xbd\x7a\xd8\xbb\xd8\xad\xfe\x58\xba\xcb\x49\x7c\x27\xbb\xee\x6e\xad\x1f\x56\xeb\xed\xe6\xae\xd3\x0b\xbd\xd5\x77\x4a\x6e\x94\xec\x76\xc9\x24\xf1\xdd\x5a\xdf\xaf\x7e\xd7\xbe\x5b\xf7\x88\x26\x96\xe8\x42\x6e\xef\x16\xab\xe5\x87\x5d\x32\x09\x0d\xe7\xe9\xd2\x5e\xb3\xd4\x36\xd3\xcb\xee\xae\x03\x43\x6b\xbd\x94\xf7\xfa\x6e\xb3\x95\xeb\xad\xfd\x65\x97\x50\x4a\x84\x02\x37\xef\x91\xce\x2c\xe9\xe5\x6a\xab\x37\x77\xfd\x5c\x2f\xba\x5d\x5a\x99\xe3\x6d\xf7\xfa\x5e\xf3\xdc\x36\xff\xb8\x7a\xdc\x3e\xb6\xfa\xee\x71\xbd\x38\x9a\xe7\x24\x77\x44\x76\xee\xda\x27\x52\x58\x22\xf3\xcd\x5d\xbb\x78\x7c\x9a\xc7\xc3\x5b\x98\xc8\xe1\x5d\x3b\x1d\x95\xee\x81\x3f\xcc\xd5\xbf\x17\xf3\xcd\x96\xc6\x72\x70\x8f\x9d\x9d\xc3\x3b\xf6\xfa\xaa\xdc\x70\x7e\xd7\xeb\x79\x3f\xd7\x7b\x53\x52\xed\x0c\xe4\xe9\xfa\x5e\x73\x37\x0a\xfd\xbb\x5e\x6e\x8f\xe5\x8e\x1f\xf4\xde\xd5\xdd\xf6\xa9\x6b\x7f\x2f\xb7\xea\x57\x7d\x4c\x80\x1f\xf0\xfe\xe5\x5d\x0a\x99\xa3\x20\x17\x8b\xb9\x5c\xaa\x01\x1a\x19\xd1\x38\xbc\x61\x97\x4a\xee\xa8\x38\xb9\x3e\xa4\x91\x13\x8d\xfd\xcb\xbb\x14\xe8\xb1\x5a\x46\xef\xb6\x1f\x1f\x76\xdb\x3e\x3d\xce\x9d\xab\xbb\x4d\x4b\xdb\x14\x0f\xfa\x8f\xd5\xf2\xe0\x0a\xb7\x74\x17\x9f\x89\xd2\x73\x7b\xf8\x75\xb5\x5d\x1d\x8f\xf6\xe9\xb9\xed\x5d\xdf\xa5\x5c\xf3\x63\xef\xee\xe7\xbb\x54\xeb\xe7\x07\x2e\xed\xa5\xdd\x36\xcd\x9e\x92\x38\xd6\x0e\xcd\xa1\x72\x18\xd2\x0a\x4e\x81\x7d\x58\xe1\xdb\x0f\xff\x88\x7e\xfe\xc7\x2f\xd1\x8f\x3f\xfc\xf4\xcb\x5f\x66\xb3\x07\xa9\xfe\x2d\x3f\xe8\xe8\x7e\xfe\x61\x2d\xb7\xf3\xd5\x72\x33\x9b\xcd\xef\x41\x2b\xba\x9e\x5d\xbd\x69\x3f\x6e\xf5\xe6\xcd\xec\xea\x8d\x5a\xdd\x3f\xac\xf5\x66\xf3\xee\xc3\xa7\xf9\x03\x7e\xe8\xef\xb7\xf8\x6f\xbe\xa2\x7f\xdf\xcd\x57\x8f\xdb\xf9\x02\x5f\x56\xb6\xc1\x83\xdc\xfe\xfa\xae\x9f\x2f\x34\xfe\xc0\x0f\x9b\xed\x7a\xbe\xfc\x60\xaf\x6d\xe7\xf7\xfa\xcd\xec\x66\x36\xeb\x1f\x97\xca\x0d\xef\x7f\xb4\xec\xae\xf1\x47\xf4\xbf\xff\x87\x6e\xdf\x46\xd0\x2b\x11\x35\xbb\x89\xae\xdd\xaf\x7a\xbd\x5e\xad\x6f\xa2\x3f\x67\x57\x1f\x3e\xd9\x6f\xd1\xfb\xef\x22\x8c\xea\xf6\x67\xfd\x07\x88\xe8\xf5\xb5\x1d\x36\xbe\xff\xfd\xb1\xef\xf5\xda\x92\xbd\xb9\x99\x5d\xcd\x7b\xdb\xe0\x2f\xdf\x45\xcb\xf9\x02\x24\xae\xd6\x7a\xfb\xb8\x5e\xe2\xeb\xdb\xa8\xbf\xdf\xde\xfe\x08\xea\xfd\xf5\x1b\x10\x8a\xfe\xfa\xdb\xfb\xe8\xaf\xbf\xbf\xa1\x91\xd8\xbe\x6e\x66\x57\x9f\x67\xb3\xab\xdf\xe5\x3a\x6a\x1f\xfb\x88\xfa\xa1\x4e\x66\x57\x77\x34\x9c\xef\xa2\xf9\xea\xf6\xfb\xd5\xc3\xc7\xeb\xbf\xb5\x8f\xfd\xdb\xe8\xc3\xa7\x9b\xd9\x95\x5a\xfc\xe8\x46\x7a\xfb\xfd\x62\xb5\xd1\xd7\x37\xb3\x73\x8d\x07\x64\x88\xbe\x87\x90\x5e\xaf\x69\xdc\xfc\x63\xfb\xd8\xdf\xfe\x1d\x43\xbf\xbe\x79\x8b\x3b\x66\x9f\x67\x33\x40\x24\x92\x9b\x8d\xde\x62\xca\x1f\xd5\x16\x54\x2c\x7f\xfc\x3c\x66\x57\xf3\x65\xbf\x8a\xa2\xd5\xe6\xf6\xbf\xe6\x0b\xfd\xd3\xb2\x5f\x3d\xb5\xe3\x47\xe8\x7e\xdf\xa1\x60\x9f\x61\x14\xf1\x63\x9c\x5d\x6d\xe6\x9f\xec\xf7\xf9\x72\x5b\xe6\xb3\xab\x7b\x18\xdc\xe8\x89\xe8\x7f\xaf\x3a\x6d\x7f\xfc\x65\x7e\xaf\x23\x88\xc9\x2d\xfe\x42\x3f\x56\x54\xae\xfb\xf9\x61\x5f\x37\xd1\xcf\xf2\x5e\x5f\xdf\x70\x0f\xe8\x93\xb9\xec\xe7\xb7\xe8\x7d\xf6\x39\xd0\xf6\x9f\xf3\x4f\x68\x6b\x47\xb3\xdf\x14\x03\x0d\x36\xc5\x58\xaf\x6f\x76\x47\xbe\x4f\x00\xac\x9d\x22\x00\xe6\xae\x6f\x9e\x19\x3d\xa2\xc0\xdc\xfb\x89\xfc\xb4\xf9\x61\xbe\xbe\xbe\x89\xda\xd5\x6a\xb1\xdb\x5a\x2e\x36\x27\x38\xff\xb8\x21\xc6\xf5\xba\x97\x4a\xff\xf9\x79\xa7\x35\x8b\x04\xa4\xfc\xee\x6e\xc8\xcd\xf9\xd7\xc3\x3f\x7f\x5b\x44\xdf\xb1\x64\x5c\xbf\x11\x26\xe9\x85\xa9\x5b\x61\xe2\x5a\x98\x38\x1e\xfe\xf4\xbd\x30\x55\x2a\x4c\xac\xe9\xff\xaa\x10\x26\x56\xc2\x54\x89\x30\x45\x41\x6d\xf1\x77\x95\x0b\xd3\xa7\xcf\xbf\xf7\x31\xfd\x56\x64\xf4\x77\x9f\x09\x13\xf7\xc2\x14\xb8\x37\x16\xa6\xee\xe8\x77\xd0\x02\xed\xb8\x11\xa6\x28\x85\x49\x6b\x61\xd2\x4e\x98\x5c\xd3\xff\xa9\xa4\xdf\xbb\x58\x18\x8d\x0f\xc6\x92\x0b\x93\x25\xd4\x5f\x82\x3e\x33\xea\x27\x29\x84\xc9\x53\x61\x0a\x25\x4c\x9a\xd1\xdf\x2a\x26\x1a\xbd\xa2\xef\x55\x27\x4c\xa5\x84\x49\x72\xfa\xde\x77\xc2\x64\x18\x03\xee\x07\x9d\x8e\x78\x6c\x6b\x61\x32\x7c\x32\xea\x03\xf3\x84\xbf\x8b\x94\xfa\x8a\xd3\xe7\xdf\xf1\x51\x2d\xf1\x88\xbf\xed\x3d\x31\xcd\x03\xe6\xa3\x6c\xe8\x7e\xfb\xd9\x99\x57\x7c\x74\x22\x4c\x03\xfe\x2a\x61\xba\x54\x98\x32\xdd\x9d\xff\x37\x4e\xdf\xfa\x9f\x28\xeb\x84\x21\x5d\xeb\x34\xc7\x8e\xae\x9e\x5d\x5d\x05\xa4\xe3\xed\xec\xea\xea\x4d\xc0\x45\x7e\xf3\x76\x76\x75\xf3\x04\x6c\x3f\x1d\x0c\xe7\x3f\xac\x5e\xda\x1d\x8e\x55\x4c\x4f\xda\xff\x24\x47\xa7\x34\xed\x93\x82\xb4\x2a\xee\xfd\x77\x87\x70\xf9\x13\x8a\xe4\x7d\x14\xe6\x27\x82\xca\x78\x1f\x35\xf5\xdb\x08\xd8\x7f\xbf\xab\x1a\xae\xf3\xac\xbc\xb1\xbf\x03\xd1\xef\x09\xf1\xff\x5a\xce\xcd\x75\x52\xa4\x49\xd1\x24\x59\x5e\xbe\x8d\xe2\x9b\xcf\xb3\x2b\x89\xfe\xff\x66\x39\xfe\xd3\xb2\xf9\x3e\x62\x6e\x31\xb8\xf7\xf6\xdf\xcf\x4f\x0f\x44\xbe\x3d\x44\xeb\x61\xd4\xf1\xc3\xea\x8f\xe5\x17\xa1\xb5\x21\x74\x58\xc4\xc5\x7e\x94\x0e\xa1\xad\x2d\x85\x89\x4b\x42\xd9\xa1\xb4\xb6\x9d\x30\x65\x27\x8c\x2a\x08\xc1\x49\xe2\x93\x56\x0f\x47\x93\xa4\xd5\x43\x8b\xa5\xd5\x1b\xb1\x1d\x49\xab\x87\xce\x48\x69\x0d\x73\x74\x3e\x69\x0d\xf0\xc3\xd2\x9a\x54\x97\x94\xd6\x60\x10\x3c\xdd\xc8\x94\x8a\x94\x28\x44\x4c\x4a\x52\x96\x50\xf2\x50\xd4\x0a\x62\x0b\x85\xaf\x85\xc9\x34\x89\x5f\x93\x0b\xd3\x42\xf1\xf6\x64\x50\x64\x2b\x4c\x99\x09\xa3\xb4\x30\x12\x06\x40\x09\x53\x97\xc2\x94\x30\x16\x8d\x30\x35\x3e\x85\x30\x5d\x41\x4a\x16\xa2\x9b\xb4\x44\xb7\x86\xf2\x87\xb8\xb7\xc2\x74\x80\x8c\x16\x26\x29\x85\x29\x1a\x1a\x97\xfd\x94\x64\x80\xe2\x4a\x98\xaa\xa7\x76\x4d\x43\x7d\xa0\x2f\xd0\xca\x7b\x61\xba\x9c\x20\xd8\xf6\xd4\x57\x5e\x11\xfd\x42\x0a\xd3\x28\xa2\x81\xfe\xf3\x5a\x98\xae\xa1\xf1\x59\x83\x0a\xc8\xc2\x70\x36\x64\x48\x64\xc1\xbc\x54\x64\xe0\xec\xf5\x8e\xa1\x08\xc8\x4a\x61\xf2\x82\x8d\x5e\x26\x4c\x96\x0b\x53\x6b\x61\x3a\x18\xa4\x58\x98\xa4\x23\xf8\x77\x9d\x30\x0d\x0c\x20\xe6\x21\x26\x63\x8b\x7b\x01\x71\x85\xb1\xe5\xc2\x94\x95\x30\x29\xe6\xbe\x20\xe3\x87\xe7\x80\x39\xa8\x7b\x61\x1a\x36\x4c\x30\xa8\x68\xa3\x31\xb7\x95\x30\x0d\xfa\x55\x64\x24\xf3\x86\x54\x08\x9e\x09\x9e\x35\xd4\x4a\xd6\xd1\x1c\xf4\xec\x3c\x1c\xaa\x12\x3c\xb7\x38\x11\x26\x87\xf1\x63\x3e\x87\x54\xc9\x69\x71\x9b\xa0\x53\x4e\x13\xb5\xca\x65\x4c\xbe\xe7\x40\xcb\x9c\xa6\x3c\x4a\xdd\x8c\xe6\xfa\x5c\x7a\x67\x1c\xab\x4e\x01\x95\x97\xd6\x40\xc1\xf4\xda\x97\x5a\x4f\x40\x05\x16\x14\x56\x32\xee\xc8\x9f\x0b\x59\x50\x40\x11\xd6\x53\xc1\x9a\xf6\x64\x45\x53\xf6\x67\xa1\x9a\x0e\xad\x31\x7e\xeb\x4b\x61\xfa\x8a\x54\x8e\xf3\x7f\x5d\xdf\x3d\xab\xa2\x3a\x21\xb5\x05\x98\x02\xe2\xba\x26\xf5\x22\x71\x5f\x2d\x4c\x9b\x13\x1c\xf1\x1b\xc6\x50\x02\x7a\x9a\xd4\x15\xd4\x0d\x54\x00\xfc\xd9\x82\xfd\xdd\x4e\x91\x1f\x6c\xd5\x91\x22\x88\x42\x3d\x40\x9d\xe0\x7a\xab\xe8\x7e\x40\x3d\x83\x9a\x8c\x89\x0e\xd4\x05\xd4\x4c\xcd\xbe\x75\xc7\x34\x1d\x1d\xb4\x6f\xb8\x6d\xed\xfc\x70\xc9\xbe\x30\xfb\xc4\xb5\x24\x9a\x79\x4c\x2a\x0f\xea\x05\x6a\xb0\xd4\xa4\xee\x65\x49\xf4\xa1\x32\x9a\x9e\x7c\xf3\xb2\x26\x55\x56\xb3\x6a\xaf\x6b\x52\xf3\xf6\xbe\x8c\x7e\x6b\x63\xfa\x5b\x72\x3f\x50\x63\x69\x7c\xac\x6e\xba\x92\x54\x2a\x54\x35\xcc\x09\xd4\x97\x47\xdd\x8c\x91\xad\x69\x3a\x67\x0c\x65\xa7\x78\xc6\x25\x90\x8f\xb5\xcf\x98\x3e\xc6\xaa\xa0\x17\xcc\xc4\x19\xf5\xd0\x58\xce\x9d\x32\xca\x9b\x8b\x2a\xa3\xfd\xf4\xfd\x97\x45\xd9\x2f\xd1\x3c\xb9\xf3\xdd\xe1\x04\x75\x2c\xf9\x3b\x5a\x07\xf7\xdb\x7b\x93\x03\x8d\xe3\x1c\x8d\x82\xd0\xa9\xd8\x80\x03\x19\xb8\xd7\x69\xb4\x1c\x1a\x4d\x09\xd3\x26\xc2\x68\x38\x39\xa9\x30\x32\x26\x0d\x93\xf5\xa4\xe9\x12\x76\xd6\x92\x98\x3e\x87\xa8\x83\xc3\x85\xf6\x45\x4e\xce\x54\xe1\x89\x17\x86\x26\x71\x0a\xc4\x06\xc8\x10\x9e\x06\xd7\x58\x0e\xc1\x33\xd0\x7a\x1c\x52\xfc\xa3\x3f\x1b\x2c\x3c\x0c\x30\x06\xea\xe4\xc2\x10\xd8\x5b\xa7\x7a\x4d\xfb\x7b\x0a\x05\x41\xdb\x1b\x40\xc2\x9e\xd4\xe7\xc2\xa4\xe5\xb3\xd4\x3b\x97\x1a\x36\x7a\x48\xea\xe1\x32\xf7\x9c\xa3\x6a\x32\xa2\xe9\x91\xfa\xa1\x79\x9b\x26\xf8\x43\x94\x9c\xec\x0f\xaf\x22\x1e\x8b\xff\x10\x8d\xb1\x08\x08\x70\x72\x46\x10\xf8\x38\x61\x1c\x54\xd9\x45\x71\x70\x6a\x49\xf6\xdb\x33\x0e\xa0\xa3\x98\x0e\xee\x77\xf0\xc0\xff\x2e\xfd\x6b\x23\xf3\x9c\x53\xc3\x1d\xa7\x8a\xf9\x37\xfb\xbd\x26\x37\x16\xe3\x3d\x4c\x05\xdb\x14\x70\xcb\x7d\x26\xcf\xb0\x8b\xd3\xe1\x04\x14\x5c\x51\x7c\xd2\x84\x32\x05\x36\x75\x3b\x04\xad\x51\x8f\x62\x0a\xd2\x46\x11\x26\xe0\x8d\x5c\xa1\x3f\xc4\xe1\xa8\x1e\xc6\xc1\xf2\x25\xb3\x70\x36\x94\x8e\x66\x9b\x41\x5b\xc6\x17\x06\xed\x89\xda\x88\x6f\xd5\x9c\x85\xb0\xdb\x16\x61\x9c\x15\xec\xdc\xc1\x24\xa2\xbf\xb4\xf6\xe2\x6c\xdc\xec\x4d\x83\xda\x38\xda\x0e\x6d\x63\x6b\x5c\x8e\x01\x37\xae\x9f\xb1\x98\x7b\xd1\x8c\x9c\x11\x76\xe3\xf9\x67\xe4\xe5\x97\x45\xde\x51\x99\xd1\xeb\xd9\xc7\x31\x69\x9b\x97\x04\x50\xaa\x25\x1a\x16\x63\x1d\xd1\x70\x7d\xd7\x6c\x03\xe3\x64\x18\x6b\xbd\x66\xbb\x58\x09\xd3\x56\x34\x86\x41\xac\x0d\xcf\xd7\x14\x64\x0d\x53\x22\x1c\xf9\x8a\xbf\x0e\x51\x33\x4c\x63\x1c\x46\x82\x9c\x9c\x0d\x11\x7e\x4e\x9c\xfc\x5f\x36\x6c\x1a\xaa\xa4\xfb\xa6\x72\x97\x23\x80\xe0\x5b\x49\xec\x1a\x72\x18\x11\x57\x29\xdc\xeb\xcb\xc7\x05\x26\x69\x9a\xe4\x7b\xc9\x39\xf1\x0f\x94\x37\x1e\x63\xc0\x4b\x6d\x2c\x10\x4e\x71\x77\x46\x34\x04\x19\x63\x48\x64\x97\xcd\xec\x7b\x4a\x47\x5f\xcf\x30\xd8\x1c\x40\xf7\xec\x28\x29\xfd\xec\x94\xbd\xd4\x30\xc8\x9a\x32\x68\x16\x57\x1d\xe1\x2a\xe7\xa5\x3b\x1b\x18\x75\xd4\xb7\xcd\x8a\x8d\x34\x16\x0d\xbe\x4b\x5a\x7a\x6b\x35\x65\xe3\x06\x71\x13\x9a\xc9\x29\xc0\x09\xd1\x23\xe4\x84\xcb\x7e\x0f\xa1\x13\xa2\x37\x0e\x3b\x23\x38\x3c\x1b\x78\x4e\xf1\xe6\x0c\x4a\x79\x61\xf4\x1c\x57\x55\xbf\xa6\x41\x19\x03\x9e\x97\x18\x94\xb1\x00\xf2\x81\x25\x29\x69\x0d\xde\x16\x77\x29\x32\x6e\x1e\xb0\x78\x27\x6e\x1a\x56\xbc\xe4\x1c\x54\x02\xe5\xef\xc7\x48\xf1\x52\x1b\x0b\x94\x53\xdc\x9d\x11\x27\x41\xc6\x1c\x4c\xd2\x8b\xc2\x64\x70\x6b\xc1\x97\xe0\x04\xb2\xa7\x62\x61\x54\x42\x32\x5c\xc7\x5c\x6b\xa1\x84\x89\x0b\x2a\x5e\xb4\x45\x91\x9a\x64\x50\x36\xa4\xe8\x65\x25\x4c\xd9\x53\x14\xde\x70\x9d\x84\x8e\x85\x91\x09\x2f\xb6\xc6\xf4\x49\x24\x25\x9d\xbb\x9e\x6a\x2f\x62\x5e\x84\x4d\x12\xc2\x23\x70\xd2\xd4\xb4\x10\xdb\x36\xc2\xe8\x4a\x98\xa6\x25\x63\x51\x48\xca\xaa\x65\x35\xd5\x86\x94\x25\x15\x37\xb6\xa9\x30\xb5\x12\x46\xf6\xb4\x78\x8a\xdf\xac\x13\xc7\x0b\xbf\x89\xa6\xc5\xd5\xbc\xa4\x05\x67\x2d\x69\xec\x18\x77\x9d\x50\x16\x2f\xe1\x1a\x16\x3b\x07\x43\x89\xf0\x96\x8a\x1b\xeb\x92\xca\xca\xf2\xdc\x83\xbf\xd0\x13\x99\x02\xc0\x10\x3d\x42\x60\x78\x7b\xc9\x21\x04\x43\xf4\xc6\x61\x70\x04\x87\x67\x03\xe1\x29\xde\x5c\xde\xed\xa2\x45\x8f\xde\xdd\x3b\x5f\x57\x12\xe0\xa5\xf1\x0f\x4c\x5f\x85\x98\x46\x3e\x9b\x2b\x5d\xf8\x13\x6d\x80\x98\xad\xdf\xc8\xb9\x7f\x5f\x19\x54\x70\xba\xa6\x61\x24\x40\xd0\x81\x24\xb8\xc1\xea\x18\x25\x01\x8a\x63\x61\x72\x9a\xcb\x33\xe2\xe4\x04\x7b\x2e\x26\xba\x6c\x81\x81\x6f\x0b\xdb\x74\xa0\xd4\x39\x09\x33\xec\x15\x00\xa0\xb8\x36\xd0\x15\x01\xf5\x5c\x0f\xa8\x4b\xb2\x2b\xb0\x67\x39\xdb\xaa\x46\x93\x2d\xe8\xd9\x1e\x94\x09\x65\x98\xad\xaf\x95\x91\x90\x77\xfc\x37\xc0\xa7\x15\x15\x2f\xa9\x54\x98\x56\x52\xb0\xd3\x48\x02\x63\x59\x70\xb6\x59\xd3\x6f\x9d\xa4\xcc\x74\x5e\xf0\xd8\x5a\x2e\xfa\x2f\x28\x93\x0d\x5f\xd0\xda\x14\x67\x3f\x13\xaa\x4d\x4c\x52\x2a\x7a\xaa\x1a\xce\x5c\x67\x54\x72\x00\xdb\xdb\x76\x5c\x20\x14\x73\xf9\xb2\xa4\x7b\x6b\x5e\x85\x72\x8a\xa3\x52\x64\xd3\xb2\x84\xfa\xaf\xeb\xe7\x82\x7d\x5c\xab\x2b\xe2\xb5\xe7\xda\x4a\x5b\xf0\x54\x52\xcd\x67\xad\x85\xc9\x52\xe2\xb7\x6d\x89\x4f\x55\x92\xdf\x09\x45\x23\x0b\xae\x4f\xed\xb8\xb6\x54\xd3\xff\x59\x43\x0a\xc5\xda\xf5\x92\x6a\x5b\x41\xb7\x74\xa5\x13\x8a\x92\x23\x99\xa2\x6b\x15\x67\xf5\x8b\x96\x82\x3f\xf8\x1c\xe0\xbf\x63\x7a\x65\x43\xf3\x8e\xa0\xb0\xcd\x49\x99\xc0\x07\xb7\x09\x97\x8c\x8a\xc6\xe0\x83\x24\x0d\xdb\xfb\x9e\xe6\xb4\xe3\x5a\x53\xf8\x0b\x95\x24\x85\xd4\xd5\x44\x1b\x73\x07\x19\xc0\xb3\x4a\xb9\x56\x15\x3c\x95\x39\x8d\x05\x63\xb5\xfe\x47\x43\x7d\xc2\x97\xc8\x78\xd5\x20\x67\x9f\xa7\xe4\x3a\xdc\x96\xeb\x55\xed\x7c\xd6\x54\x90\x06\xb9\xc2\x9c\x83\x1f\x9d\xd1\x1c\x17\x0d\x29\x53\xcc\xa5\x2b\x76\x83\xaf\x04\xbf\x23\xe3\x3a\xd8\x8e\xeb\x7b\x21\x87\xa5\xa6\x76\x0d\x17\xaf\x59\x65\xdf\xb2\x8c\x72\x4d\x2f\x78\x51\x5c\xd3\x0a\x59\x84\x82\xc6\x9c\x60\x4c\xb6\xb6\x37\xa1\xfe\xba\x84\x3e\x45\x35\xec\xd3\xf4\x0d\xc9\x7a\x55\x91\xff\xd3\xb8\xf8\xe3\x50\x61\x07\x61\x3b\x45\x61\x07\x09\x92\xc2\x3e\xb1\xdb\xf5\x50\x61\x07\x29\x8e\x53\xd8\x63\xb8\x3c\x9b\xc2\x3e\xc9\xde\xd3\xba\xc6\x8b\x03\x8c\xbc\xc9\x8b\xb4\x2c\xce\xa5\xb1\x87\x36\x14\xbf\xe6\xa6\x0e\x1b\x89\x2b\x92\x78\xfb\xe1\xb5\x3f\x68\x06\xb8\x25\xf0\xc6\xa1\xdd\xf3\x94\xb4\x25\x5c\xa0\xd0\xc6\x0f\xdd\x50\xb4\x90\x3a\xeb\xe0\x73\x53\x82\xac\x4f\x93\xfa\x00\x41\x27\xf5\xc1\xfd\xdb\xc7\x52\x1f\xa0\x38\x56\xea\x4f\x73\x79\x46\xa9\x3f\xc1\xde\x64\x37\x25\xab\x8b\x34\xaf\xcf\xe4\xa6\x1c\xed\x90\x9f\xee\x9f\xb8\x78\xba\x63\x27\x1e\xb6\x30\x63\x89\xdf\x8d\xa7\x35\xc7\xd3\x3d\x17\xf4\x42\x32\xab\x82\xee\x57\xec\xe3\x40\x52\x93\x98\x0a\xb1\x7a\xde\xd7\x61\x6d\x74\x4f\xb6\x07\x3a\x1c\x36\xcf\x15\x7a\x15\x5c\x85\x02\xbb\x01\x1b\x90\x27\x74\x5d\x55\xb4\x67\x01\xf6\x07\x71\xba\xca\x78\x9f\x49\x4b\x9b\xf7\x2a\xde\x23\x02\x5b\x5c\xd6\x34\xb6\xc2\x15\x81\xd5\xb4\x6f\x04\x7e\x07\x6c\xac\xae\xc9\x36\x35\x15\xf9\x39\xe0\x15\x63\x42\xe0\x50\xe9\x5d\x7b\xb2\xb3\x22\xcf\xe5\x97\xf0\x5b\x34\xdb\xd6\x41\x04\x0e\x3f\x87\x29\xd0\x1b\xa6\x44\x98\xf3\x9d\x87\x70\x08\xb6\x61\x1a\xe3\x50\x16\xe4\xe4\x6c\xf0\xf2\x73\xe2\xe2\xe4\x4b\xe3\xea\xf8\x70\x89\xd7\x5e\x25\x1c\xda\x91\x3b\x35\x3a\x06\x78\xe0\xd8\xdb\xa8\x9b\x57\x32\x54\x4b\xff\x23\x5a\xf6\x25\x73\x5b\xde\x1d\x00\xc7\xd1\xd5\x29\x7b\x00\xe0\x9d\xb0\x69\x28\xf0\x92\x73\x50\x08\x9c\xfe\x71\x8c\x07\x2f\xb5\xb1\xa0\x38\xc5\xdd\x19\x91\x11\x64\x6c\x72\x11\xc9\x59\xe1\x31\x74\xb2\xca\x74\xcb\x83\x48\x0b\xf7\x59\x41\x94\x14\x29\x64\x5c\xdc\x6e\x77\x0f\x56\x14\xe9\x40\xa0\x11\x9d\x49\xde\x72\x6e\x8b\xe1\x13\x8a\x92\xec\x8e\xba\x98\xa2\x52\x08\x3a\x00\x52\x73\x54\x5c\x74\x9c\x25\x4e\xc8\xb2\xc0\x82\x94\x4e\xab\xc3\x32\x74\xa4\xd9\xf3\x9c\x6a\x41\x3a\xde\x29\x07\xdf\x4c\xba\x28\x28\x25\x2b\x91\xf0\x56\x1b\x44\x3e\x35\x5b\xa0\x94\x97\x27\xc1\x67\xc3\xd1\x16\x00\x89\xc8\xac\x75\xb5\x29\x5c\x8f\x69\xa3\x1d\x2e\x63\x46\xc4\x87\xe8\x0a\x96\x09\x63\x46\x64\x87\x88\x0c\x3e\x1f\x22\x41\xdd\xd2\xff\x68\x87\x08\x5c\x72\x86\xb9\xea\x28\x22\xd2\x15\x81\x59\xb1\x45\xcd\xb8\xe6\x0c\xc0\xd6\x2e\x0a\xac\xe9\xde\x12\x11\x9f\xa4\xeb\x68\x27\x1b\x8a\x48\x5d\x34\x55\x70\x54\x87\x31\x21\x22\x87\xb2\x42\xd4\x66\xb3\x05\xdd\xf0\x6e\x42\x28\x1f\xf0\x02\x1e\xec\x16\xff\xce\xa3\x1c\xbc\xe2\x32\x45\x35\x78\x89\x91\x62\x08\x1c\xfa\x73\xa8\x16\xbc\x94\xc6\x29\x85\x53\x5c\x9d\x4d\x25\x04\x59\x72\x1b\x72\xd2\xe2\xa2\xf9\xb2\xe3\xb3\x94\x5e\x2b\xf2\xf2\x55\x6f\xfa\xa2\x2a\xf8\xa6\x40\x47\xcd\xfb\x80\x7d\xdb\xe9\xfd\x2c\x4d\x92\x5a\x1f\x31\x96\x5a\xff\x51\x54\x47\x52\xeb\xa3\x34\x52\x6a\x4f\x70\x75\x3e\xa9\x0d\xb1\xf4\x55\x6c\xaa\x1f\x3e\xe0\xeb\xcb\x76\xd3\xd7\x9a\xec\x58\x29\x29\x5a\x71\x76\x2c\xdf\x39\x6e\x04\xd2\x69\xb3\x73\x0d\xd9\xa0\x54\xf3\xea\x23\x97\xb8\xd8\xc8\x29\xe1\xad\x93\x31\xd3\x6b\xa8\x6d\xc3\x12\x1e\x73\x5d\xbe\xcd\xd2\xf5\x64\x27\x80\x1a\xd8\x39\x5b\xcf\xd8\x13\x5d\x38\x82\xb2\x64\xd4\xb4\x14\x29\xf5\x1c\x01\xc1\x5e\x20\x42\xb2\x76\x28\xa7\x6d\xa2\x36\xb3\xc7\xa8\xc8\x79\x25\x55\xb1\xdd\x83\xad\xec\x3a\xb2\x93\x76\x6b\x69\xcc\x7b\x03\x1a\xb6\x49\x31\x65\x6f\x61\xb3\x93\x9c\x32\xb6\x65\x4f\x74\xab\x9a\x90\xd7\x72\x34\x09\x5b\x0e\xfb\x86\x36\x75\x4c\x3c\x65\xbc\x9b\x1d\x6d\x7b\xc5\xab\xa2\x19\xd9\xc4\x44\x53\xc6\x11\xce\x68\x2b\x85\x49\x2a\xda\x7a\xdb\x70\x5f\x4d\x4f\x47\xc5\xb4\x1c\x31\x36\x6c\x7b\x31\x87\xd6\x4e\xc6\x34\x0e\xcc\x85\x2a\xd9\xd6\x4a\xa2\x8d\x88\xd7\x66\xb8\x53\xca\x7c\xe6\x0d\x65\xb5\xcb\x8e\xe6\xa3\xea\x29\xb2\xad\xda\xe7\xdd\xf5\x18\x3b\x78\xb7\x59\xcd\x86\xae\xd5\x6c\xdb\xf1\x7c\x9f\x9e\xb1\x26\x1e\x1a\x49\xdf\xed\x6e\xfe\x8c\x64\xc9\xfa\x2f\x35\xf9\x15\x39\x9f\x70\x50\x34\xbc\x74\xc5\x6d\x2c\xcd\x96\xb7\xda\x4a\xca\xb4\xda\x95\xec\x9c\x4f\x1c\x28\x88\x66\xc5\x27\x0a\xc0\x77\xb0\xcf\x21\xa3\x48\xd7\xae\x22\xa3\x4d\x47\x73\x02\xda\x95\x27\xcb\x8a\xb9\x54\xbc\x65\x37\x63\x2d\x3b\xa4\x19\x03\xb8\x99\xa0\x1a\x03\xd4\xac\x6e\x0c\x1e\xc3\x77\xa0\x1c\x03\xb4\x46\x69\xc7\xd3\x9c\x9d\x4b\x3d\x9e\xe0\x8a\xf5\x63\x7a\xd9\xa2\x8d\xa1\x03\x0e\x5f\xcb\xac\x87\xca\x9a\xbc\x09\x53\xbe\x86\x20\x20\x2b\xe8\xff\x61\x01\xf6\xf2\x35\x49\x80\xbd\xd4\x58\x80\x03\xc7\x44\x1e\x09\xb0\x97\xd6\x48\x01\x3e\xc5\xd9\xf9\x04\x38\xc8\x95\x33\xf0\x17\x2d\x77\xf0\x9d\xbf\xf9\x05\x26\x3e\x27\x15\x8b\x30\x12\xa1\x6a\x9d\x3d\x2f\xe2\xc2\x2c\xc2\xc4\xeb\x92\x17\x75\xf9\x80\x18\x98\xcd\xb4\xa0\xc4\xa4\x62\xe9\xb4\x45\x4a\xcd\xf3\x79\x4e\xf6\x44\x09\x5e\x20\x43\x58\x99\x4b\x3e\xc8\xa6\x20\xd5\x8d\x70\xb1\xe4\x2d\x42\xf6\xf4\x05\x36\x93\x30\x61\x70\x09\x60\xae\x34\xab\x70\x1b\x8e\x35\xb4\x70\x58\xa6\xb4\x48\x89\xf0\x13\xa6\x0a\x63\xa8\x62\x32\xb5\x35\xbb\x13\x70\x2d\x70\x1f\x78\xa9\x78\x51\x58\x71\x52\x34\x61\x27\xd9\x8e\x43\x92\x89\x84\x59\x85\xa9\x42\x48\x08\x73\x5b\x48\x4a\xa2\x82\x7f\xfc\xdf\x27\x94\x34\x2d\x14\x99\x68\x98\x51\x98\xdd\x86\xd1\x9d\xf2\x77\xa0\xbb\x60\xd7\xc0\x2e\x72\xbb\xf0\xb8\x27\x53\xd6\x35\x34\x97\xb2\xa5\x14\x01\xc2\x59\x98\x3d\xcc\x11\xdc\x16\x8c\xb3\xe1\x13\xe4\xdc\x96\xab\x9e\x0b\xbd\xe0\x7e\x41\x43\xd8\x2d\x21\x8a\xb4\x0b\x9e\x41\xd2\x0a\x93\x28\x32\xa7\x52\xd1\x12\x8b\x3d\x7d\xae\xa4\xb9\x40\x5b\xc9\xdb\x2a\xd1\xb7\x3d\xbd\x83\x4f\x9c\x83\xf9\xc6\x58\x61\x3e\xdb\x82\x9e\x97\xf4\x14\x61\xc1\x34\x17\xbc\x5d\x12\xae\x4c\xeb\xd9\x32\x19\x94\xd0\x09\xba\x28\x48\xcf\x6a\xa3\x13\x67\xd2\x1e\xe8\xa3\x20\xbd\x51\x1a\x69\x0c\x87\xe7\xd2\x49\x27\x79\x7b\xd2\x4a\x17\x56\x4b\x43\x67\xfe\xbe\x66\xbc\xac\xb8\xc6\x01\x1f\x9b\x0a\x56\x9c\x81\xd2\x61\x03\x0b\xaf\x5b\x27\xe4\x49\x77\x7c\xc0\xcd\xb0\x58\x07\x38\x9c\x24\xd6\x01\x7a\x2c\xd6\xc1\x73\x94\x8f\xc4\x3a\x40\x6f\xa4\x58\x9f\xe6\xf0\x7c\x62\x7d\x82\x37\xe7\x2d\x5e\x74\x67\xe1\xf0\x31\xd5\x5f\x1e\x4d\x23\x52\x85\x3a\xaf\xcb\x9d\xac\x70\x4e\x51\x9e\x3d\x2c\xa2\xa1\x6c\x6b\x53\x93\x5c\xc2\xdc\xd9\x43\x47\xf9\x00\x22\x9f\xb9\xb5\x6b\x84\x39\x45\xab\x4d\x49\xa6\xa2\xe0\x28\x10\xe6\xb2\xe7\xcc\xb0\xcd\x4c\x4b\x3e\x44\x89\x37\x5a\xa5\x09\x61\xa6\x52\x14\x45\x67\x5c\xd7\x54\xb3\x69\x40\x34\x67\x0f\x63\x2d\xc8\xd4\x24\x7c\x1c\x0b\xcc\xb8\x35\xad\x92\x22\x52\xbb\x1b\xbf\xe0\xac\x6f\xc5\xe7\xd3\xb1\x99\xc7\xf8\x6c\xae\x2a\x26\x53\x95\x71\xb4\x6a\xcf\xe6\x4b\x28\xaa\xd3\x6c\x8e\x14\x9b\x39\x98\x62\xe0\xd8\x1e\xde\x9a\xd0\x98\x14\xd7\x27\x63\x1c\x3d\xd7\xdd\xd8\xba\xfc\x84\xa3\x69\x4d\x19\x5e\x98\x5c\x9b\x5d\x67\x17\xa6\xca\xe8\x9e\xb2\xa6\xfe\xe1\x8e\x60\x5e\x10\x29\xdb\xfa\xa4\x8c\x8b\x2d\x31\xb6\x8a\x4c\x34\xa2\xcb\x26\xa6\x1a\x1f\x98\xf7\xa4\x23\x77\x09\xfc\x26\x5c\xeb\x84\xa8\x14\xe6\x36\xe6\xe8\x1c\x3a\x07\xae\x49\xc3\x07\x7f\xd8\x7a\xa7\x8c\xfb\xed\xc8\xbc\xf7\x5c\xfb\x04\xb7\x42\x65\xa4\x87\xf0\x9c\xf1\xdc\x31\xa7\xb6\x9e\xab\xe6\xe8\x3d\xa3\x0c\xb6\xe2\x7a\xea\x86\x5d\x13\x7b\x78\x6d\x42\xcf\x14\xa6\x5e\xf1\xf9\x83\x90\x95\x96\x6b\xad\xf1\x3c\x0b\xfe\xee\x0a\x43\xe1\x92\xd8\x3a\x6f\x5e\x4f\xc6\x98\xdc\x59\x84\x59\x4a\xcf\x07\xae\x14\xdc\x2f\x8c\x3f\xe1\x13\x12\x92\x8c\xe6\x77\x68\x5d\xb9\xe7\x55\x08\xcc\x33\x64\x12\x72\x35\x54\xcf\x14\xc0\xd4\x04\x2d\x1a\xa0\x66\x75\x68\xf0\xa0\xf9\x03\x0d\x1a\xa0\x35\x4a\x7f\x9e\xe6\xec\x5c\xda\xf3\x04\x57\xae\x8e\xa3\xbc\xe8\x29\x26\x43\x47\xf8\xbf\x66\xe9\x92\xaf\x22\x3b\x54\x9e\x84\xb6\xf0\xf1\xd1\x17\x80\x0d\x81\x1f\x72\x06\x02\xbc\x4d\x12\x62\x2f\x35\x16\xe2\xc0\xcb\x10\x8e\x84\xd8\x4b\x6b\xa4\x10\x9f\xe2\xec\x7c\x42\x1c\xe4\xca\xf9\xb5\x17\xad\x99\x3e\x7c\xcb\xc4\xb7\x75\xf0\x4e\xcd\x67\x1d\xda\x32\xed\x9c\x4a\x96\xec\x19\x54\x92\xa2\x3e\x44\x96\x35\x9f\x51\x6e\x13\xc7\x2d\x21\xc3\xba\x1f\xbc\xc8\x0a\x13\x9e\xb2\xa9\xc0\xbd\x68\xd3\x3b\x5e\x07\xce\x21\x87\x9b\xa0\xb8\xa4\x1a\x66\x38\xf3\x54\x57\x0c\xce\xec\x04\xec\x0c\xd2\xb1\xa8\xf1\xbc\x21\xe4\x00\x2f\x83\xed\x47\x21\x25\xc4\xc1\xb9\x30\xe2\xe5\x81\xd1\x51\x5c\x74\x9f\xe8\xc0\x8b\x56\xbe\xc5\x33\x6e\x6a\x5e\xbb\xa8\x8b\x7d\x98\xf8\x8e\xda\xef\xdd\x91\xa8\x35\x79\xf3\xbe\x02\x22\xff\xf4\x4c\x92\x73\x1f\x31\x16\x76\xff\x3b\x6f\x8e\x24\xde\x47\x69\xa4\xd8\x9f\xe0\xea\x7c\xb2\x1f\x62\xe9\xab\x38\x79\x66\xef\x4d\x42\x5f\xdb\x06\xe9\x17\x1d\xcd\xb6\xb3\xcb\x0c\x7d\x2b\x2e\x17\xc2\x75\xdb\x36\xa5\xb6\xb6\x30\x36\xa1\x20\xb0\xe7\xa0\x66\xc8\x8f\xb2\x4b\x81\x92\xf3\xc9\xad\xff\x74\x81\xa1\x09\x9c\x00\x8e\x21\x32\x16\x16\xc3\xaf\x7a\x3a\x00\xc4\x50\xeb\x51\x50\x08\x8c\xfe\x5c\x20\xf0\x31\xf0\x55\x9c\x13\x70\xf8\xba\xac\xaf\xeb\x74\x8d\x17\x1f\x71\xe6\xc1\x80\x4f\xc6\x15\xaf\x1b\xd8\xcd\xcd\xca\xbf\x95\x61\x70\x96\x26\x09\xf9\x00\x1d\x96\xf2\xc1\xd7\x96\x1d\x89\xf9\x40\xfb\x91\x72\xee\xe7\xe0\x7c\x82\xee\xe1\xe1\xab\xd8\x3b\x79\xfc\xf6\xb7\x6f\xe7\x2d\x48\xaa\x7e\x8e\x81\xed\xf6\x9d\xfe\xf8\x4d\x48\x0e\x47\xee\xac\xe6\xdd\xb1\xec\x9e\x24\x63\xd7\xa3\xda\xfd\x48\xc0\x26\xec\x62\xba\xb7\x29\xc9\x7d\xd2\x05\x6f\x32\x60\x97\xcc\xd6\x7e\xf3\x07\xd1\x88\x2d\x41\xe1\x82\x36\xbb\xa9\x5f\x72\x42\x94\xd7\x01\x35\xdf\xdb\xe4\xcf\xf4\x4a\x2e\x07\x0a\xbd\x05\xa9\xe2\xb5\x43\x87\xf1\xd2\x93\xcc\xf7\x3c\xcf\x09\xa8\xf4\x50\xb2\xb8\xf4\xbe\x33\xf0\x00\x99\x1e\x1a\xa3\xb0\x19\xe6\xe4\x5c\xe8\x0c\x70\xe2\xe2\xf4\xf8\xa2\x9e\xd8\xe0\x1b\x18\x5f\x7b\xa3\xdc\x21\xca\xbc\x6f\x3f\xca\xc9\xba\x21\x06\xcf\x7a\x7f\xb9\x66\x88\xa9\x49\xa2\xea\x27\xc7\xf2\x1a\x7a\x8d\xe5\x91\xd0\xfa\xa9\x8d\x94\xdc\x93\xdc\x9d\x4f\x7c\xc3\x8c\x7d\x15\xa5\x9b\xbb\xaf\x08\x3d\xd3\x96\xb7\x66\x67\xcb\x9b\x1a\xde\xf2\xa6\x1b\x4a\xd5\x77\x9a\x2a\x3d\xb2\x82\x35\x3d\x6b\xe7\x84\x97\x6a\xdc\x11\x32\x90\x57\xd0\x42\x7b\xcd\xb2\x0b\x0d\x2e\x35\x8d\x0d\xda\xb9\xe0\xed\xff\xa0\xd5\x70\x55\x46\xcf\xaf\x15\x72\x45\x8d\x49\x45\xcb\x15\xb0\x3c\x5d\x43\x55\x07\x15\x2f\xe9\xb4\xcd\xf0\x11\x32\x69\x4e\x7c\xc1\x6b\x73\x38\xb3\x5b\xde\x78\xcb\x76\xc6\xcb\x4e\x43\xd5\x0b\xba\xe2\xd7\x3b\x25\xbc\x5b\xc8\x93\xd9\x3d\x7a\x0a\x13\x80\x76\x44\xc3\xa2\x6b\xe0\x15\xb0\x07\x90\x3a\x6a\x37\x0a\x47\xbe\x11\x9f\x0b\x3c\x83\xe3\x76\x5b\xda\x2e\x8c\x98\xfd\xb7\xe7\x7e\xcb\xbb\xd9\x2a\x5e\xc2\xb4\x32\xcd\x5e\x98\x2f\xf2\x28\xf8\x35\x66\x65\x42\x4b\x98\xbe\x33\x2f\x3d\xf3\x33\x49\xa2\x07\x29\xb1\x5c\x7b\xde\x61\x7c\x24\xdd\x83\x34\x46\xca\x78\x88\x93\xf3\x49\xba\x97\x93\xaf\xe2\x54\xcb\xc1\x17\x42\x7f\x43\xc7\x1c\x7d\xc5\xaf\xa8\xfa\xff\x00\x00\x00\xff\xff\xea\xbf\xaf\x72\x00\x80\x00\x00")
-
-func bindataGoBytes() ([]byte, error) {
-	return bindataRead(
-		_bindataGo,
-		"bindata.go",
-	)
-}
-
-func bindataGo() (*asset, error) {
-	bytes, err := bindataGoBytes()
-	if err != nil {
-		return nil, err
-	}
-
-	info := bindataFileInfo{name: "bindata.go", size: 53248, mode: os.FileMode(436), modTime: time.Unix(1524945292, 0)}
-	a := &asset{bytes: bytes, info: info}
-	return a, nil
-}
-
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -914,10 +1481,38 @@ var _bindata = map[string]func() (*asset, error){
 	"16_drop_picklist_tables.down.sql": _16_drop_picklist_tablesDownSql,
 	"17_add_is_verified.up.sql": _17_add_is_verifiedUpSql,
 	"17_remove_is_verified.down.sql": _17_remove_is_verifiedDownSql,
+	"18_add_photo_year.up.sql": _18_add_photo_yearUpSql,
+	"18_remove_photo_year.down.sql": _18_remove_photo_yearDownSql,
+	"19_create_sessions_table.up.sql": _19_create_sessions_tableUpSql,
+	"19_drop_sessions_table.down.sql": _19_drop_sessions_tableDownSql,
 	"1_create_events_table.up.sql": _1_create_events_tableUpSql,
 	"1_drop_events_table.down.sql": _1_drop_events_tableDownSql,
+	"20_create_signing_keys_table.up.sql": _20_create_signing_keys_tableUpSql,
+	"20_drop_signing_keys_table.down.sql": _20_drop_signing_keys_tableDownSql,
+	"21_create_roles_tables.up.sql": _21_create_roles_tablesUpSql,
+	"21_drop_roles_tables.down.sql": _21_drop_roles_tablesDownSql,
+	"22_create_organizations.up.sql": _22_create_organizationsUpSql,
+	"22_drop_organizations.down.sql": _22_drop_organizationsDownSql,
+	"23_create_picklist_shares.up.sql": _23_create_picklist_sharesUpSql,
+	"23_drop_picklist_shares.down.sql": _23_drop_picklist_sharesDownSql,
+	"24_add_picklist_revisions.up.sql": _24_add_picklist_revisionsUpSql,
+	"24_drop_picklist_revisions.down.sql": _24_drop_picklist_revisionsDownSql,
+	"25_add_pick_annotations.up.sql": _25_add_pick_annotationsUpSql,
+	"25_drop_pick_annotations.down.sql": _25_drop_pick_annotationsDownSql,
+	"26_add_notes_search.up.sql": _26_add_notes_searchUpSql,
+	"26_drop_notes_search.down.sql": _26_drop_notes_searchDownSql,
+	"27_add_report_tags.down.sql": _27_add_report_tagsDownSql,
+	"27_add_report_tags.up.sql": _27_add_report_tagsUpSql,
+	"28_create_bundle_keys.down.sql": _28_create_bundle_keysDownSql,
+	"28_create_bundle_keys.up.sql": _28_create_bundle_keysUpSql,
+	"29_add_schedule_edit.up.sql": _29_add_schedule_editUpSql,
+	"29_remove_schedule_edit.down.sql": _29_remove_schedule_editDownSql,
 	"2_create_matches_table.up.sql": _2_create_matches_tableUpSql,
 	"2_drop_matches_table.down.sql": _2_drop_matches_tableDownSql,
+	"30_add_manual_schedule.up.sql": _30_add_manual_scheduleUpSql,
+	"30_drop_manual_schedule.down.sql": _30_drop_manual_scheduleDownSql,
+	"31_create_match_changes.up.sql": _31_create_match_changesUpSql,
+	"31_drop_match_changes.down.sql": _31_drop_match_changesDownSql,
 	"3_create_alliances_table.up.sql": _3_create_alliances_tableUpSql,
 	"3_drop_alliances_table.down.sql": _3_drop_alliances_tableDownSql,
 	"4_create_reports_table.up.sql": _4_create_reports_tableUpSql,
@@ -932,7 +1527,6 @@ var _bindata = map[string]func() (*asset, error){
 	"8_remove_isadmin.down.sql": _8_remove_isadminDownSql,
 	"9_add_reports_cascade.up.sql": _9_add_reports_cascadeUpSql,
 	"9_remove_reports_cascade.down.sql": _9_remove_reports_cascadeDownSql,
-	"bindata.go": bindataGo,
 }
 
 // AssetDir returns the file names below a certain
@@ -993,10 +1587,38 @@ var _bintree = &bintree{nil, map[string]*bintree{
 	"16_drop_picklist_tables.down.sql": &bintree{_16_drop_picklist_tablesDownSql, map[string]*bintree{}},
 	"17_add_is_verified.up.sql": &bintree{_17_add_is_verifiedUpSql, map[string]*bintree{}},
 	"17_remove_is_verified.down.sql": &bintree{_17_remove_is_verifiedDownSql, map[string]*bintree{}},
+	"18_add_photo_year.up.sql": &bintree{_18_add_photo_yearUpSql, map[string]*bintree{}},
+	"18_remove_photo_year.down.sql": &bintree{_18_remove_photo_yearDownSql, map[string]*bintree{}},
+	"19_create_sessions_table.up.sql": &bintree{_19_create_sessions_tableUpSql, map[string]*bintree{}},
+	"19_drop_sessions_table.down.sql": &bintree{_19_drop_sessions_tableDownSql, map[string]*bintree{}},
 	"1_create_events_table.up.sql": &bintree{_1_create_events_tableUpSql, map[string]*bintree{}},
 	"1_drop_events_table.down.sql": &bintree{_1_drop_events_tableDownSql, map[string]*bintree{}},
+	"20_create_signing_keys_table.up.sql": &bintree{_20_create_signing_keys_tableUpSql, map[string]*bintree{}},
+	"20_drop_signing_keys_table.down.sql": &bintree{_20_drop_signing_keys_tableDownSql, map[string]*bintree{}},
+	"21_create_roles_tables.up.sql": &bintree{_21_create_roles_tablesUpSql, map[string]*bintree{}},
+	"21_drop_roles_tables.down.sql": &bintree{_21_drop_roles_tablesDownSql, map[string]*bintree{}},
+	"22_create_organizations.up.sql": &bintree{_22_create_organizationsUpSql, map[string]*bintree{}},
+	"22_drop_organizations.down.sql": &bintree{_22_drop_organizationsDownSql, map[string]*bintree{}},
+	"23_create_picklist_shares.up.sql": &bintree{_23_create_picklist_sharesUpSql, map[string]*bintree{}},
+	"23_drop_picklist_shares.down.sql": &bintree{_23_drop_picklist_sharesDownSql, map[string]*bintree{}},
+	"24_add_picklist_revisions.up.sql": &bintree{_24_add_picklist_revisionsUpSql, map[string]*bintree{}},
+	"24_drop_picklist_revisions.down.sql": &bintree{_24_drop_picklist_revisionsDownSql, map[string]*bintree{}},
+	"25_add_pick_annotations.up.sql": &bintree{_25_add_pick_annotationsUpSql, map[string]*bintree{}},
+	"25_drop_pick_annotations.down.sql": &bintree{_25_drop_pick_annotationsDownSql, map[string]*bintree{}},
+	"26_add_notes_search.up.sql": &bintree{_26_add_notes_searchUpSql, map[string]*bintree{}},
+	"26_drop_notes_search.down.sql": &bintree{_26_drop_notes_searchDownSql, map[string]*bintree{}},
+	"27_add_report_tags.down.sql": &bintree{_27_add_report_tagsDownSql, map[string]*bintree{}},
+	"27_add_report_tags.up.sql": &bintree{_27_add_report_tagsUpSql, map[string]*bintree{}},
+	"28_create_bundle_keys.down.sql": &bintree{_28_create_bundle_keysDownSql, map[string]*bintree{}},
+	"28_create_bundle_keys.up.sql": &bintree{_28_create_bundle_keysUpSql, map[string]*bintree{}},
+	"29_add_schedule_edit.up.sql": &bintree{_29_add_schedule_editUpSql, map[string]*bintree{}},
+	"29_remove_schedule_edit.down.sql": &bintree{_29_remove_schedule_editDownSql, map[string]*bintree{}},
 	"2_create_matches_table.up.sql": &bintree{_2_create_matches_tableUpSql, map[string]*bintree{}},
 	"2_drop_matches_table.down.sql": &bintree{_2_drop_matches_tableDownSql, map[string]*bintree{}},
+	"30_add_manual_schedule.up.sql": &bintree{_30_add_manual_scheduleUpSql, map[string]*bintree{}},
+	"30_drop_manual_schedule.down.sql": &bintree{_30_drop_manual_scheduleDownSql, map[string]*bintree{}},
+	"31_create_match_changes.up.sql": &bintree{_31_create_match_changesUpSql, map[string]*bintree{}},
+	"31_drop_match_changes.down.sql": &bintree{_31_drop_match_changesDownSql, map[string]*bintree{}},
 	"3_create_alliances_table.up.sql": &bintree{_3_create_alliances_tableUpSql, map[string]*bintree{}},
 	"3_drop_alliances_table.down.sql": &bintree{_3_drop_alliances_tableDownSql, map[string]*bintree{}},
 	"4_create_reports_table.up.sql": &bintree{_4_create_reports_tableUpSql, map[string]*bintree{}},
@@ -1011,7 +1633,6 @@ var _bintree = &bintree{nil, map[string]*bintree{
 	"8_remove_isadmin.down.sql": &bintree{_8_remove_isadminDownSql, map[string]*bintree{}},
 	"9_add_reports_cascade.up.sql": &bintree{_9_add_reports_cascadeUpSql, map[string]*bintree{}},
 	"9_remove_reports_cascade.down.sql": &bintree{_9_remove_reports_cascadeDownSql, map[string]*bintree{}},
-	"bindata.go": &bintree{bindataGo, map[string]*bintree{}},
 }}
 
 // RestoreAsset restores an asset under the given directory
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 
@@ -8,11 +9,20 @@ import (
 	eventPostgres "github.com/Pigmice2733/scouting-backend/internal/store/event/postgres"
 	matchPostgres "github.com/Pigmice2733/scouting-backend/internal/store/match/postgres"
 	"github.com/Pigmice2733/scouting-backend/internal/store/postgres/migrations"
+	"github.com/Pigmice2733/scouting-backend/internal/store/postgres/txdb"
 
 	alliancePostgres "github.com/Pigmice2733/scouting-backend/internal/store/alliance/postgres"
+	bundleKeyPostgres "github.com/Pigmice2733/scouting-backend/internal/store/bundlekey/postgres"
+	organizationPostgres "github.com/Pigmice2733/scouting-backend/internal/store/organization/postgres"
 	photoPostgres "github.com/Pigmice2733/scouting-backend/internal/store/photo/postgres"
 	picklistPostgres "github.com/Pigmice2733/scouting-backend/internal/store/picklist/postgres"
 	reportPostgres "github.com/Pigmice2733/scouting-backend/internal/store/report/postgres"
+	rolePostgres "github.com/Pigmice2733/scouting-backend/internal/store/role/postgres"
+	schemaPostgres "github.com/Pigmice2733/scouting-backend/internal/store/schema/postgres"
+	sessionPostgres "github.com/Pigmice2733/scouting-backend/internal/store/session/postgres"
+	sharingPostgres "github.com/Pigmice2733/scouting-backend/internal/store/sharing/postgres"
+	signingKeyPostgres "github.com/Pigmice2733/scouting-backend/internal/store/signingkey/postgres"
+	tagPostgres "github.com/Pigmice2733/scouting-backend/internal/store/tag/postgres"
 	userPostgres "github.com/Pigmice2733/scouting-backend/internal/store/user/postgres"
 	// for the postgres sql driver
 	_ "github.com/lib/pq"
@@ -47,6 +57,19 @@ func NewFromOptions(options Options) (*store.Service, error) {
 	return New(db)
 }
 
+// SchemaVersion gets the version of the newest migration, which is the version
+// of the database schema once the migrations have run.
+func SchemaVersion() int {
+	version := 0
+	for _, name := range migrations.AssetNames() {
+		var n int
+		if _, err := fmt.Sscanf(name, "%d_", &n); err == nil && n > version {
+			version = n
+		}
+	}
+	return version
+}
+
 // New returns a new Service.
 func New(db *sql.DB) (*store.Service, error) {
 	driver, err := postgres.WithInstance(db, &postgres.Config{MigrationsTable: "migrations", DatabaseName: "scoutingbackend"})
@@ -73,13 +96,54 @@ func New(db *sql.DB) (*store.Service, error) {
 		return nil, err
 	}
 
+	return newService(db), nil
+}
+
+// newService creates the stores of a Service, which all query db.
+func newService(db *sql.DB) *store.Service {
+	s := services(db, txdb.New(db))
+	s.Transactor = transactor{db}
+	return s
+}
+
+// services creates the stores of a Service which query tx, either a database or
+// a transaction on it. Signing keys are rotated on their own, so they are never
+// part of a transaction.
+func services(db *sql.DB, tx txdb.DB) *store.Service {
 	return &store.Service{
-		Event:    eventPostgres.New(db),
-		Match:    matchPostgres.New(db),
-		Alliance: alliancePostgres.New(db),
-		Report:   reportPostgres.New(db),
-		User:     userPostgres.New(db),
-		Photo:    photoPostgres.New(db),
-		Picklist: picklistPostgres.New(db),
-	}, nil
+		Event:      eventPostgres.New(tx),
+		Match:      matchPostgres.New(tx),
+		Alliance:   alliancePostgres.New(tx),
+		Report:     reportPostgres.New(tx),
+		User:       userPostgres.New(tx),
+		Role:       rolePostgres.New(tx),
+		Photo:      photoPostgres.New(tx),
+		Picklist:   picklistPostgres.New(tx),
+		Session:    sessionPostgres.New(tx),
+		SigningKey: signingKeyPostgres.New(db),
+		Org:        organizationPostgres.New(tx),
+		Sharing:    sharingPostgres.New(tx),
+		Schema:     schemaPostgres.New(tx),
+		Tag:        tagPostgres.New(tx),
+		BundleKey:  bundleKeyPostgres.New(tx),
+	}
+}
+
+// transactor runs changes to the postgres stores in a transaction.
+type transactor struct {
+	db *sql.DB
+}
+
+func (t transactor) Transact(ctx context.Context, fn func(s *store.Service) error) error {
+	tx, db, err := txdb.Begin(ctx, t.db)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(services(t.db, db)); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
 }
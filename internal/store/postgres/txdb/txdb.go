@@ -0,0 +1,90 @@
+// Package txdb lets the postgres stores run their queries either on a database
+// or inside a transaction that spans several stores. Transactions begun by a
+// store inside another transaction become savepoints, so the store can still
+// commit or roll back its own changes.
+package txdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Querier runs queries, either on a database or in a transaction.
+type Querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
+// Tx is a transaction, or a savepoint in an outer transaction.
+type Tx interface {
+	Querier
+	Commit() error
+	Rollback() error
+}
+
+// DB is what the postgres stores query. It is either a database or a
+// transaction.
+type DB interface {
+	Querier
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (Tx, error)
+}
+
+// New wraps a database.
+func New(db *sql.DB) DB {
+	return database{db}
+}
+
+type database struct {
+	*sql.DB
+}
+
+func (db database) BeginTx(ctx context.Context, opts *sql.TxOptions) (Tx, error) {
+	return db.DB.BeginTx(ctx, opts)
+}
+
+// Begin begins a transaction on a database, and returns it along with a DB
+// that runs everything in it.
+func Begin(ctx context.Context, db *sql.DB) (*sql.Tx, DB, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tx, &transaction{Tx: tx}, nil
+}
+
+type transaction struct {
+	*sql.Tx
+	savepoints int
+}
+
+// BeginTx creates a savepoint in the transaction. The options are ignored,
+// since they can only be set for the outer transaction.
+func (t *transaction) BeginTx(ctx context.Context, opts *sql.TxOptions) (Tx, error) {
+	t.savepoints++
+	sp := &savepoint{transaction: t, ctx: ctx, name: fmt.Sprintf("sp%d", t.savepoints)}
+
+	if _, err := t.ExecContext(ctx, "SAVEPOINT "+sp.name); err != nil {
+		return nil, err
+	}
+
+	return sp, nil
+}
+
+type savepoint struct {
+	*transaction
+	ctx  context.Context
+	name string
+}
+
+func (sp *savepoint) Commit() error {
+	_, err := sp.ExecContext(sp.ctx, "RELEASE SAVEPOINT "+sp.name)
+	return err
+}
+
+func (sp *savepoint) Rollback() error {
+	_, err := sp.ExecContext(sp.ctx, "ROLLBACK TO SAVEPOINT "+sp.name)
+	return err
+}
@@ -2,46 +2,62 @@ package postgres
 
 import (
 	"bytes"
-	"database/sql"
+	"context"
 	"encoding/json"
 
 	"github.com/Pigmice2733/scouting-backend/internal/analysis"
 	"github.com/Pigmice2733/scouting-backend/internal/store/alliance"
+	"github.com/Pigmice2733/scouting-backend/internal/store/postgres/txdb"
 	"github.com/Pigmice2733/scouting-backend/internal/store/report"
+	"github.com/lib/pq"
 )
 
 // Service is used for getting information about a report from a postgres database.
 type Service struct {
-	db *sql.DB
+	db txdb.DB
 }
 
 // New creates a new report service.
-func New(db *sql.DB) report.Service {
+func New(db txdb.DB) report.Service {
 	return &Service{db: db}
 }
 
+// visibleTo is a condition for reports that can be seen by the organization in
+// the first parameter of a query.
+const visibleTo = `(reports.org = $1 OR EXISTS (
+	SELECT 1 FROM sharingAgreements
+		WHERE accepted AND eventKey = reports.eventKey AND (
+			(proposer = $1 AND partner = reports.org) OR (partner = $1 AND proposer = reports.org)
+		)
+))`
+
 // Upsert upserts (creates if the resource doesn't exist, otherwise updates) a report into the postgresql database.
-func (s *Service) Upsert(rep report.Report, as alliance.Service) error {
+func (s *Service) Upsert(ctx context.Context, rep report.Report, as alliance.Service) error {
 	stats := new(bytes.Buffer)
 	if err := json.NewEncoder(stats).Encode(rep.Stats); err != nil {
 		return err
 	}
 
-	_, err := s.db.Exec(`
-		INSERT INTO reports (reporter, team, stats, notes, eventKey, matchKey)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		ON CONFLICT (eventKey, matchKey, team)
+	tags := rep.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO reports (reporter, team, stats, notes, eventKey, matchKey, org, tags)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (org, eventKey, matchKey, team)
 		DO
 			UPDATE
-				SET reporter = $1, team = $2, stats = $3, notes = $4
-	`, rep.Reporter, rep.Team, stats.String(), rep.Notes, rep.EventKey, rep.MatchKey)
+				SET reporter = $1, team = $2, stats = $3, notes = $4, tags = $8
+	`, rep.Reporter, rep.Team, stats.String(), rep.Notes, rep.EventKey, rep.MatchKey, rep.Org, pq.Array(tags))
 
 	return err
 }
 
 // GetReportedOn gets all teams that have been reported on at an event.
-func (s *Service) GetReportedOn(eventKey string) (reportedOn []string, err error) {
-	rows, err := s.db.Query("SELECT DISTINCT team FROM reports WHERE eventKey = $1", eventKey)
+func (s *Service) GetReportedOn(ctx context.Context, org, eventKey string) (reportedOn []string, err error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT DISTINCT team FROM reports WHERE "+visibleTo+" AND eventKey = $2", org, eventKey)
 	if err != nil {
 		return reportedOn, err
 	}
@@ -60,8 +76,8 @@ func (s *Service) GetReportedOn(eventKey string) (reportedOn []string, err error
 }
 
 // GetStatsByEventAndTeam gets all statistics from reports of a certain team at a certain event.
-func (s *Service) GetStatsByEventAndTeam(eventKey, team string) ([]analysis.Data, error) {
-	rows, err := s.db.Query("SELECT stats FROM reports WHERE eventKey = $1 AND team = $2", eventKey, team)
+func (s *Service) GetStatsByEventAndTeam(ctx context.Context, org, eventKey, team string) ([]analysis.Data, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT stats FROM reports WHERE "+visibleTo+" AND eventKey = $2 AND team = $3", org, eventKey, team)
 	if err != nil {
 		return nil, err
 	}
@@ -87,9 +103,11 @@ func (s *Service) GetStatsByEventAndTeam(eventKey, team string) ([]analysis.Data
 	return stats, rows.Err()
 }
 
-// GetNotesByEventAndTeam gets all notes from reports of a certain team at a certain event.
-func (s *Service) GetNotesByEventAndTeam(eventKey, team string) (map[string]string, error) {
-	rows, err := s.db.Query("SELECT matchKey, notes FROM reports WHERE eventKey = $1 AND team = $2 AND notes IS NOT NULL", eventKey, team)
+// GetNotesByEventAndTeam gets all notes from reports of a certain team at a
+// certain event. If more than one organization reported on a match, their
+// notes are joined by newlines.
+func (s *Service) GetNotesByEventAndTeam(ctx context.Context, org, eventKey, team string) (map[string]string, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT matchKey, notes FROM reports WHERE "+visibleTo+" AND eventKey = $2 AND team = $3 AND notes IS NOT NULL ORDER BY reports.org <> $1, reports.org", org, eventKey, team)
 	if err != nil {
 		return nil, err
 	}
@@ -105,15 +123,72 @@ func (s *Service) GetNotesByEventAndTeam(eventKey, team string) (map[string]stri
 			return nil, err
 		}
 
+		if existing, ok := notes[matchKey]; ok {
+			note = existing + "\n" + note
+		}
 		notes[matchKey] = note
 	}
 
 	return notes, rows.Err()
 }
 
+// GetTagsByEventAndTeam gets the tags of every report on a certain team at a
+// certain event.
+func (s *Service) GetTagsByEventAndTeam(ctx context.Context, org, eventKey, team string) ([][]string, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT tags FROM reports WHERE "+visibleTo+" AND eventKey = $2 AND team = $3", org, eventKey, team)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := make([][]string, 0)
+
+	for rows.Next() {
+		var reportTags []string
+		if err := rows.Scan(pq.Array(&reportTags)); err != nil {
+			return nil, err
+		}
+
+		tags = append(tags, reportTags)
+	}
+
+	return tags, rows.Err()
+}
+
+// GetReportsByEvent gets all reports at a certain event, ordered by match and
+// team.
+func (s *Service) GetReportsByEvent(ctx context.Context, org, eventKey string) ([]report.Report, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT org, reporter, matchKey, team, stats, notes, tags FROM reports WHERE "+visibleTo+" AND eventKey = $2 ORDER BY matchKey, team, org", org, eventKey)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reports []report.Report
+
+	for rows.Next() {
+		var rep report.Report
+		var statsStr string
+
+		if err := rows.Scan(&rep.Org, &rep.Reporter, &rep.MatchKey, &rep.Team, &statsStr, &rep.Notes, pq.Array(&rep.Tags)); err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal([]byte(statsStr), &rep.Stats); err != nil {
+			return nil, err
+		}
+
+		rep.EventKey = eventKey
+
+		reports = append(reports, rep)
+	}
+
+	return reports, rows.Err()
+}
+
 // GetReportsByEventAndTeam gets all reports on a certain team at a certain event.
-func (s *Service) GetReportsByEventAndTeam(eventKey, team string) ([]report.Report, error) {
-	rows, err := s.db.Query("SELECT reporter, matchKey, stats, notes FROM reports WHERE eventKey = $1 AND team = $2", eventKey, team)
+func (s *Service) GetReportsByEventAndTeam(ctx context.Context, org, eventKey, team string) ([]report.Report, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT org, reporter, matchKey, stats, notes, tags FROM reports WHERE "+visibleTo+" AND eventKey = $2 AND team = $3", org, eventKey, team)
 	if err != nil {
 		return nil, err
 	}
@@ -125,7 +200,7 @@ func (s *Service) GetReportsByEventAndTeam(eventKey, team string) ([]report.Repo
 		var rep report.Report
 		var statsStr string
 
-		if err := rows.Scan(&rep.Reporter, &rep.MatchKey, &statsStr, &rep.Notes); err != nil {
+		if err := rows.Scan(&rep.Org, &rep.Reporter, &rep.MatchKey, &statsStr, &rep.Notes, pq.Array(&rep.Tags)); err != nil {
 			return nil, err
 		}
 
@@ -143,8 +218,8 @@ func (s *Service) GetReportsByEventAndTeam(eventKey, team string) ([]report.Repo
 }
 
 // GetReportsByTeam gets all reports on a certain team from all events.
-func (s *Service) GetReportsByTeam(team string) ([]report.Report, error) {
-	rows, err := s.db.Query("SELECT reporter, eventKey, matchKey, stats, notes FROM reports WHERE team = $1", team)
+func (s *Service) GetReportsByTeam(ctx context.Context, org, team string) ([]report.Report, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT org, reporter, eventKey, matchKey, stats, notes, tags FROM reports WHERE "+visibleTo+" AND team = $2", org, team)
 	if err != nil {
 		return nil, err
 	}
@@ -156,7 +231,7 @@ func (s *Service) GetReportsByTeam(team string) ([]report.Report, error) {
 		var rep report.Report
 		var statsStr string
 
-		if err := rows.Scan(&rep.Reporter, &rep.EventKey, &rep.MatchKey, &statsStr, &rep.Notes); err != nil {
+		if err := rows.Scan(&rep.Org, &rep.Reporter, &rep.EventKey, &rep.MatchKey, &statsStr, &rep.Notes, pq.Array(&rep.Tags)); err != nil {
 			return nil, err
 		}
 
@@ -172,9 +247,9 @@ func (s *Service) GetReportsByTeam(team string) ([]report.Report, error) {
 	return reports, rows.Err()
 }
 
-// GetReporterStats gets a map of all reporters to the amount of reports they have submitted.
-func (s *Service) GetReporterStats() (map[string]int, error) {
-	rows, err := s.db.Query("SELECT reporter, COUNT(reporter) FROM reports GROUP BY reporter")
+// GetReporterStats gets a map of all reporters of an organization to the amount of reports they have submitted.
+func (s *Service) GetReporterStats(ctx context.Context, org string) (map[string]int, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT reporter, COUNT(reporter) FROM reports WHERE org = $1 GROUP BY reporter", org)
 	if err != nil {
 		return nil, err
 	}
@@ -195,3 +270,38 @@ func (s *Service) GetReporterStats() (map[string]int, error) {
 
 	return stats, rows.Err()
 }
+
+// SearchNotes searches the notes of reports in the postgresql database with
+// english full-text search, most relevant first. Snippets are HTML: the notes
+// are escaped, and matching words are surrounded with <mark> tags.
+func (s *Service) SearchNotes(ctx context.Context, org string, q report.NoteQuery) ([]report.NoteResult, error) {
+	results := []report.NoteResult{}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT reporter, eventKey, matchKey, team, ts_headline('english', replace(replace(replace(replace(notes, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;'), query, 'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=25, MinWords=10'), created
+			FROM reports, plainto_tsquery('english', $2) query
+			WHERE `+visibleTo+`
+				AND to_tsvector('english', COALESCE(notes, '')) @@ query
+				AND ($3 = '' OR eventKey = $3)
+				AND ($4 = '' OR team = $4)
+				AND ($5 = '' OR reporter = $5)
+				AND ($6::timestamptz IS NULL OR created >= $6)
+				AND ($7::timestamptz IS NULL OR created < $7)
+			ORDER BY ts_rank(to_tsvector('english', COALESCE(notes, '')), query) DESC, created DESC
+			LIMIT $8
+		`, org, q.Text, q.EventKey, q.Team, q.Reporter, q.Since, q.Until, q.Limit)
+	if err != nil {
+		return results, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r report.NoteResult
+		if err := rows.Scan(&r.Reporter, &r.EventKey, &r.MatchKey, &r.Team, &r.Snippet, &r.Created); err != nil {
+			return results, err
+		}
+		results = append(results, r)
+	}
+
+	return results, rows.Err()
+}
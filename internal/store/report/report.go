@@ -1,27 +1,60 @@
 package report
 
 import (
+	"context"
+	"time"
+
 	"github.com/Pigmice2733/scouting-backend/internal/analysis"
 	"github.com/Pigmice2733/scouting-backend/internal/store/alliance"
 )
 
 // Report stores information about how a team performed in a match.
 type Report struct {
+	Org      string                 `json:"org"`
 	Reporter string                 `json:"reporter"`
 	EventKey string                 `json:"eventKey"`
 	MatchKey string                 `json:"matchKey"`
 	Team     string                 `json:"team"`
 	Notes    *string                `json:"notes"`
+	Tags     []string               `json:"tags"`
 	Stats    map[string]interface{} `json:"stats"`
 }
 
-// Service is a store for reports.
+// NoteQuery is a full-text search over report notes. Empty filters are left
+// out of the search.
+type NoteQuery struct {
+	Text     string
+	EventKey string
+	Team     string
+	Reporter string
+	Since    *time.Time
+	Until    *time.Time
+	Limit    int
+}
+
+// NoteResult is a report whose notes matched a search, with a snippet of the
+// notes where the matching words are highlighted.
+type NoteResult struct {
+	Reporter string    `json:"reporter"`
+	EventKey string    `json:"eventKey"`
+	MatchKey string    `json:"matchKey"`
+	Team     string    `json:"team"`
+	Snippet  string    `json:"snippet"`
+	Created  time.Time `json:"created"`
+}
+
+// Service is a store for reports. Reports are read on behalf of an
+// organization, which can see its own reports and the reports of organizations
+// it has an accepted sharing agreement with for the event of the report.
 type Service interface {
-	Upsert(rep Report, as alliance.Service) error
-	GetReportedOn(eventKey string) ([]string, error)
-	GetStatsByEventAndTeam(eventKey, team string) ([]analysis.Data, error)
-	GetNotesByEventAndTeam(eventKey, team string) (map[string]string, error)
-	GetReportsByEventAndTeam(eventKey, team string) ([]Report, error)
-	GetReportsByTeam(team string) ([]Report, error)
-	GetReporterStats() (map[string]int, error)
+	Upsert(ctx context.Context, rep Report, as alliance.Service) error
+	GetReportedOn(ctx context.Context, org, eventKey string) ([]string, error)
+	GetStatsByEventAndTeam(ctx context.Context, org, eventKey, team string) ([]analysis.Data, error)
+	GetNotesByEventAndTeam(ctx context.Context, org, eventKey, team string) (map[string]string, error)
+	GetTagsByEventAndTeam(ctx context.Context, org, eventKey, team string) ([][]string, error)
+	GetReportsByEvent(ctx context.Context, org, eventKey string) ([]Report, error)
+	GetReportsByEventAndTeam(ctx context.Context, org, eventKey, team string) ([]Report, error)
+	GetReportsByTeam(ctx context.Context, org, team string) ([]Report, error)
+	GetReporterStats(ctx context.Context, org string) (map[string]int, error)
+	SearchNotes(ctx context.Context, org string, q NoteQuery) ([]NoteResult, error)
 }
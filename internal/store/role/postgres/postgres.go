@@ -0,0 +1,137 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/Pigmice2733/scouting-backend/internal/store"
+	"github.com/Pigmice2733/scouting-backend/internal/store/postgres/txdb"
+	"github.com/Pigmice2733/scouting-backend/internal/store/role"
+	"github.com/lib/pq"
+)
+
+// Service is used for getting information about a role from a postgres database.
+type Service struct {
+	db txdb.DB
+}
+
+// New creates a new role service.
+func New(db txdb.DB) role.Service {
+	return &Service{db: db}
+}
+
+// GetRoles gets all roles along with their permissions from the postgresql
+// database.
+func (s *Service) GetRoles(ctx context.Context) ([]role.Role, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT roles.name, roles.description, rolePermissions.permission
+			FROM roles
+			LEFT JOIN rolePermissions ON rolePermissions.role = roles.name
+			ORDER BY roles.name, rolePermissions.permission
+		`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := []role.Role{}
+	for rows.Next() {
+		var r role.Role
+		var permission *string
+		if err := rows.Scan(&r.Name, &r.Description, &permission); err != nil {
+			return nil, err
+		}
+
+		if len(roles) == 0 || roles[len(roles)-1].Name != r.Name {
+			r.Permissions = []string{}
+			roles = append(roles, r)
+		}
+
+		if permission != nil {
+			last := &roles[len(roles)-1]
+			last.Permissions = append(last.Permissions, *permission)
+		}
+	}
+
+	return roles, rows.Err()
+}
+
+// GetPermissions gets all the permissions granted by a list of roles from the
+// postgresql database.
+func (s *Service) GetPermissions(ctx context.Context, roles []string) ([]string, error) {
+	permissions := []string{}
+
+	rows, err := s.db.QueryContext(ctx, "SELECT DISTINCT permission FROM rolePermissions WHERE role = ANY($1)", pq.Array(roles))
+	if err != nil {
+		return permissions, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var permission string
+		if err := rows.Scan(&permission); err != nil {
+			return permissions, err
+		}
+		permissions = append(permissions, permission)
+	}
+
+	return permissions, rows.Err()
+}
+
+// Upsert creates a role or replaces the description and permissions of an
+// existing role in the postgresql database.
+func (s *Service) Upsert(ctx context.Context, r role.Role) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT
+			INTO
+				roles (name, description)
+			VALUES ($1, $2)
+			ON CONFLICT (name) DO UPDATE
+				SET description = $2
+		`, r.Name, r.Description); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM rolePermissions WHERE role = $1", r.Name); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	stmt, err := tx.PrepareContext(ctx, "INSERT INTO rolePermissions (role, permission) VALUES ($1, $2) ON CONFLICT DO NOTHING")
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+
+	for _, permission := range r.Permissions {
+		if _, err := stmt.ExecContext(ctx, r.Name, permission); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Delete removes a role from the postgresql database, taking it away from all
+// users that had it.
+func (s *Service) Delete(ctx context.Context, name string) error {
+	res, err := s.db.ExecContext(ctx, "DELETE FROM roles WHERE name = $1", name)
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return store.ErrNoResults
+	}
+
+	return nil
+}
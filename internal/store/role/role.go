@@ -0,0 +1,68 @@
+package role
+
+import "context"
+
+// Permissions that can be granted to roles.
+const (
+	ReportWrite     = "report:write"
+	PicklistWrite   = "picklist:write"
+	PicklistReadAll = "picklist:read-all"
+	UserReadAll     = "user:read-all"
+	UserEditAll     = "user:edit-all"
+	RoleEdit        = "role:edit"
+	SchemaEdit      = "schema:edit"
+	SharingEdit     = "sharing:edit"
+	OrgManage       = "org:manage"
+	ScheduleEdit    = "schedule:edit"
+)
+
+// Permissions is a list of all permissions.
+var Permissions = []string{ReportWrite, PicklistWrite, PicklistReadAll, UserReadAll, UserEditAll, RoleEdit, SchemaEdit, SharingEdit, OrgManage, ScheduleEdit}
+
+// Default roles.
+const (
+	Scout      = "scout"
+	Analyst    = "analyst"
+	DriveCoach = "drive-coach"
+	Admin      = "admin"
+	SuperAdmin = "superadmin"
+)
+
+// Defaults is a list of all default roles.
+var Defaults = []string{Scout, Analyst, DriveCoach, Admin, SuperAdmin}
+
+// Default returns whether a role is one of the default roles.
+func Default(name string) bool {
+	for _, d := range Defaults {
+		if d == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Role holds information about a role, a named set of permissions that users
+// can be given.
+type Role struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+// ValidPermission returns whether a permission exists.
+func ValidPermission(permission string) bool {
+	for _, p := range Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// Service is a store for roles.
+type Service interface {
+	GetRoles(ctx context.Context) ([]Role, error)
+	GetPermissions(ctx context.Context, roles []string) ([]string, error)
+	Upsert(ctx context.Context, r Role) error
+	Delete(ctx context.Context, name string) error
+}
@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/Pigmice2733/scouting-backend/internal/analysis"
+	"github.com/Pigmice2733/scouting-backend/internal/store"
+	"github.com/Pigmice2733/scouting-backend/internal/store/postgres/txdb"
+	"github.com/Pigmice2733/scouting-backend/internal/store/schema"
+)
+
+// Service is used for getting the report schema of an organization from a postgres database.
+type Service struct {
+	db txdb.DB
+}
+
+// New creates a new schema service.
+func New(db txdb.DB) schema.Service {
+	return &Service{db: db}
+}
+
+// Get gets the report schema of an organization from the postgresql database.
+func (s *Service) Get(ctx context.Context, org string) (analysis.Schema, error) {
+	var schemaStr string
+	if err := s.db.QueryRowContext(ctx, "SELECT schema FROM schemas WHERE org = $1", org).Scan(&schemaStr); err == sql.ErrNoRows {
+		return nil, store.ErrNoResults
+	} else if err != nil {
+		return nil, err
+	}
+
+	sch := make(analysis.Schema)
+	err := json.Unmarshal([]byte(schemaStr), &sch)
+	return sch, err
+}
+
+// Upsert sets the report schema of an organization in the postgresql database.
+func (s *Service) Upsert(ctx context.Context, org string, sch analysis.Schema) error {
+	schemaBytes, err := json.Marshal(sch)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO schemas (org, schema)
+		VALUES ($1, $2)
+		ON CONFLICT (org)
+		DO
+			UPDATE
+				SET schema = $2
+	`, org, string(schemaBytes))
+	return err
+}
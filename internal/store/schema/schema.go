@@ -0,0 +1,12 @@
+package schema
+
+import (
+	"context"
+	"github.com/Pigmice2733/scouting-backend/internal/analysis"
+)
+
+// Service is a store for the report schemas of organizations.
+type Service interface {
+	Get(ctx context.Context, org string) (analysis.Schema, error)
+	Upsert(ctx context.Context, org string, s analysis.Schema) error
+}
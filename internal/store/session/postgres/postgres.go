@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Pigmice2733/scouting-backend/internal/store"
+	"github.com/Pigmice2733/scouting-backend/internal/store/postgres/txdb"
+	"github.com/Pigmice2733/scouting-backend/internal/store/session"
+)
+
+// Service is used for getting information about a session from a postgres database.
+type Service struct {
+	db txdb.DB
+}
+
+// New creates a new session service.
+func New(db txdb.DB) session.Service {
+	return &Service{db: db}
+}
+
+// Create creates a new session in the postgresql database.
+func (s *Service) Create(ctx context.Context, sess session.Session) (id string, err error) {
+	err = s.db.QueryRowContext(ctx, `
+		INSERT
+			INTO
+				sessions(username, hashedRefreshToken, expires)
+			VALUES ($1, $2, $3)
+			RETURNING id
+		`, sess.Username, sess.HashedRefreshToken, sess.Expires).Scan(&id)
+	return
+}
+
+// Get gets a session with a given id from the postgresql database.
+func (s *Service) Get(ctx context.Context, id string) (sess session.Session, err error) {
+	err = s.db.QueryRowContext(ctx, "SELECT id, username, hashedRefreshToken, created, expires, revoked FROM sessions WHERE id = $1", id).Scan(
+		&sess.ID, &sess.Username, &sess.HashedRefreshToken, &sess.Created, &sess.Expires, &sess.Revoked)
+	if err == sql.ErrNoRows {
+		err = store.ErrNoResults
+	}
+	return
+}
+
+// Rotate replaces the refresh token of an active session in the postgresql
+// database, given the current refresh token. If the current refresh token does
+// not match, store.ErrNoResults is returned.
+func (s *Service) Rotate(ctx context.Context, id, oldHashedRefreshToken, newHashedRefreshToken string, expires time.Time) error {
+	res, err := s.db.ExecContext(ctx, `
+		UPDATE sessions
+			SET hashedRefreshToken = $1, expires = $2
+			WHERE id = $3 AND hashedRefreshToken = $4 AND NOT revoked AND expires > now()
+		`, newHashedRefreshToken, expires, id, oldHashedRefreshToken)
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return store.ErrNoResults
+	}
+
+	return nil
+}
+
+// Revoke revokes a session in the postgresql database.
+func (s *Service) Revoke(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, "UPDATE sessions SET revoked = true WHERE id = $1", id)
+	return err
+}
+
+// RevokeAll revokes all sessions of a user in the postgresql database.
+func (s *Service) RevokeAll(ctx context.Context, username string) error {
+	_, err := s.db.ExecContext(ctx, "UPDATE sessions SET revoked = true WHERE username = $1", username)
+	return err
+}
+
+// DeleteExpired removes all sessions that expired before a certain time from
+// the postgresql database.
+func (s *Service) DeleteExpired(ctx context.Context, before time.Time) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM sessions WHERE expires < $1", before)
+	return err
+}
@@ -0,0 +1,32 @@
+package session
+
+import (
+	"context"
+	"time"
+)
+
+// Session holds information about a login of a user. Access tokens are tied to
+// a session and are only valid while their session is not revoked or expired.
+type Session struct {
+	ID                 string    `json:"id"`
+	Username           string    `json:"username"`
+	HashedRefreshToken string    `json:"-"`
+	Created            time.Time `json:"created"`
+	Expires            time.Time `json:"expires"`
+	Revoked            bool      `json:"revoked"`
+}
+
+// Active returns whether the session can still be used.
+func (s Session) Active() bool {
+	return !s.Revoked && time.Now().Before(s.Expires)
+}
+
+// Service is a store for sessions.
+type Service interface {
+	Create(ctx context.Context, s Session) (id string, err error)
+	Get(ctx context.Context, id string) (Session, error)
+	Rotate(ctx context.Context, id, oldHashedRefreshToken, newHashedRefreshToken string, expires time.Time) error
+	Revoke(ctx context.Context, id string) error
+	RevokeAll(ctx context.Context, username string) error
+	DeleteExpired(ctx context.Context, before time.Time) error
+}
@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/Pigmice2733/scouting-backend/internal/store"
+	"github.com/Pigmice2733/scouting-backend/internal/store/postgres/txdb"
+	"github.com/Pigmice2733/scouting-backend/internal/store/sharing"
+)
+
+// Service is used for getting information about a sharing agreement from a postgres database.
+type Service struct {
+	db txdb.DB
+}
+
+// New creates a new sharing agreement service.
+func New(db txdb.DB) sharing.Service {
+	return &Service{db: db}
+}
+
+// Propose creates a new unaccepted sharing agreement in the postgresql
+// database.
+func (s *Service) Propose(ctx context.Context, a sharing.Agreement) (id string, err error) {
+	err = s.db.QueryRowContext(ctx, `
+		INSERT
+			INTO
+				sharingAgreements(eventKey, proposer, partner)
+			VALUES ($1, $2, $3)
+			RETURNING id
+		`, a.EventKey, a.Proposer, a.Partner).Scan(&id)
+	return
+}
+
+// Accept accepts a sharing agreement proposed to an organization in the
+// postgresql database.
+func (s *Service) Accept(ctx context.Context, id, partner string) error {
+	res, err := s.db.ExecContext(ctx, "UPDATE sharingAgreements SET accepted = true WHERE id = $1 AND partner = $2", id, partner)
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return store.ErrNoResults
+	}
+
+	return nil
+}
+
+// Delete removes a sharing agreement that an organization is part of from the
+// postgresql database.
+func (s *Service) Delete(ctx context.Context, id, org string) error {
+	res, err := s.db.ExecContext(ctx, "DELETE FROM sharingAgreements WHERE id = $1 AND (proposer = $2 OR partner = $2)", id, org)
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return store.ErrNoResults
+	}
+
+	return nil
+}
+
+// GetByOrg gets all sharing agreements an organization is part of from the
+// postgresql database.
+func (s *Service) GetByOrg(ctx context.Context, org string) ([]sharing.Agreement, error) {
+	agreements := []sharing.Agreement{}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, eventKey, proposer, partner, accepted, created
+			FROM sharingAgreements
+			WHERE proposer = $1 OR partner = $1
+			ORDER BY created DESC
+		`, org)
+	if err != nil {
+		return agreements, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a sharing.Agreement
+		if err := rows.Scan(&a.ID, &a.EventKey, &a.Proposer, &a.Partner, &a.Accepted, &a.Created); err != nil {
+			return agreements, err
+		}
+		agreements = append(agreements, a)
+	}
+
+	return agreements, rows.Err()
+}
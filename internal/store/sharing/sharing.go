@@ -0,0 +1,26 @@
+package sharing
+
+import (
+	"context"
+	"time"
+)
+
+// Agreement is an opt-in agreement between two organizations to pool their
+// reports at an event. It is proposed by one organization and only takes
+// effect once the partner organization accepts it.
+type Agreement struct {
+	ID       string    `json:"id"`
+	EventKey string    `json:"eventKey"`
+	Proposer string    `json:"proposer"`
+	Partner  string    `json:"partner"`
+	Accepted bool      `json:"accepted"`
+	Created  time.Time `json:"created"`
+}
+
+// Service is a store for sharing agreements.
+type Service interface {
+	Propose(ctx context.Context, a Agreement) (id string, err error)
+	Accept(ctx context.Context, id, partner string) error
+	Delete(ctx context.Context, id, org string) error
+	GetByOrg(ctx context.Context, org string) ([]Agreement, error)
+}
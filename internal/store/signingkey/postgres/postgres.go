@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/Pigmice2733/scouting-backend/internal/store/signingkey"
+)
+
+// Service is used for getting information about a signing key from a postgres database.
+type Service struct {
+	db *sql.DB
+}
+
+// New creates a new signing key service.
+func New(db *sql.DB) signingkey.Service {
+	return &Service{db: db}
+}
+
+// GetAll gets all signing keys from the postgresql database, newest first.
+func (s *Service) GetAll() ([]signingkey.Key, error) {
+	var keys []signingkey.Key
+
+	rows, err := s.db.Query("SELECT kid, algorithm, privateKey, created, retired FROM signingKeys ORDER BY created DESC")
+	if err != nil {
+		return keys, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var k signingkey.Key
+		if err := rows.Scan(&k.ID, &k.Algorithm, &k.PrivateKey, &k.Created, &k.Retired); err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+
+	return keys, rows.Err()
+}
+
+// Rotate adds a new signing key to the postgresql database and retires all
+// other keys, unless there is already an active key created after
+// createdBefore. The table is locked so that only one server can rotate the
+// keys at a time.
+func (s *Service) Rotate(k signingkey.Key, createdBefore time.Time) (bool, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := tx.Exec("LOCK TABLE signingKeys IN EXCLUSIVE MODE"); err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	var fresh bool
+	if err := tx.QueryRow("SELECT EXISTS(SELECT 1 FROM signingKeys WHERE retired IS NULL AND created >= $1)", createdBefore).Scan(&fresh); err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	if fresh {
+		return false, tx.Commit()
+	}
+
+	if _, err := tx.Exec("UPDATE signingKeys SET retired = $1 WHERE retired IS NULL", k.Created); err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	if _, err := tx.Exec("INSERT INTO signingKeys (kid, algorithm, privateKey, created) VALUES ($1, $2, $3, $4)", k.ID, k.Algorithm, k.PrivateKey, k.Created); err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+// DeleteRetired removes all signing keys that were retired before a certain
+// time from the postgresql database.
+func (s *Service) DeleteRetired(before time.Time) error {
+	_, err := s.db.Exec("DELETE FROM signingKeys WHERE retired < $1", before)
+	return err
+}
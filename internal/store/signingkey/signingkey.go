@@ -0,0 +1,21 @@
+package signingkey
+
+import "time"
+
+// Key holds a key used for signing and verifying jwts. PrivateKey is the raw
+// secret for HS256, a PKCS #1 DER encoded RSA private key for RS256, and an
+// ed25519 private key for EdDSA.
+type Key struct {
+	ID         string     `json:"kid"`
+	Algorithm  string     `json:"alg"`
+	PrivateKey []byte     `json:"privateKey"`
+	Created    time.Time  `json:"created"`
+	Retired    *time.Time `json:"retired"`
+}
+
+// Service is a store for signing keys.
+type Service interface {
+	GetAll() ([]Key, error)
+	Rotate(k Key, createdBefore time.Time) (rotated bool, err error)
+	DeleteRetired(before time.Time) error
+}
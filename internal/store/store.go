@@ -1,12 +1,21 @@
 package store
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/Pigmice2733/scouting-backend/internal/store/alliance"
+	"github.com/Pigmice2733/scouting-backend/internal/store/bundlekey"
+	"github.com/Pigmice2733/scouting-backend/internal/store/organization"
 	"github.com/Pigmice2733/scouting-backend/internal/store/photo"
 	"github.com/Pigmice2733/scouting-backend/internal/store/picklist"
 	"github.com/Pigmice2733/scouting-backend/internal/store/report"
+	"github.com/Pigmice2733/scouting-backend/internal/store/role"
+	"github.com/Pigmice2733/scouting-backend/internal/store/schema"
+	"github.com/Pigmice2733/scouting-backend/internal/store/session"
+	"github.com/Pigmice2733/scouting-backend/internal/store/sharing"
+	"github.com/Pigmice2733/scouting-backend/internal/store/signingkey"
+	"github.com/Pigmice2733/scouting-backend/internal/store/tag"
 
 	"github.com/Pigmice2733/scouting-backend/internal/store/match"
 
@@ -17,13 +26,47 @@ import (
 // ErrNoResults is a generic error of sql.ErrNoRows.
 var ErrNoResults = fmt.Errorf("no results returned")
 
+// ErrConflict is returned when a record was changed by someone else since the
+// version being updated was read.
+var ErrConflict = fmt.Errorf("record was changed since it was read")
+
 // Service provides an interface for interacting with a store.
 type Service struct {
-	Event    event.Service
-	Match    match.Service
-	Alliance alliance.Service
-	Report   report.Service
-	User     user.Service
-	Photo    photo.Service
-	Picklist picklist.Service
+	Event      event.Service
+	Match      match.Service
+	Alliance   alliance.Service
+	Report     report.Service
+	User       user.Service
+	Role       role.Service
+	Photo      photo.Service
+	Picklist   picklist.Service
+	Session    session.Service
+	SigningKey signingkey.Service
+	Org        organization.Service
+	Sharing    sharing.Service
+	Schema     schema.Service
+	Tag        tag.Service
+	BundleKey  bundlekey.Service
+
+	// Transactor runs changes in a transaction. It is nil if the store doesn't
+	// support transactions.
+	Transactor Transactor
+}
+
+// Transactor runs changes to a store in a transaction.
+type Transactor interface {
+	// Transact calls fn with a Service whose changes are all made in one
+	// transaction, which is committed if fn returns nil and rolled back
+	// otherwise.
+	Transact(ctx context.Context, fn func(s *Service) error) error
+}
+
+// Transact calls fn with a Service whose changes are all made in one
+// transaction, which is committed if fn returns nil and rolled back otherwise.
+// Stores that don't support transactions call fn with themselves.
+func (s *Service) Transact(ctx context.Context, fn func(s *Service) error) error {
+	if s.Transactor == nil {
+		return fn(s)
+	}
+	return s.Transactor.Transact(ctx, fn)
 }
@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/Pigmice2733/scouting-backend/internal/store"
+	"github.com/Pigmice2733/scouting-backend/internal/store/postgres/txdb"
+	"github.com/Pigmice2733/scouting-backend/internal/store/tag"
+)
+
+// Service is used for getting the report tags of an organization from a postgres database.
+type Service struct {
+	db txdb.DB
+}
+
+// New creates a new tag service.
+func New(db txdb.DB) tag.Service {
+	return &Service{db: db}
+}
+
+// Get gets the report tags an organization uses in a season from the
+// postgresql database. If the organization hasn't configured tags for the
+// season, store.ErrNoResults is returned.
+func (s *Service) Get(ctx context.Context, org string, year int) ([]tag.Tag, error) {
+	var configured bool
+	if err := s.db.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM reportTagSets WHERE org = $1 AND year = $2)", org, year).Scan(&configured); err != nil {
+		return nil, err
+	} else if !configured {
+		return nil, store.ErrNoResults
+	}
+
+	rows, err := s.db.QueryContext(ctx, "SELECT name, description FROM reportTags WHERE org = $1 AND year = $2 ORDER BY position", org, year)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := []tag.Tag{}
+	for rows.Next() {
+		var t tag.Tag
+		if err := rows.Scan(&t.Name, &t.Description); err != nil {
+			return nil, err
+		}
+		tags = append(tags, t)
+	}
+
+	return tags, rows.Err()
+}
+
+// Set sets the report tags an organization uses in a season in the postgresql
+// database. The season is recorded as configured even if there are no tags,
+// so that it doesn't fall back to the default tags.
+func (s *Service) Set(ctx context.Context, org string, year int, tags []tag.Tag) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, "INSERT INTO reportTagSets (org, year) VALUES ($1, $2) ON CONFLICT DO NOTHING", org, year); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM reportTags WHERE org = $1 AND year = $2", org, year); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	stmt, err := tx.PrepareContext(ctx, "INSERT INTO reportTags (org, year, name, description, position) VALUES ($1, $2, $3, $4, $5)")
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+
+	for i, t := range tags {
+		if _, err := stmt.ExecContext(ctx, org, year, t.Name, t.Description, i); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
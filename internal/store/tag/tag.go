@@ -0,0 +1,89 @@
+package tag
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+)
+
+// Tag is a robot issue that reports can be tagged with, like a robot breaking
+// down or tipping over.
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// Defaults are the tags of a season that an organization hasn't configured
+// tags for.
+var Defaults = []Tag{
+	{Name: "broke-down", Description: "Robot broke down or stopped moving"},
+	{Name: "tipped", Description: "Robot tipped over"},
+	{Name: "penalty-heavy", Description: "Robot drew a lot of penalties"},
+	{Name: "defended", Description: "Robot was defended against"},
+	{Name: "played-defense", Description: "Robot played defense"},
+}
+
+var nameRegex = regexp.MustCompile("^[a-z0-9]+(-[a-z0-9]+)*$")
+
+// ValidateTags returns an error if a list of tags has invalid or duplicate
+// names.
+func ValidateTags(tags []Tag) error {
+	seen := make(map[string]bool)
+	for _, t := range tags {
+		if !nameRegex.MatchString(t.Name) {
+			return fmt.Errorf("invalid tag name '%s'", t.Name)
+		}
+		if seen[t.Name] {
+			return fmt.Errorf("duplicate tag '%s'", t.Name)
+		}
+		seen[t.Name] = true
+	}
+	return nil
+}
+
+// Validate returns an error if the tags of a report aren't all in the allowed
+// tags, or if a tag is used more than once.
+func Validate(allowed []Tag, used []string) error {
+	names := make(map[string]bool)
+	for _, t := range allowed {
+		names[t.Name] = true
+	}
+
+	seen := make(map[string]bool)
+	for _, name := range used {
+		if !names[name] {
+			return fmt.Errorf("unknown tag '%s'", name)
+		}
+		if seen[name] {
+			return fmt.Errorf("duplicate tag '%s'", name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+// Rates gets the fraction of reports that were tagged with each tag, given the
+// tags of every report. Tags that no report used are left out.
+func Rates(reports [][]string) map[string]float64 {
+	rates := make(map[string]float64)
+	if len(reports) == 0 {
+		return rates
+	}
+
+	for _, tags := range reports {
+		for _, t := range tags {
+			rates[t]++
+		}
+	}
+
+	for t, count := range rates {
+		rates[t] = count / float64(len(reports))
+	}
+	return rates
+}
+
+// Service is a store for the report tags organizations use each season.
+type Service interface {
+	Get(ctx context.Context, org string, year int) ([]Tag, error)
+	Set(ctx context.Context, org string, year int, tags []Tag) error
+}
@@ -0,0 +1,37 @@
+package tag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateTags(t *testing.T) {
+	assert.Nil(t, ValidateTags(Defaults))
+	assert.Nil(t, ValidateTags(nil))
+	assert.NotNil(t, ValidateTags([]Tag{{Name: "Broke Down"}}))
+	assert.NotNil(t, ValidateTags([]Tag{{Name: ""}}))
+	assert.NotNil(t, ValidateTags([]Tag{{Name: "tipped-"}}))
+	assert.NotNil(t, ValidateTags([]Tag{{Name: "tipped"}, {Name: "tipped"}}))
+}
+
+func TestValidate(t *testing.T) {
+	assert.Nil(t, Validate(Defaults, nil))
+	assert.Nil(t, Validate(Defaults, []string{"tipped", "defended"}))
+	assert.NotNil(t, Validate(Defaults, []string{"brownout"}))
+	assert.NotNil(t, Validate(Defaults, []string{"tipped", "tipped"}))
+	assert.NotNil(t, Validate(nil, []string{"tipped"}))
+}
+
+func TestRates(t *testing.T) {
+	assert.Equal(t, map[string]float64{}, Rates(nil))
+	assert.Equal(t, map[string]float64{
+		"tipped":     0.25,
+		"broke-down": 0.5,
+	}, Rates([][]string{
+		{"tipped", "broke-down"},
+		{},
+		{"broke-down"},
+		nil,
+	}))
+}
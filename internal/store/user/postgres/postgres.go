@@ -1,37 +1,78 @@
 package postgres
 
+import "context"
 import "database/sql"
 import "github.com/Pigmice2733/scouting-backend/internal/store/user"
 import "github.com/Pigmice2733/scouting-backend/internal/store"
+import "github.com/Pigmice2733/scouting-backend/internal/store/postgres/txdb"
 
 // Service is used for getting information about a user from a postgres database.
 type Service struct {
-	db *sql.DB
+	db txdb.DB
 }
 
 // New creates a new user service.
-func New(db *sql.DB) user.Service {
+func New(db txdb.DB) user.Service {
 	return &Service{db: db}
 }
 
-// Create creates a new user in the postgresql database.
-func (s *Service) Create(u user.User) error {
-	_, err := s.db.Exec("INSERT INTO users VALUES ($1, $2, $3, $4)", u.Username, u.HashedPassword, u.IsAdmin, u.IsVerified)
-	return err
+// Create creates a new user along with their roles in the postgresql database.
+func (s *Service) Create(ctx context.Context, u user.User) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, "INSERT INTO users (username, hashedPassword, isVerified, org) VALUES ($1, $2, $3, $4)", u.Username, u.HashedPassword, u.IsVerified, u.Org); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := setRoles(ctx, tx, u.Username, u.Roles); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
 }
 
 // Get gets a user with a given username from the postgresql database.
-func (s *Service) Get(username string) (u user.User, err error) {
-	err = s.db.QueryRow("SELECT username, hashedPassword, isAdmin, isVerified FROM users WHERE username = $1", username).Scan(&u.Username, &u.HashedPassword, &u.IsAdmin, &u.IsVerified)
+func (s *Service) Get(ctx context.Context, username string) (u user.User, err error) {
+	err = s.db.QueryRowContext(ctx, "SELECT org, username, hashedPassword, isVerified FROM users WHERE username = $1", username).Scan(&u.Org, &u.Username, &u.HashedPassword, &u.IsVerified)
 	if err == sql.ErrNoRows {
-		err = store.ErrNoResults
+		return u, store.ErrNoResults
+	} else if err != nil {
+		return u, err
+	}
+
+	u.Roles = []string{}
+
+	rows, err := s.db.QueryContext(ctx, "SELECT role FROM userRoles WHERE username = $1 ORDER BY role", username)
+	if err != nil {
+		return u, err
 	}
-	return
+	defer rows.Close()
+
+	for rows.Next() {
+		var role string
+		if err := rows.Scan(&role); err != nil {
+			return u, err
+		}
+		u.Roles = append(u.Roles, role)
+	}
+
+	return u, rows.Err()
 }
 
-// GetUsers gets all users in the postgresql database.
-func (s *Service) GetUsers() ([]user.User, error) {
-	rows, err := s.db.Query("SELECT username, hashedPassword, isAdmin, isVerified from users")
+// GetUsers gets all users of an organization in the postgresql database.
+func (s *Service) GetUsers(ctx context.Context, org string) ([]user.User, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT users.org, users.username, users.hashedPassword, users.isVerified, userRoles.role
+			FROM users
+			LEFT JOIN userRoles ON userRoles.username = users.username
+			WHERE users.org = $1
+			ORDER BY users.username, userRoles.role
+		`, org)
 	if err != nil {
 		return []user.User{}, err
 	}
@@ -40,32 +81,92 @@ func (s *Service) GetUsers() ([]user.User, error) {
 	var users []user.User
 	for rows.Next() {
 		var u user.User
-		if err := rows.Scan(&u.Username, &u.HashedPassword, &u.IsAdmin, &u.IsVerified); err != nil {
+		var role *string
+		if err := rows.Scan(&u.Org, &u.Username, &u.HashedPassword, &u.IsVerified, &role); err != nil {
 			return users, err
 		}
-		users = append(users, u)
+
+		if len(users) == 0 || users[len(users)-1].Username != u.Username {
+			u.Roles = []string{}
+			users = append(users, u)
+		}
+
+		if role != nil {
+			last := &users[len(users)-1]
+			last.Roles = append(last.Roles, *role)
+		}
 	}
 
 	return users, rows.Err()
 }
 
-// Update updates a given user in the postgresql database.
-func (s *Service) Update(username string, nu user.NullableUser) error {
-	_, err := s.db.Exec(`
+// Update updates a given user of an organization in the postgresql database.
+// If the user doesn't exist in the organization store.ErrNoResults is returned.
+func (s *Service) Update(ctx context.Context, org, username string, nu user.NullableUser) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, `
 		UPDATE users
 			SET
 				username = COALESCE($1, username),
 				hashedPassword = COALESCE($2, hashedPassword),
-				isAdmin = COALESCE($3, isAdmin),
-				isVerified = COALESCE($4, isVerified)
+				isVerified = COALESCE($3, isVerified)
 			WHERE
-				username = $5
-		`, nu.Username, nu.HashedPassword, nu.IsAdmin, nu.IsVerified, username)
-	return err
+				username = $4 AND org = $5
+		`, nu.Username, nu.HashedPassword, nu.IsVerified, username, org)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		tx.Rollback()
+		return err
+	} else if n == 0 {
+		tx.Rollback()
+		return store.ErrNoResults
+	}
+
+	if nu.Roles != nil {
+		if nu.Username != nil {
+			username = *nu.Username
+		}
+
+		if _, err := tx.ExecContext(ctx, "DELETE FROM userRoles WHERE username = $1", username); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if err := setRoles(ctx, tx, username, nu.Roles); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
 }
 
-// Delete removes an existing user with a given username from the postgresql database.
-func (s *Service) Delete(username string) error {
-	_, err := s.db.Exec("DELETE FROM users WHERE username = $1", username)
+// Delete removes an existing user of an organization with a given username from the postgresql database.
+func (s *Service) Delete(ctx context.Context, org, username string) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM users WHERE username = $1 AND org = $2", username, org)
 	return err
 }
+
+func setRoles(ctx context.Context, tx txdb.Tx, username string, roles []string) error {
+	stmt, err := tx.PrepareContext(ctx, "INSERT INTO userRoles (username, role) VALUES ($1, $2) ON CONFLICT DO NOTHING")
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, role := range roles {
+		if _, err := stmt.ExecContext(ctx, username, role); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -1,26 +1,42 @@
 package user
 
+import "context"
+
 // User holds information about a user.
 type User struct {
-	Username       string `json:"username"`
-	HashedPassword string `json:"hashedPassword"`
-	IsAdmin        bool   `json:"isAdmin"`
-	IsVerified     bool   `json:"isVerified"`
+	Org            string   `json:"org"`
+	Username       string   `json:"username"`
+	HashedPassword string   `json:"hashedPassword"`
+	IsVerified     bool     `json:"isVerified"`
+	Roles          []string `json:"roles"`
+}
+
+// HasRole returns whether the user has a role.
+func (u User) HasRole(role string) bool {
+	for _, r := range u.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
 }
 
-// NullableUser is a nullable version of user.
+// NullableUser is a nullable version of user. A nil Roles slice leaves the
+// roles of the user unchanged.
 type NullableUser struct {
-	Username       *string `json:"username"`
-	HashedPassword *string `json:"hashedPassword"`
-	IsAdmin        *bool   `json:"isAdmin"`
-	IsVerified     *bool   `json:"isVerified"`
+	Username       *string  `json:"username"`
+	HashedPassword *string  `json:"hashedPassword"`
+	IsVerified     *bool    `json:"isVerified"`
+	Roles          []string `json:"roles"`
 }
 
-// Service is a store for users.
+// Service is a store for users. Usernames are unique across all
+// organizations, but users can only be listed, updated and deleted through
+// their organization.
 type Service interface {
-	Create(User) error
-	Get(username string) (User, error)
-	GetUsers() ([]User, error)
-	Update(username string, nu NullableUser) error
-	Delete(username string) error
+	Create(ctx context.Context, u User) error
+	Get(ctx context.Context, username string) (User, error)
+	GetUsers(ctx context.Context, org string) ([]User, error)
+	Update(ctx context.Context, org, username string, nu NullableUser) error
+	Delete(ctx context.Context, org, username string) error
 }
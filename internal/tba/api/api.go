@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -11,47 +12,55 @@ import (
 	"github.com/Pigmice2733/scouting-backend/internal/store/match"
 	"github.com/Pigmice2733/scouting-backend/internal/tba"
 	"github.com/Pigmice2733/scouting-backend/internal/tba/api/lastmodified"
+	"github.com/Pigmice2733/scouting-backend/internal/tba/client"
 )
 
-var lastModified = lastmodified.New()
-
 const imgurFormat = "http://i.imgur.com/%sl.jpg"
 const youtubeFormat = "https://www.youtube.com/watch?v=%s"
 
 // Consumer consumes info from the TBA api.
 type Consumer struct {
-	tbaURL string
-	tbaKey string
+	tbaURL       string
+	tbaKey       string
+	client       *client.Client
+	lastModified lastmodified.Manager
+}
+
+// New returns a new TBA API Consumer, which makes requests with a client.
+func New(tbaURL, tbaKey string, c *client.Client) *Consumer {
+	return &Consumer{tbaURL: tbaURL, tbaKey: tbaKey, client: c, lastModified: lastmodified.New()}
 }
 
-// New returns a new TBA API Consumer.
-func New(tbaURL, tbaKey string) *Consumer {
-	return &Consumer{tbaURL: tbaURL, tbaKey: tbaKey}
+// Upstreams gets the stats of the requests made to the TBA API.
+func (c Consumer) Upstreams() map[string]client.Stats {
+	return map[string]client.Stats{"tba": c.client.Stats()}
 }
 
-func (c Consumer) makeRequest(path string) (*http.Response, error) {
+func (c Consumer) makeRequest(ctx context.Context, path string) (*http.Response, error) {
 	req, err := http.NewRequest("GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
 
-	if lastModified := lastModified.Get(path); lastModified != "" {
+	if lastModified := c.lastModified.Get(path); lastModified != "" {
 		req.Header.Set("If-Modified-Since", lastModified)
 	}
 
 	req.Header.Set("X-TBA-Auth-Key", c.tbaKey)
 
-	return http.DefaultClient.Do(req)
+	return c.client.Do(req)
 }
 
 // GetEvents retrieves all associated events from the blue alliance API.
-func (c Consumer) GetEvents(year int) ([]event.BasicEvent, error) {
+func (c Consumer) GetEvents(ctx context.Context, year int) ([]event.BasicEvent, error) {
 	path := fmt.Sprintf("%s/events/%d", c.tbaURL, year)
 
-	resp, err := c.makeRequest(path)
+	resp, err := c.makeRequest(ctx, path)
 	if err != nil {
 		return []event.BasicEvent{}, err
 	}
+	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusNotModified {
 		return []event.BasicEvent{}, tba.ErrNotModified
@@ -91,19 +100,20 @@ func (c Consumer) GetEvents(year int) ([]event.BasicEvent, error) {
 		})
 	}
 
-	lastModified.Set(path, resp.Header.Get("Last-Modified"))
+	c.lastModified.Set(path, resp.Header.Get("Last-Modified"))
 
 	return bEvents, nil
 }
 
 // GetMatches retrieves all associated matches from the blue alliance API.
-func (c Consumer) GetMatches(eventKey string) ([]match.Match, error) {
+func (c Consumer) GetMatches(ctx context.Context, eventKey string) ([]match.Match, error) {
 	path := fmt.Sprintf("%s/event/%s/matches", c.tbaURL, eventKey)
 
-	resp, err := c.makeRequest(path)
+	resp, err := c.makeRequest(ctx, path)
 	if err != nil {
 		return []match.Match{}, err
 	}
+	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusNotModified {
 		return []match.Match{}, tba.ErrNotModified
@@ -157,18 +167,31 @@ func (c Consumer) GetMatches(eventKey string) ([]match.Match, error) {
 		})
 	}
 
-	lastModified.Set(path, resp.Header.Get("Last-Modified"))
+	c.lastModified.Set(path, resp.Header.Get("Last-Modified"))
 
 	return bMatches, nil
 }
 
-func (c Consumer) getMedia(team string, year int) ([]media, error) {
+// ForgetEvents makes the next GetEvents for a year retrieve the events even if
+// they haven't been modified.
+func (c Consumer) ForgetEvents(year int) {
+	c.lastModified.Delete(fmt.Sprintf("%s/events/%d", c.tbaURL, year))
+}
+
+// ForgetMatches makes the next GetMatches for an event retrieve the matches even
+// if they haven't been modified.
+func (c Consumer) ForgetMatches(eventKey string) {
+	c.lastModified.Delete(fmt.Sprintf("%s/event/%s/matches", c.tbaURL, eventKey))
+}
+
+func (c Consumer) getMedia(ctx context.Context, team string, year int) ([]media, error) {
 	path := fmt.Sprintf("%s/team/%s/media/%d", c.tbaURL, team, year)
 
-	resp, err := c.makeRequest(path)
+	resp, err := c.makeRequest(ctx, path)
 	if err != nil {
 		return []media{}, err
 	}
+	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusNotModified {
 		return []media{}, tba.ErrNotModified
@@ -186,8 +209,8 @@ func (c Consumer) getMedia(team string, year int) ([]media, error) {
 
 // GetPhotoURL returns the optimal photo url for a team in a certain year from
 // TBA api.
-func (c Consumer) GetPhotoURL(team string, year int) (url string, err error) {
-	media, err := c.getMedia(team, year)
+func (c Consumer) GetPhotoURL(ctx context.Context, team string, year int) (url string, err error) {
+	media, err := c.getMedia(ctx, team, year)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,252 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Pigmice2733/scouting-backend/internal/tba"
+	"github.com/Pigmice2733/scouting-backend/internal/tba/client"
+	"github.com/Pigmice2733/scouting-backend/internal/tba/fake"
+	"github.com/stretchr/testify/assert"
+)
+
+const key = "key"
+
+func fakeTBA(t *testing.T) (*fake.Server, *httptest.Server) {
+	f, err := fake.Load("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Key = key
+
+	return f, httptest.NewServer(f)
+}
+
+func TestGetEvents(t *testing.T) {
+	_, ts := fakeTBA(t)
+	defer ts.Close()
+
+	c := New(ts.URL, key, client.New(client.Options{}))
+
+	events, err := c.GetEvents(context.Background(), 2018)
+	if !assert.Nil(t, err) || !assert.Len(t, events, 2) {
+		return
+	}
+
+	pacific, _ := time.LoadLocation("America/Los_Angeles")
+	central, _ := time.LoadLocation("America/Chicago")
+
+	orwil := events[0]
+	assert.Equal(t, "2018orwil", orwil.Key)
+	assert.Equal(t, "PNW District Wilsonville Event", orwil.Name)
+	assert.Equal(t, "Wilsonville", orwil.ShortName)
+	assert.Equal(t, 1, orwil.EventType)
+	if assert.NotNil(t, orwil.Lat) && assert.NotNil(t, orwil.Long) {
+		assert.Equal(t, 45.3007, *orwil.Lat)
+		assert.Equal(t, -122.7737, *orwil.Long)
+	}
+	assert.True(t, time.Date(2018, 3, 2, 0, 0, 0, 0, pacific).Equal(orwil.Date))
+	assert.True(t, time.Date(2018, 3, 4, 0, 0, 0, 0, pacific).Equal(orwil.EndDate))
+
+	cmptx := events[1]
+	assert.Equal(t, "2018cmptx", cmptx.Key)
+	assert.Equal(t, 4, cmptx.EventType)
+	assert.Nil(t, cmptx.Lat)
+	assert.Nil(t, cmptx.Long)
+	assert.True(t, time.Date(2018, 4, 18, 0, 0, 0, 0, central).Equal(cmptx.Date))
+}
+
+func TestGetEventsNotModified(t *testing.T) {
+	f, ts := fakeTBA(t)
+	defer ts.Close()
+
+	c := New(ts.URL, key, client.New(client.Options{}))
+
+	_, err := c.GetEvents(context.Background(), 2018)
+	assert.Nil(t, err)
+
+	_, err = c.GetEvents(context.Background(), 2018)
+	assert.Equal(t, tba.ErrNotModified, err)
+
+	// consumers don't share last modified times
+	_, err = New(ts.URL, key, client.New(client.Options{})).GetEvents(context.Background(), 2018)
+	assert.Nil(t, err)
+
+	c.ForgetEvents(2018)
+	_, err = c.GetEvents(context.Background(), 2018)
+	assert.Nil(t, err)
+
+	f.Set("/events/2018", []byte("[]"), time.Now().Add(time.Minute))
+	events, err := c.GetEvents(context.Background(), 2018)
+	assert.Nil(t, err)
+	assert.Empty(t, events)
+
+	assert.Equal(t, 5, f.Requests("/events/2018"))
+}
+
+func TestGetMatches(t *testing.T) {
+	_, ts := fakeTBA(t)
+	defer ts.Close()
+
+	c := New(ts.URL, key, client.New(client.Options{}))
+
+	matches, err := c.GetMatches(context.Background(), "2018orwil")
+	if !assert.Nil(t, err) || !assert.Len(t, matches, 2) {
+		return
+	}
+
+	qm1 := matches[0]
+	assert.Equal(t, "2018orwil_qm1", qm1.Key)
+	assert.Equal(t, "2018orwil", qm1.EventKey)
+	assert.Equal(t, 120, qm1.RedScore)
+	assert.Equal(t, 98, qm1.BlueScore)
+	assert.Equal(t, []string{"frc2733", "frc1540", "frc4488"}, qm1.RedAlliance)
+	assert.Equal(t, []string{"frc1425", "frc360", "frc1318"}, qm1.BlueAlliance)
+	assert.Equal(t, "https://www.youtube.com/watch?v=dTjzn4HCP-o", qm1.YoutubeURL)
+	if assert.NotNil(t, qm1.PredictedTime) && assert.NotNil(t, qm1.ActualTime) {
+		// the predicted time is used over the scheduled time
+		assert.Equal(t, int64(1520096460), qm1.PredictedTime.Unix())
+		assert.Equal(t, int64(1520096485), qm1.ActualTime.Unix())
+	}
+
+	qm2 := matches[1]
+	assert.Equal(t, "2018orwil_qm2", qm2.Key)
+	assert.Equal(t, -1, qm2.RedScore)
+	assert.Equal(t, -1, qm2.BlueScore)
+	assert.Equal(t, "", qm2.YoutubeURL)
+	assert.Nil(t, qm2.ActualTime)
+	if assert.NotNil(t, qm2.PredictedTime) {
+		assert.Equal(t, int64(1520096820), qm2.PredictedTime.Unix())
+	}
+
+	_, err = c.GetMatches(context.Background(), "2018orwil")
+	assert.Equal(t, tba.ErrNotModified, err)
+
+	c.ForgetMatches("2018orwil")
+	matches, err = c.GetMatches(context.Background(), "2018orwil")
+	assert.Nil(t, err)
+	assert.Len(t, matches, 2)
+}
+
+func TestGetPhotoURL(t *testing.T) {
+	_, ts := fakeTBA(t)
+	defer ts.Close()
+
+	c := New(ts.URL, key, client.New(client.Options{}))
+
+	url, err := c.GetPhotoURL(context.Background(), "frc2733", 2018)
+	assert.Nil(t, err)
+	assert.Equal(t, "https://www.instagram.com/p/BfGcBgBnOHG/media/?size=l", url)
+
+	url, err = c.GetPhotoURL(context.Background(), "frc1540", 2018)
+	assert.Nil(t, err)
+	assert.Equal(t, "", url)
+
+	// teams without media aren't an error
+	url, err = c.GetPhotoURL(context.Background(), "frc9999", 2018)
+	assert.Nil(t, err)
+	assert.Equal(t, "", url)
+}
+
+func TestErrors(t *testing.T) {
+	f, ts := fakeTBA(t)
+	defer ts.Close()
+
+	_, err := New(ts.URL, "wrong", client.New(client.Options{})).GetEvents(context.Background(), 2018)
+	assert.NotNil(t, err)
+
+	_, err = New(ts.URL, key, client.New(client.Options{})).GetMatches(context.Background(), "2018orore")
+	assert.NotNil(t, err)
+
+	c := New(ts.URL, key, client.New(client.Options{}))
+
+	f.Fail("/events/2018", http.StatusInternalServerError, http.StatusServiceUnavailable)
+	_, err = c.GetEvents(context.Background(), 2018)
+	assert.NotNil(t, err)
+	_, err = c.GetEvents(context.Background(), 2018)
+	assert.NotNil(t, err)
+
+	// failed requests don't affect last modified times
+	_, err = c.GetEvents(context.Background(), 2018)
+	assert.Nil(t, err)
+
+	f.Fail("/team/frc2733/media/2018", http.StatusInternalServerError)
+	_, err = c.GetPhotoURL(context.Background(), "frc2733", 2018)
+	assert.NotNil(t, err)
+}
+
+func TestLatency(t *testing.T) {
+	f, ts := fakeTBA(t)
+	defer ts.Close()
+
+	f.SetLatency(time.Millisecond * 50)
+
+	start := time.Now()
+	_, err := New(ts.URL, key, client.New(client.Options{})).GetEvents(context.Background(), 2018)
+	assert.Nil(t, err)
+	assert.True(t, time.Since(start) >= time.Millisecond*50)
+}
+
+func TestCancel(t *testing.T) {
+	f, ts := fakeTBA(t)
+	defer ts.Close()
+
+	c := New(ts.URL, key, client.New(client.Options{}))
+
+	f.SetLatency(time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*20)
+	defer cancel()
+
+	start := time.Now()
+	_, err := c.GetEvents(ctx, 2018)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.True(t, time.Since(start) < time.Second)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	_, err = c.GetMatches(ctx, "2018orwil")
+	assert.Equal(t, context.Canceled, err)
+	_, err = c.GetPhotoURL(ctx, "frc2733", 2018)
+	assert.Equal(t, context.Canceled, err)
+
+	// cancelled requests don't affect last modified times
+	f.SetLatency(0)
+	events, err := c.GetEvents(context.Background(), 2018)
+	assert.Nil(t, err)
+	assert.Len(t, events, 2)
+}
+
+func TestResilience(t *testing.T) {
+	f, ts := fakeTBA(t)
+	defer ts.Close()
+
+	c := New(ts.URL, key, client.New(client.Options{
+		Timeout:    time.Millisecond * 100,
+		Retries:    1,
+		MinBackoff: time.Millisecond,
+		MaxBackoff: time.Millisecond,
+	}))
+
+	// transient errors are retried
+	f.Fail("/events/2018", http.StatusBadGateway)
+	events, err := c.GetEvents(context.Background(), 2018)
+	assert.Nil(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, 2, f.Requests("/events/2018"))
+
+	// a hung tba doesn't block polling forever
+	f.SetLatency(time.Second)
+	start := time.Now()
+	_, err = c.GetMatches(context.Background(), "2018orwil")
+	assert.NotNil(t, err)
+	assert.True(t, time.Since(start) < time.Second)
+
+	stats := c.Upstreams()["tba"]
+	assert.Equal(t, int64(2), stats.Requests)
+	assert.Equal(t, int64(4), stats.Attempts)
+	assert.Equal(t, int64(1), stats.Failures)
+}
@@ -27,3 +27,10 @@ func (m Manager) Set(path, lastModified string) {
 	m.pathLastModified[path] = lastModified
 	m.mu.Unlock()
 }
+
+// Delete forgets the last modified string for a URL.
+func (m Manager) Delete(path string) {
+	m.mu.Lock()
+	delete(m.pathLastModified, path)
+	m.mu.Unlock()
+}
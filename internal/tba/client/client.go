@@ -0,0 +1,369 @@
+// Package client is an HTTP client for the upstream APIs events and matches are
+// consumed from. It gives every attempt a deadline, retries server and network
+// errors with exponential backoff, limits the rate of requests with a token
+// bucket, and stops requesting an upstream that keeps failing until it has had
+// time to recover.
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned instead of requesting an upstream that has failed
+// too many times in a row.
+var ErrCircuitOpen = errors.New("client: upstream is failing, not requesting it until it recovers")
+
+// Options configures a client.
+type Options struct {
+	// Timeout is the deadline of each attempt of a request, including reading
+	// the response body.
+	Timeout time.Duration
+	// Retries is how many times a request that failed with a server or network
+	// error is retried.
+	Retries int
+	// MinBackoff is the wait before the first retry, which doubles with every
+	// retry up to MaxBackoff.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// Rate is the number of requests per second allowed on average, with bursts
+	// of up to Burst requests. A rate of 0 is unlimited.
+	Rate  float64
+	Burst int
+	// FailureThreshold is the number of requests in a row that have to fail for
+	// the circuit to open. Once open, requests fail with ErrCircuitOpen until
+	// Cooldown has passed, when a single request is let through to check if the
+	// upstream has recovered. A threshold of 0 never opens the circuit.
+	FailureThreshold int
+	Cooldown         time.Duration
+	// Transport is the transport requests are made with, defaulting to
+	// http.DefaultTransport.
+	Transport http.RoundTripper
+}
+
+// DefaultOptions are reasonable options for the TBA and FRC Events APIs.
+var DefaultOptions = Options{
+	Timeout:          time.Second * 5,
+	Retries:          2,
+	MinBackoff:       time.Millisecond * 250,
+	MaxBackoff:       time.Second * 4,
+	Rate:             10,
+	Burst:            20,
+	FailureThreshold: 5,
+	Cooldown:         time.Second * 30,
+}
+
+// Circuit states.
+const (
+	Closed   = "closed"
+	Open     = "open"
+	HalfOpen = "half-open"
+)
+
+// Stats are the stats of the requests made with a client, for monitoring the
+// health of its upstream.
+type Stats struct {
+	// Requests is the number of requests made, and Attempts the number of
+	// attempts including retries.
+	Requests int64 `json:"requests"`
+	Attempts int64 `json:"attempts"`
+	Retries  int64 `json:"retries"`
+	// Failures is the number of requests that failed after all retries.
+	Failures int64 `json:"failures"`
+	// Throttled is the number of requests that waited for the rate limit.
+	Throttled int64 `json:"throttled"`
+	// Rejected is the number of requests that failed because the circuit was
+	// open.
+	Rejected            int64      `json:"rejected"`
+	Circuit             string     `json:"circuit"`
+	ConsecutiveFailures int        `json:"consecutiveFailures"`
+	AverageLatency      float64    `json:"averageLatencyMs"`
+	LastSuccess         *time.Time `json:"lastSuccess"`
+	LastFailure         *time.Time `json:"lastFailure"`
+	LastError           string     `json:"lastError,omitempty"`
+}
+
+// Client makes requests to an upstream API.
+type Client struct {
+	opts   Options
+	client *http.Client
+
+	mu          sync.Mutex
+	stats       Stats
+	latency     time.Duration
+	tokens      float64
+	refilled    time.Time
+	openedAt    time.Time
+	trialing    bool
+	consecutive int
+}
+
+// New creates a client with options.
+func New(opts Options) *Client {
+	transport := opts.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &Client{
+		opts:     opts,
+		client:   &http.Client{Transport: transport},
+		stats:    Stats{Circuit: Closed},
+		tokens:   float64(opts.Burst),
+		refilled: time.Now(),
+	}
+}
+
+// Do sends a request, retrying it if it fails with a server or network error.
+// Requests with bodies aren't retried. The context of the request bounds all
+// attempts and waits, while each attempt is also bounded by the client
+// timeout. The response body must be closed, even when a response with a
+// server error status code is returned after all retries failed.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	c.mu.Lock()
+	c.stats.Requests++
+	allowed := c.allow(time.Now())
+	if !allowed {
+		c.stats.Rejected++
+	}
+	c.mu.Unlock()
+
+	if !allowed {
+		return nil, ErrCircuitOpen
+	}
+
+	retries := c.opts.Retries
+	if req.Body != nil {
+		retries = 0
+	}
+
+	for attempt := 0; ; attempt++ {
+		if err := c.wait(ctx); err != nil {
+			c.done(ctx, err)
+			return nil, err
+		}
+
+		resp, err := c.attempt(req)
+
+		var wait time.Duration
+		switch {
+		case err != nil:
+		case resp.StatusCode >= 500:
+			err = fmt.Errorf("client: upstream responded with status code: %d", resp.StatusCode)
+		case resp.StatusCode == http.StatusTooManyRequests:
+			err = fmt.Errorf("client: upstream is rate limiting requests")
+			if seconds, perr := strconv.Atoi(resp.Header.Get("Retry-After")); perr == nil {
+				wait = time.Duration(seconds) * time.Second
+			}
+		default:
+			c.done(ctx, nil)
+			return resp, nil
+		}
+
+		if attempt >= retries || ctx.Err() != nil {
+			c.done(ctx, err)
+			if resp != nil {
+				return resp, nil
+			} else if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, err
+		}
+
+		if resp != nil {
+			io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 4096))
+			resp.Body.Close()
+		}
+
+		if backoff := c.backoff(attempt); wait < backoff {
+			wait = backoff
+		} else if wait > c.opts.MaxBackoff {
+			wait = c.opts.MaxBackoff
+		}
+
+		c.mu.Lock()
+		c.stats.Retries++
+		c.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			c.done(ctx, ctx.Err())
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// attempt makes one attempt of a request with the client timeout. The timeout
+// is cancelled when the response body is closed.
+func (c *Client) attempt(req *http.Request) (*http.Response, error) {
+	ctx, cancel := req.Context(), context.CancelFunc(func() {})
+	if c.opts.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, c.opts.Timeout)
+	}
+
+	start := time.Now()
+	resp, err := c.client.Do(req.WithContext(ctx))
+
+	c.mu.Lock()
+	c.stats.Attempts++
+	c.latency += time.Since(start)
+	c.mu.Unlock()
+
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+
+	return resp, nil
+}
+
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// backoff gets the wait before a retry, doubling with every attempt, with some
+// jitter so that retries of many requests are spread out.
+func (c *Client) backoff(attempt int) time.Duration {
+	backoff := c.opts.MinBackoff << uint(attempt)
+	if backoff > c.opts.MaxBackoff || backoff <= 0 {
+		backoff = c.opts.MaxBackoff
+	}
+	if backoff <= 0 {
+		return 0
+	}
+
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
+
+// wait waits for a token from the rate limit's bucket.
+func (c *Client) wait(ctx context.Context) error {
+	if c.opts.Rate <= 0 {
+		return nil
+	}
+
+	c.mu.Lock()
+	now := time.Now()
+	c.tokens += now.Sub(c.refilled).Seconds() * c.opts.Rate
+	if burst := float64(c.opts.Burst); c.tokens > burst {
+		c.tokens = burst
+	}
+	c.refilled = now
+	c.tokens--
+	tokens := c.tokens
+	if tokens < 0 {
+		c.stats.Throttled++
+	}
+	c.mu.Unlock()
+
+	if tokens >= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(time.Duration(-tokens / c.opts.Rate * float64(time.Second)))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		c.mu.Lock()
+		c.tokens++
+		c.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// allow checks whether the circuit lets a request through, opening a half-open
+// circuit for a single trial request once the cooldown has passed. c.mu must be
+// held.
+func (c *Client) allow(now time.Time) bool {
+	switch c.stats.Circuit {
+	case Open:
+		if now.Sub(c.openedAt) < c.opts.Cooldown {
+			return false
+		}
+		c.stats.Circuit = HalfOpen
+		c.trialing = true
+		return true
+	case HalfOpen:
+		if c.trialing {
+			return false
+		}
+		c.trialing = true
+		return true
+	}
+
+	return true
+}
+
+// done records the result of a request. Requests given up on by the caller
+// don't count as upstream failures.
+func (c *Client) done(ctx context.Context, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	c.trialing = false
+
+	if err == nil {
+		c.consecutive = 0
+		c.stats.Circuit = Closed
+		c.stats.LastSuccess = &now
+		return
+	}
+
+	c.stats.Failures++
+	c.stats.LastFailure = &now
+	c.stats.LastError = err.Error()
+
+	if ctx.Err() != nil {
+		return
+	}
+
+	c.consecutive++
+	if c.stats.Circuit == HalfOpen || (c.opts.FailureThreshold > 0 && c.consecutive >= c.opts.FailureThreshold) {
+		c.stats.Circuit = Open
+		c.openedAt = now
+	}
+}
+
+// Stats gets the stats of the client.
+func (c *Client) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.ConsecutiveFailures = c.consecutive
+	if c.stats.Attempts > 0 {
+		stats.AverageLatency = float64(c.latency) / float64(c.stats.Attempts) / float64(time.Millisecond)
+	}
+
+	// a circuit whose cooldown has passed lets the next request through
+	if stats.Circuit == Open && time.Since(c.openedAt) >= c.opts.Cooldown {
+		stats.Circuit = HalfOpen
+	}
+
+	return stats
+}
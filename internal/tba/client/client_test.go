@@ -0,0 +1,160 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Pigmice2733/scouting-backend/internal/tba/fake"
+	"github.com/stretchr/testify/assert"
+)
+
+var modified = time.Date(2018, 3, 3, 17, 10, 0, 0, time.UTC)
+
+func fakeServer() (*fake.Server, *httptest.Server) {
+	f := fake.New()
+	f.Set("/events/2018", []byte("[]"), modified)
+	return f, httptest.NewServer(f)
+}
+
+func get(c *Client, url string) (int, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+
+	return resp.StatusCode, nil
+}
+
+func TestRetries(t *testing.T) {
+	f, ts := fakeServer()
+	defer ts.Close()
+
+	// without keep alives, so the transport doesn't retry dropped connections
+	// itself
+	c := New(Options{
+		Retries:    2,
+		MinBackoff: time.Millisecond,
+		MaxBackoff: time.Millisecond * 2,
+		Transport:  &http.Transport{DisableKeepAlives: true},
+	})
+
+	f.Fail("/events/2018", http.StatusInternalServerError, fake.DropConnection)
+	status, err := get(c, ts.URL+"/events/2018")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, 3, f.Requests("/events/2018"))
+
+	// client errors aren't retried
+	status, err = get(c, ts.URL+"/events/2017")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Equal(t, 1, f.Requests("/events/2017"))
+
+	// the last response is returned when all retries fail
+	f.Fail("/events/2018", http.StatusBadGateway, http.StatusBadGateway, http.StatusServiceUnavailable)
+	status, err = get(c, ts.URL+"/events/2018")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+
+	stats := c.Stats()
+	assert.Equal(t, int64(3), stats.Requests)
+	assert.Equal(t, int64(7), stats.Attempts)
+	assert.Equal(t, int64(4), stats.Retries)
+	assert.Equal(t, int64(1), stats.Failures)
+	assert.Equal(t, 1, stats.ConsecutiveFailures)
+	assert.NotNil(t, stats.LastSuccess)
+	assert.NotNil(t, stats.LastFailure)
+}
+
+func TestTimeout(t *testing.T) {
+	f, ts := fakeServer()
+	defer ts.Close()
+
+	f.SetLatency(time.Second)
+
+	c := New(Options{Timeout: time.Millisecond * 20})
+
+	start := time.Now()
+	_, err := get(c, ts.URL+"/events/2018")
+	assert.NotNil(t, err)
+	assert.True(t, time.Since(start) < time.Second)
+
+	// the context of a request bounds all retries
+	c = New(Options{Timeout: time.Millisecond * 20, Retries: 10, MinBackoff: time.Millisecond * 20, MaxBackoff: time.Millisecond * 20})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+
+	req, _ := http.NewRequest("GET", ts.URL+"/events/2018", nil)
+	start = time.Now()
+	_, err = c.Do(req.WithContext(ctx))
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.True(t, time.Since(start) < time.Millisecond*500)
+
+	// requests given up on by the caller don't count towards opening the circuit
+	assert.Equal(t, 0, c.Stats().ConsecutiveFailures)
+}
+
+func TestRateLimit(t *testing.T) {
+	_, ts := fakeServer()
+	defer ts.Close()
+
+	c := New(Options{Rate: 50, Burst: 2})
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		_, err := get(c, ts.URL+"/events/2018")
+		assert.Nil(t, err)
+	}
+
+	// two requests burst, and the other two wait 20ms each
+	assert.True(t, time.Since(start) >= time.Millisecond*35)
+	assert.Equal(t, int64(2), c.Stats().Throttled)
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	f, ts := fakeServer()
+	defer ts.Close()
+
+	c := New(Options{FailureThreshold: 2, Cooldown: time.Millisecond * 50})
+
+	f.Fail("/events/2018", http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError)
+
+	for i := 0; i < 2; i++ {
+		status, err := get(c, ts.URL+"/events/2018")
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, status)
+	}
+	assert.Equal(t, Open, c.Stats().Circuit)
+
+	_, err := get(c, ts.URL+"/events/2018")
+	assert.Equal(t, ErrCircuitOpen, err)
+	assert.Equal(t, 2, f.Requests("/events/2018"))
+
+	// after the cooldown a failing trial request opens the circuit again
+	time.Sleep(time.Millisecond * 60)
+	assert.Equal(t, HalfOpen, c.Stats().Circuit)
+	_, err = get(c, ts.URL+"/events/2018")
+	assert.Nil(t, err)
+	assert.Equal(t, Open, c.Stats().Circuit)
+
+	// and a successful one closes it
+	time.Sleep(time.Millisecond * 60)
+	status, err := get(c, ts.URL+"/events/2018")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, status)
+
+	stats := c.Stats()
+	assert.Equal(t, Closed, stats.Circuit)
+	assert.Equal(t, 0, stats.ConsecutiveFailures)
+	assert.Equal(t, int64(1), stats.Rejected)
+}
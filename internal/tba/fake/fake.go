@@ -0,0 +1,211 @@
+// Package fake is a fake TBA API server for tests and local development. It
+// serves recorded fixtures, answers conditional requests with 304s like TBA
+// does, and can be made slow or unreliable.
+//
+// Fixtures are stored in a directory, one JSON file per API path, so the
+// response for /event/2018orwil/matches is event/2018orwil/matches.json.
+package fake
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DropConnection can be given to Fail to make requests fail with a closed
+// connection instead of an error response.
+const DropConnection = -1
+
+// Fixture is a recorded response of the TBA API.
+type Fixture struct {
+	Body     []byte
+	Modified time.Time
+}
+
+// Server is a fake TBA API server. It is an http.Handler, and is usually run
+// with httptest.NewServer.
+type Server struct {
+	// Key is the API key requests must have in the X-TBA-Auth-Key header. If it
+	// is empty, any key is accepted.
+	Key string
+
+	mu       sync.Mutex
+	fixtures map[string]Fixture
+	latency  time.Duration
+	failures map[string][]int
+	requests map[string]int
+}
+
+// New creates a fake TBA API server without any fixtures.
+func New() *Server {
+	return &Server{
+		fixtures: make(map[string]Fixture),
+		failures: make(map[string][]int),
+		requests: make(map[string]int),
+	}
+}
+
+// Load creates a fake TBA API server with the fixtures in a directory. The
+// modification times of the files are used as the Last-Modified times of the
+// fixtures.
+func Load(dir string) (*Server, error) {
+	s := New()
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || filepath.Ext(path) != ".json" {
+			return err
+		}
+
+		body, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, strings.TrimSuffix(path, ".json"))
+		if err != nil {
+			return err
+		}
+
+		s.Set("/"+filepath.ToSlash(rel), body, info.ModTime())
+
+		return nil
+	})
+
+	return s, err
+}
+
+// Set sets the fixture served for a path.
+func (s *Server) Set(path string, body []byte, modified time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fixtures[path] = Fixture{Body: body, Modified: modified.UTC().Truncate(time.Second)}
+}
+
+// SetLatency makes every response wait for a duration first.
+func (s *Server) SetLatency(latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = latency
+}
+
+// Fail makes the next requests for a path fail, one for each status code given.
+// DropConnection closes the connection without a response.
+func (s *Server) Fail(path string, statuses ...int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[path] = append(s.failures[path], statuses...)
+}
+
+// Requests returns how many requests have been made for a path.
+func (s *Server) Requests(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[path]
+}
+
+// ServeHTTP serves the fixture for the path of a request.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	path := r.URL.Path
+	s.requests[path]++
+	latency := s.latency
+	fixture, found := s.fixtures[path]
+	status := 0
+	if failures := s.failures[path]; len(failures) > 0 {
+		status, s.failures[path] = failures[0], failures[1:]
+	}
+	s.mu.Unlock()
+
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	switch {
+	case status == DropConnection:
+		if hj, ok := w.(http.Hijacker); ok {
+			if conn, _, err := hj.Hijack(); err == nil {
+				conn.Close()
+				return
+			}
+		}
+		status = http.StatusBadGateway
+		fallthrough
+	case status != 0:
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+
+	if r.Method != "GET" {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if s.Key != "" && r.Header.Get("X-TBA-Auth-Key") != s.Key {
+		http.Error(w, `{"Error": "X-TBA-Auth-Key is invalid. Please get an access key at http://www.thebluealliance.com/account."}`, http.StatusUnauthorized)
+		return
+	}
+
+	if !found {
+		http.Error(w, fmt.Sprintf(`{"Error": "%s not found"}`, path), http.StatusNotFound)
+		return
+	}
+
+	if since, err := http.ParseTime(r.Header.Get("If-Modified-Since")); err == nil && !fixture.Modified.After(since) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.Header().Set("Last-Modified", fixture.Modified.Format(http.TimeFormat))
+	w.Write(fixture.Body)
+}
+
+// Record requests paths from a TBA API and writes the responses as fixtures
+// into a directory, so they can be served by Load.
+func Record(client *http.Client, baseURL, key string, paths []string, dir string) error {
+	for _, path := range paths {
+		req, err := http.NewRequest("GET", baseURL+path, nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("X-TBA-Auth-Key", key)
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return fmt.Errorf("requesting '%s': %v", path, err)
+		}
+
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("reading '%s': %v", path, err)
+		} else if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("requesting '%s' failed with status code: %d", path, resp.StatusCode)
+		}
+
+		file := filepath.Join(dir, filepath.FromSlash(strings.TrimPrefix(path, "/"))+".json")
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(file, body, 0644); err != nil {
+			return err
+		}
+
+		if modified, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+			if err := os.Chtimes(file, modified, modified); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
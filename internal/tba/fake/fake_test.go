@@ -0,0 +1,132 @@
+package fake
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var modified = time.Date(2018, 3, 3, 17, 10, 0, 0, time.UTC)
+
+func get(t *testing.T, url string, header http.Header) (*http.Response, string) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header != nil {
+		req.Header = header
+	}
+
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, ""
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return resp, string(body)
+}
+
+func TestServeHTTP(t *testing.T) {
+	s := New()
+	s.Set("/events/2018", []byte(`[]`), modified)
+
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	resp, body := get(t, ts.URL+"/events/2018", nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "Sat, 03 Mar 2018 17:10:00 GMT", resp.Header.Get("Last-Modified"))
+	assert.Equal(t, "[]", body)
+
+	resp, _ = get(t, ts.URL+"/events/2018", http.Header{"If-Modified-Since": {"Sat, 03 Mar 2018 17:10:00 GMT"}})
+	assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+
+	resp, _ = get(t, ts.URL+"/events/2018", http.Header{"If-Modified-Since": {"Sat, 03 Mar 2018 17:09:59 GMT"}})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, _ = get(t, ts.URL+"/events/2017", nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	assert.Equal(t, 3, s.Requests("/events/2018"))
+	assert.Equal(t, 1, s.Requests("/events/2017"))
+}
+
+func TestKey(t *testing.T) {
+	s := New()
+	s.Key = "key"
+	s.Set("/events/2018", []byte(`[]`), modified)
+
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	resp, _ := get(t, ts.URL+"/events/2018", nil)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp, _ = get(t, ts.URL+"/events/2018", http.Header{"X-TBA-Auth-Key": {"key"}})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestFail(t *testing.T) {
+	s := New()
+	s.Set("/events/2018", []byte(`[]`), modified)
+	s.Fail("/events/2018", http.StatusInternalServerError, DropConnection)
+
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	resp, _ := get(t, ts.URL+"/events/2018", nil)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+
+	resp, _ = get(t, ts.URL+"/events/2018", nil)
+	assert.Nil(t, resp)
+
+	resp, _ = get(t, ts.URL+"/events/2018", nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestLoadAndRecord(t *testing.T) {
+	upstream := New()
+	upstream.Set("/events/2018", []byte(`[{"key":"2018orwil"}]`), modified)
+	upstream.Set("/event/2018orwil/matches", []byte(`[]`), modified)
+
+	ts := httptest.NewServer(upstream)
+	defer ts.Close()
+
+	dir, err := ioutil.TempDir("", "fake")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = Record(http.DefaultClient, ts.URL, "key", []string{"/events/2018", "/event/2018orwil/matches"}, dir)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	body, err := ioutil.ReadFile(filepath.Join(dir, "event", "2018orwil", "matches.json"))
+	assert.Nil(t, err)
+	assert.Equal(t, "[]", string(body))
+
+	s, err := Load(dir)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, Fixture{Body: []byte(`[{"key":"2018orwil"}]`), Modified: modified}, s.fixtures["/events/2018"])
+	assert.Equal(t, Fixture{Body: []byte(`[]`), Modified: modified}, s.fixtures["/event/2018orwil/matches"])
+
+	err = Record(http.DefaultClient, ts.URL, "key", []string{"/events/2017"}, dir)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,379 @@
+// Package frc consumes the official FRC Events API as an alternative to the TBA
+// API. Event keys are made the same way TBA makes them, from the season and the
+// lowercased event code, so data from both APIs can be mixed.
+package frc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Pigmice2733/scouting-backend/internal/schedule"
+	"github.com/Pigmice2733/scouting-backend/internal/store/event"
+	"github.com/Pigmice2733/scouting-backend/internal/store/match"
+	"github.com/Pigmice2733/scouting-backend/internal/tba"
+	"github.com/Pigmice2733/scouting-backend/internal/tba/api/lastmodified"
+	"github.com/Pigmice2733/scouting-backend/internal/tba/client"
+)
+
+// URL is the URL of version 2.0 of the FRC Events API.
+const URL = "https://frc-api.firstinspires.org/v2.0"
+
+// timeLayout is the layout of times in the FRC Events API, which are in the
+// local time of the event.
+const timeLayout = "2006-01-02T15:04:05"
+
+// eventTypes maps FRC Events API event types to TBA event types.
+var eventTypes = map[string]int{
+	"Regional":                       0,
+	"DistrictEvent":                  1,
+	"DistrictChampionship":           2,
+	"DistrictChampionshipWithLevels": 2,
+	"ChampionshipSubdivision":        3,
+	"Championship":                   4,
+	"DistrictChampionshipDivision":   5,
+	"OffSeason":                      99,
+	"OffSeasonWithAzureSync":         99,
+	"Preseason":                      100,
+}
+
+// timezones maps the Windows time zone names used by the FRC Events API to IANA
+// time zone names.
+var timezones = map[string]string{
+	"Eastern Standard Time":          "America/New_York",
+	"Central Standard Time":          "America/Chicago",
+	"Mountain Standard Time":         "America/Denver",
+	"US Mountain Standard Time":      "America/Phoenix",
+	"Pacific Standard Time":          "America/Los_Angeles",
+	"Alaskan Standard Time":          "America/Anchorage",
+	"Hawaiian Standard Time":         "Pacific/Honolulu",
+	"Atlantic Standard Time":         "America/Halifax",
+	"Canada Central Standard Time":   "America/Regina",
+	"Central Standard Time (Mexico)": "America/Mexico_City",
+	"E. Australia Standard Time":     "Australia/Brisbane",
+	"AUS Eastern Standard Time":      "Australia/Sydney",
+	"China Standard Time":            "Asia/Shanghai",
+	"Israel Standard Time":           "Asia/Jerusalem",
+	"Turkey Standard Time":           "Europe/Istanbul",
+	"GMT Standard Time":              "Europe/London",
+	"UTC":                            "UTC",
+}
+
+// Consumer consumes info from the FRC Events API.
+type Consumer struct {
+	url          string
+	username     string
+	token        string
+	client       *client.Client
+	lastModified lastmodified.Manager
+
+	mu        sync.Mutex
+	locations map[string]*time.Location
+}
+
+// New returns a new FRC Events API consumer, which authenticates with a
+// username and authorization token and makes requests with a client.
+func New(url, username, token string, c *client.Client) *Consumer {
+	return &Consumer{
+		url:          url,
+		username:     username,
+		token:        token,
+		client:       c,
+		lastModified: lastmodified.New(),
+		locations:    make(map[string]*time.Location),
+	}
+}
+
+// get requests a path of the API and decodes the response into v. Conditional
+// requests return tba.ErrNotModified if the path hasn't been modified since it
+// was last requested.
+func (c *Consumer) get(ctx context.Context, path string, conditional bool, v interface{}) error {
+	req, err := http.NewRequest("GET", c.url+path, nil)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+
+	req.SetBasicAuth(c.username, c.token)
+	req.Header.Set("Accept", "application/json")
+	if lastModified := c.lastModified.Get(path); conditional && lastModified != "" {
+		req.Header.Set("If-Modified-Since", lastModified)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return tba.ErrNotModified
+	} else if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("frc: polling failed with status code: %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1.049e+6)).Decode(v); err != nil {
+		return err
+	}
+
+	if conditional {
+		c.lastModified.Set(path, resp.Header.Get("Last-Modified"))
+	}
+
+	return nil
+}
+
+// splitKey splits an event key into its season and FRC Events API event code.
+func splitKey(eventKey string) (int, string, error) {
+	if len(eventKey) <= 4 {
+		return 0, "", fmt.Errorf("frc: invalid event key '%s'", eventKey)
+	}
+
+	season, err := strconv.Atoi(eventKey[:4])
+	if err != nil {
+		return 0, "", fmt.Errorf("frc: invalid event key '%s'", eventKey)
+	}
+
+	return season, strings.ToUpper(eventKey[4:]), nil
+}
+
+func location(timezone string) *time.Location {
+	if name, ok := timezones[timezone]; ok {
+		if loc, err := time.LoadLocation(name); err == nil {
+			return loc
+		}
+	}
+	return time.UTC
+}
+
+// eventLocation gets the time zone of an event, which match times are in.
+// Time zones are only requested once per event.
+func (c *Consumer) eventLocation(ctx context.Context, season int, code string) (*time.Location, error) {
+	key := fmt.Sprintf("%d%s", season, code)
+
+	c.mu.Lock()
+	loc, ok := c.locations[key]
+	c.mu.Unlock()
+	if ok {
+		return loc, nil
+	}
+
+	var resp frcEvents
+	if err := c.get(ctx, fmt.Sprintf("/%d/events?eventCode=%s", season, code), false, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Events) == 0 {
+		return nil, fmt.Errorf("frc: no event '%s' in %d", code, season)
+	}
+
+	loc = location(resp.Events[0].Timezone)
+
+	c.mu.Lock()
+	c.locations[key] = loc
+	c.mu.Unlock()
+
+	return loc, nil
+}
+
+// GetEvents retrieves all events in a season from the FRC Events API.
+func (c *Consumer) GetEvents(ctx context.Context, year int) ([]event.BasicEvent, error) {
+	var resp frcEvents
+	if err := c.get(ctx, fmt.Sprintf("/%d/events", year), true, &resp); err != nil {
+		return []event.BasicEvent{}, err
+	}
+
+	var bEvents []event.BasicEvent
+	for _, e := range resp.Events {
+		loc := location(e.Timezone)
+
+		date, err := time.ParseInLocation(timeLayout, e.DateStart, loc)
+		if err != nil {
+			return bEvents, err
+		}
+		endDate, err := time.ParseInLocation(timeLayout, e.DateEnd, loc)
+		if err != nil {
+			return bEvents, err
+		}
+
+		eventType, ok := eventTypes[e.Type]
+		if !ok {
+			eventType = -1
+		}
+
+		bEvents = append(bEvents, event.BasicEvent{
+			Key:       fmt.Sprintf("%d%s", year, strings.ToLower(e.Code)),
+			Name:      e.Name,
+			ShortName: e.Name,
+			EventType: eventType,
+			Date:      date,
+			EndDate:   endDate,
+		})
+	}
+
+	return bEvents, nil
+}
+
+// GetMatches retrieves the qualification and playoff matches of an event, with
+// their results, from the FRC Events API.
+func (c *Consumer) GetMatches(ctx context.Context, eventKey string) ([]match.Match, error) {
+	season, code, err := splitKey(eventKey)
+	if err != nil {
+		return []match.Match{}, err
+	}
+
+	loc, err := c.eventLocation(ctx, season, code)
+	if err != nil {
+		return []match.Match{}, err
+	}
+
+	var matches []match.Match
+	modified := false
+
+	for _, level := range []string{"qual", "playoff"} {
+		var resp frcSchedule
+		err := c.get(ctx, fmt.Sprintf("/%d/schedule/%s/%s/hybrid", season, code, level), true, &resp)
+		if err == tba.ErrNotModified {
+			continue
+		} else if err != nil {
+			// levels that were already retrieved have to be retrieved again
+			// next time, since their matches aren't returned
+			c.ForgetMatches(eventKey)
+			return []match.Match{}, err
+		}
+		modified = true
+
+		for _, m := range resp.Schedule {
+			mm, ok := convertMatch(eventKey, m, loc)
+			if ok {
+				matches = append(matches, mm)
+			}
+		}
+	}
+
+	if !modified {
+		return []match.Match{}, tba.ErrNotModified
+	}
+
+	return matches, nil
+}
+
+// convertMatch converts an FRC Events API match into a match. It returns false
+// for matches whose key can't be made from their description.
+func convertMatch(eventKey string, m frcMatch, loc *time.Location) (match.Match, bool) {
+	suffix, ok, err := schedule.FMSMatchKey(m.Description)
+	if err != nil || !ok {
+		return match.Match{}, false
+	}
+
+	mm := match.Match{
+		BasicMatch: match.BasicMatch{
+			Key:           eventKey + "_" + suffix,
+			EventKey:      eventKey,
+			PredictedTime: parseTime(m.StartTime, loc),
+			ActualTime:    parseTime(m.ActualStartTime, loc),
+		},
+		RedScore:  -1,
+		BlueScore: -1,
+	}
+
+	if m.ScoreRedFinal != nil {
+		mm.RedScore = *m.ScoreRedFinal
+	}
+	if m.ScoreBlueFinal != nil {
+		mm.BlueScore = *m.ScoreBlueFinal
+	}
+
+	for _, t := range m.Teams {
+		if t.TeamNumber == 0 {
+			continue
+		}
+
+		team := "frc" + strconv.Itoa(t.TeamNumber)
+		if strings.HasPrefix(t.Station, "Red") {
+			mm.RedAlliance = append(mm.RedAlliance, team)
+		} else if strings.HasPrefix(t.Station, "Blue") {
+			mm.BlueAlliance = append(mm.BlueAlliance, team)
+		}
+	}
+
+	return mm, true
+}
+
+func parseTime(value *string, loc *time.Location) *time.Time {
+	if value == nil || *value == "" {
+		return nil
+	}
+
+	t, err := time.ParseInLocation(timeLayout, *value, loc)
+	if err != nil {
+		return nil
+	}
+
+	return &t
+}
+
+// Upstreams gets the stats of the requests made to the FRC Events API.
+func (c *Consumer) Upstreams() map[string]client.Stats {
+	return map[string]client.Stats{"frc": c.client.Stats()}
+}
+
+// GetPhotoURL always returns an empty URL, since the FRC Events API only has
+// team avatars, not photos.
+func (c *Consumer) GetPhotoURL(ctx context.Context, team string, year int) (string, error) {
+	return "", nil
+}
+
+// GetRankings retrieves the qualification rankings of an event from the FRC
+// Events API.
+func (c *Consumer) GetRankings(ctx context.Context, eventKey string) ([]tba.Ranking, error) {
+	season, code, err := splitKey(eventKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// rankings aren't stored, so they are requested unconditionally
+	var resp frcRankings
+	if err := c.get(ctx, fmt.Sprintf("/%d/rankings/%s", season, code), false, &resp); err != nil {
+		return nil, err
+	}
+
+	rankings := make([]tba.Ranking, 0, len(resp.Rankings))
+	for _, r := range resp.Rankings {
+		rankings = append(rankings, tba.Ranking{
+			Rank:          r.Rank,
+			Team:          "frc" + strconv.Itoa(r.TeamNumber),
+			RankingScore:  r.SortOrder1,
+			Wins:          r.Wins,
+			Losses:        r.Losses,
+			Ties:          r.Ties,
+			MatchesPlayed: r.MatchesPlayed,
+		})
+	}
+
+	return rankings, nil
+}
+
+// ForgetEvents makes the next GetEvents for a year retrieve the events even if
+// they haven't been modified.
+func (c *Consumer) ForgetEvents(year int) {
+	c.lastModified.Delete(fmt.Sprintf("/%d/events", year))
+}
+
+// ForgetMatches makes the next GetMatches for an event retrieve the matches even
+// if they haven't been modified.
+func (c *Consumer) ForgetMatches(eventKey string) {
+	season, code, err := splitKey(eventKey)
+	if err != nil {
+		return
+	}
+
+	for _, level := range []string{"qual", "playoff"} {
+		c.lastModified.Delete(fmt.Sprintf("/%d/schedule/%s/%s/hybrid", season, code, level))
+	}
+}
@@ -0,0 +1,185 @@
+package frc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Pigmice2733/scouting-backend/internal/tba"
+	"github.com/Pigmice2733/scouting-backend/internal/tba/client"
+	"github.com/stretchr/testify/assert"
+)
+
+const lastModified = "Sat, 03 Mar 2018 17:10:00 GMT"
+
+// fixtures maps request URIs to the recorded responses in testdata.
+var fixtures = map[string]string{
+	"/2018/events":                        "events.json",
+	"/2018/events?eventCode=ORWIL":        "event_orwil.json",
+	"/2018/schedule/ORWIL/qual/hybrid":    "schedule_qual.json",
+	"/2018/schedule/ORWIL/playoff/hybrid": "schedule_playoff.json",
+	"/2018/rankings/ORWIL":                "rankings.json",
+}
+
+func fixtureServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, token, ok := r.BasicAuth(); !ok || username != "user" || token != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		fixture, ok := fixtures[r.URL.RequestURI()]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if r.Header.Get("If-Modified-Since") == lastModified {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("Last-Modified", lastModified)
+		http.ServeFile(w, r, filepath.Join("testdata", fixture))
+	}))
+}
+
+func TestGetEvents(t *testing.T) {
+	ts := fixtureServer(t)
+	defer ts.Close()
+
+	c := New(ts.URL, "user", "token", client.New(client.Options{}))
+
+	events, err := c.GetEvents(context.Background(), 2018)
+	if !assert.Nil(t, err) || !assert.Len(t, events, 2) {
+		return
+	}
+
+	pacific, _ := time.LoadLocation("America/Los_Angeles")
+
+	assert.Equal(t, "2018orwil", events[0].Key)
+	assert.Equal(t, "PNW District Wilsonville Event", events[0].Name)
+	assert.Equal(t, 1, events[0].EventType)
+	assert.True(t, time.Date(2018, 3, 2, 0, 0, 0, 0, pacific).Equal(events[0].Date))
+	assert.True(t, time.Date(2018, 3, 4, 23, 59, 59, 0, pacific).Equal(events[0].EndDate))
+	assert.Nil(t, events[0].Lat)
+
+	assert.Equal(t, "2018girlsgen", events[1].Key)
+	assert.Equal(t, 99, events[1].EventType)
+	assert.True(t, time.Date(2018, 10, 13, 0, 0, 0, 0, time.UTC).Equal(events[1].Date))
+
+	_, err = c.GetEvents(context.Background(), 2018)
+	assert.Equal(t, tba.ErrNotModified, err)
+
+	c.ForgetEvents(2018)
+	_, err = c.GetEvents(context.Background(), 2018)
+	assert.Nil(t, err)
+}
+
+func TestGetMatches(t *testing.T) {
+	ts := fixtureServer(t)
+	defer ts.Close()
+
+	c := New(ts.URL, "user", "token", client.New(client.Options{}))
+
+	matches, err := c.GetMatches(context.Background(), "2018orwil")
+	if !assert.Nil(t, err) || !assert.Len(t, matches, 3) {
+		return
+	}
+
+	pacific, _ := time.LoadLocation("America/Los_Angeles")
+
+	qm1 := matches[0]
+	assert.Equal(t, "2018orwil_qm1", qm1.Key)
+	assert.Equal(t, "2018orwil", qm1.EventKey)
+	assert.Equal(t, 120, qm1.RedScore)
+	assert.Equal(t, 98, qm1.BlueScore)
+	assert.Equal(t, []string{"frc2733", "frc1540", "frc4488"}, qm1.RedAlliance)
+	assert.Equal(t, []string{"frc1425", "frc360", "frc1318"}, qm1.BlueAlliance)
+	if assert.NotNil(t, qm1.PredictedTime) && assert.NotNil(t, qm1.ActualTime) {
+		assert.True(t, time.Date(2018, 3, 3, 9, 0, 0, 0, pacific).Equal(*qm1.PredictedTime))
+		assert.True(t, time.Date(2018, 3, 3, 9, 1, 25, 107000000, pacific).Equal(*qm1.ActualTime))
+	}
+
+	qm2 := matches[1]
+	assert.Equal(t, "2018orwil_qm2", qm2.Key)
+	assert.Equal(t, -1, qm2.RedScore)
+	assert.Equal(t, -1, qm2.BlueScore)
+	assert.Nil(t, qm2.ActualTime)
+
+	qf := matches[2]
+	assert.Equal(t, "2018orwil_qf1m1", qf.Key)
+	assert.Equal(t, []string{"frc2733", "frc1540", "frc4488"}, qf.RedAlliance)
+	assert.Empty(t, qf.BlueAlliance)
+
+	_, err = c.GetMatches(context.Background(), "2018orwil")
+	assert.Equal(t, tba.ErrNotModified, err)
+
+	c.ForgetMatches("2018orwil")
+	matches, err = c.GetMatches(context.Background(), "2018orwil")
+	assert.Nil(t, err)
+	assert.Len(t, matches, 3)
+}
+
+func TestGetMatchesErrors(t *testing.T) {
+	ts := fixtureServer(t)
+	defer ts.Close()
+
+	_, err := New(ts.URL, "user", "token", client.New(client.Options{})).GetMatches(context.Background(), "2018orore")
+	assert.NotNil(t, err)
+
+	_, err = New(ts.URL, "user", "token", client.New(client.Options{})).GetMatches(context.Background(), "orwil")
+	assert.NotNil(t, err)
+
+	_, err = New(ts.URL, "user", "wrong", client.New(client.Options{})).GetMatches(context.Background(), "2018orwil")
+	assert.NotNil(t, err)
+}
+
+func TestCancel(t *testing.T) {
+	ts := fixtureServer(t)
+	defer ts.Close()
+
+	c := New(ts.URL, "user", "token", client.New(client.Options{}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := c.GetEvents(ctx, 2018)
+	assert.Equal(t, context.Canceled, err)
+	_, err = c.GetMatches(ctx, "2018orwil")
+	assert.Equal(t, context.Canceled, err)
+	_, err = c.GetRankings(ctx, "2018orwil")
+	assert.Equal(t, context.Canceled, err)
+
+	matches, err := c.GetMatches(context.Background(), "2018orwil")
+	assert.Nil(t, err)
+	assert.Len(t, matches, 3)
+}
+
+func TestGetRankings(t *testing.T) {
+	ts := fixtureServer(t)
+	defer ts.Close()
+
+	c := New(ts.URL, "user", "token", client.New(client.Options{}))
+
+	expected := []tba.Ranking{
+		{Rank: 1, Team: "frc2733", RankingScore: 2.45, Wins: 10, Losses: 1, Ties: 1, MatchesPlayed: 12},
+		{Rank: 2, Team: "frc1540", RankingScore: 2.3, Wins: 9, Losses: 3, MatchesPlayed: 12},
+	}
+
+	// rankings are never reported as not modified
+	for i := 0; i < 2; i++ {
+		rankings, err := c.GetRankings(context.Background(), "2018orwil")
+		assert.Nil(t, err)
+		assert.Equal(t, expected, rankings)
+	}
+}
+
+func TestGetPhotoURL(t *testing.T) {
+	url, err := New("http://localhost", "user", "token", client.New(client.Options{})).GetPhotoURL(context.Background(), "frc2733", 2018)
+	assert.Nil(t, err)
+	assert.Equal(t, "", url)
+}
@@ -0,0 +1,44 @@
+package frc
+
+type frcEvents struct {
+	Events []frcEvent `json:"Events"`
+}
+
+type frcEvent struct {
+	Code      string `json:"code"`
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	DateStart string `json:"dateStart"`
+	DateEnd   string `json:"dateEnd"`
+	Timezone  string `json:"timezone"`
+}
+
+type frcSchedule struct {
+	Schedule []frcMatch `json:"Schedule"`
+}
+
+type frcMatch struct {
+	Description     string  `json:"description"`
+	TournamentLevel string  `json:"tournamentLevel"`
+	MatchNumber     int     `json:"matchNumber"`
+	StartTime       *string `json:"startTime"`
+	ActualStartTime *string `json:"actualStartTime"`
+	ScoreRedFinal   *int    `json:"scoreRedFinal"`
+	ScoreBlueFinal  *int    `json:"scoreBlueFinal"`
+	Teams           []struct {
+		TeamNumber int    `json:"teamNumber"`
+		Station    string `json:"station"`
+	} `json:"teams"`
+}
+
+type frcRankings struct {
+	Rankings []struct {
+		Rank          int     `json:"rank"`
+		TeamNumber    int     `json:"teamNumber"`
+		SortOrder1    float64 `json:"sortOrder1"`
+		Wins          int     `json:"wins"`
+		Losses        int     `json:"losses"`
+		Ties          int     `json:"ties"`
+		MatchesPlayed int     `json:"matchesPlayed"`
+	} `json:"Rankings"`
+}
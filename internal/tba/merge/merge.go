@@ -0,0 +1,263 @@
+// Package merge combines two consumers into one, filling in data the primary
+// consumer is missing with data from the secondary consumer.
+package merge
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Pigmice2733/scouting-backend/internal/store/event"
+	"github.com/Pigmice2733/scouting-backend/internal/store/match"
+	"github.com/Pigmice2733/scouting-backend/internal/tba"
+	"github.com/Pigmice2733/scouting-backend/internal/tba/client"
+)
+
+// Consumer merges the data of a primary and a secondary consumer. Events and
+// matches from the primary consumer are used, with any fields they are missing
+// filled in from the secondary consumer, and events and matches only the
+// secondary consumer has are added.
+//
+// The last data retrieved from each consumer is remembered, so that when only
+// one of them has new data the merged data is still complete.
+type Consumer struct {
+	primary, secondary tba.Consumer
+
+	mu      sync.Mutex
+	events  map[int][2][]event.BasicEvent
+	matches map[string][2][]match.Match
+}
+
+// New creates a consumer that merges the data of two consumers.
+func New(primary, secondary tba.Consumer) *Consumer {
+	return &Consumer{
+		primary:   primary,
+		secondary: secondary,
+		events:    make(map[int][2][]event.BasicEvent),
+		matches:   make(map[string][2][]match.Match),
+	}
+}
+
+// GetEvents gets the merged events of a year. It returns tba.ErrNotModified if
+// neither consumer has new events, and an error only if both consumers fail.
+func (c *Consumer) GetEvents(ctx context.Context, year int) ([]event.BasicEvent, error) {
+	pEvents, pErr := c.primary.GetEvents(ctx, year)
+	sEvents, sErr := c.secondary.GetEvents(ctx, year)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	last := c.events[year]
+	modified := false
+	if pErr == nil {
+		last[0], modified = pEvents, true
+	}
+	if sErr == nil {
+		last[1], modified = sEvents, true
+	}
+	c.events[year] = last
+
+	if !modified {
+		return []event.BasicEvent{}, bothErr(pErr, sErr)
+	}
+
+	return Events(last[0], last[1]), nil
+}
+
+// GetMatches gets the merged matches of an event. It returns tba.ErrNotModified
+// if neither consumer has new matches, and an error only if both consumers
+// fail.
+func (c *Consumer) GetMatches(ctx context.Context, eventKey string) ([]match.Match, error) {
+	pMatches, pErr := c.primary.GetMatches(ctx, eventKey)
+	sMatches, sErr := c.secondary.GetMatches(ctx, eventKey)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	last := c.matches[eventKey]
+	modified := false
+	if pErr == nil {
+		last[0], modified = pMatches, true
+	}
+	if sErr == nil {
+		last[1], modified = sMatches, true
+	}
+	c.matches[eventKey] = last
+
+	if !modified {
+		return []match.Match{}, bothErr(pErr, sErr)
+	}
+
+	return Matches(last[0], last[1]), nil
+}
+
+// GetPhotoURL gets the photo URL of a team from the primary consumer, or from
+// the secondary consumer if the primary one has no photo.
+func (c *Consumer) GetPhotoURL(ctx context.Context, team string, year int) (string, error) {
+	url, err := c.primary.GetPhotoURL(ctx, team, year)
+	if url != "" || err == tba.ErrNotModified {
+		return url, err
+	}
+
+	sURL, sErr := c.secondary.GetPhotoURL(ctx, team, year)
+	if sErr != nil && err != nil {
+		return "", err
+	} else if sErr != nil {
+		return url, nil
+	}
+
+	return sURL, nil
+}
+
+// GetRankings gets the rankings of an event from the first consumer that can
+// get rankings.
+func (c *Consumer) GetRankings(ctx context.Context, eventKey string) ([]tba.Ranking, error) {
+	var err error
+	for _, consumer := range []tba.Consumer{c.primary, c.secondary} {
+		if r, ok := consumer.(tba.Ranker); ok {
+			var rankings []tba.Ranking
+			if rankings, err = r.GetRankings(ctx, eventKey); err == nil {
+				return rankings, nil
+			}
+		}
+	}
+
+	if err == nil {
+		err = tba.ErrNoRankings
+	}
+
+	return nil, err
+}
+
+// ForgetEvents forgets the events of a year in both consumers.
+func (c *Consumer) ForgetEvents(year int) {
+	for _, consumer := range []tba.Consumer{c.primary, c.secondary} {
+		if f, ok := consumer.(tba.Forgetter); ok {
+			f.ForgetEvents(year)
+		}
+	}
+}
+
+// ForgetMatches forgets the matches of an event in both consumers.
+func (c *Consumer) ForgetMatches(eventKey string) {
+	for _, consumer := range []tba.Consumer{c.primary, c.secondary} {
+		if f, ok := consumer.(tba.Forgetter); ok {
+			f.ForgetMatches(eventKey)
+		}
+	}
+}
+
+// Upstreams gets the stats of the upstream APIs of both consumers.
+func (c *Consumer) Upstreams() map[string]client.Stats {
+	upstreams := make(map[string]client.Stats)
+	for _, consumer := range []tba.Consumer{c.primary, c.secondary} {
+		if m, ok := consumer.(tba.Monitor); ok {
+			for name, stats := range m.Upstreams() {
+				upstreams[name] = stats
+			}
+		}
+	}
+	return upstreams
+}
+
+func failed(err error) bool {
+	return err != nil && err != tba.ErrNotModified
+}
+
+// bothErr gets the error to return when neither consumer has new data.
+func bothErr(pErr, sErr error) error {
+	if failed(pErr) && failed(sErr) {
+		return pErr
+	}
+	return tba.ErrNotModified
+}
+
+// Events merges two lists of events. Events from the primary list come first,
+// followed by events only in the secondary list.
+func Events(primary, secondary []event.BasicEvent) []event.BasicEvent {
+	byKey := make(map[string]event.BasicEvent)
+	for _, e := range secondary {
+		byKey[e.Key] = e
+	}
+
+	merged := make([]event.BasicEvent, 0, len(primary)+len(secondary))
+	seen := make(map[string]bool)
+
+	for _, e := range primary {
+		if s, ok := byKey[e.Key]; ok {
+			if e.Name == "" {
+				e.Name = s.Name
+			}
+			if e.ShortName == "" {
+				e.ShortName = s.ShortName
+			}
+			if e.Lat == nil {
+				e.Lat = s.Lat
+			}
+			if e.Long == nil {
+				e.Long = s.Long
+			}
+			if e.Date.IsZero() {
+				e.Date = s.Date
+			}
+			if e.EndDate.IsZero() {
+				e.EndDate = s.EndDate
+			}
+		}
+		merged = append(merged, e)
+		seen[e.Key] = true
+	}
+
+	for _, e := range secondary {
+		if !seen[e.Key] {
+			merged = append(merged, e)
+		}
+	}
+
+	return merged
+}
+
+// Matches merges two lists of matches. Matches from the primary list come
+// first, followed by matches only in the secondary list. Unplayed matches have
+// scores of -1 and are filled in with the scores of the secondary list.
+func Matches(primary, secondary []match.Match) []match.Match {
+	byKey := make(map[string]match.Match)
+	for _, m := range secondary {
+		byKey[m.Key] = m
+	}
+
+	merged := make([]match.Match, 0, len(primary)+len(secondary))
+	seen := make(map[string]bool)
+
+	for _, m := range primary {
+		if s, ok := byKey[m.Key]; ok {
+			if m.PredictedTime == nil {
+				m.PredictedTime = s.PredictedTime
+			}
+			if m.ActualTime == nil {
+				m.ActualTime = s.ActualTime
+			}
+			if len(m.RedAlliance) == 0 {
+				m.RedAlliance = s.RedAlliance
+			}
+			if len(m.BlueAlliance) == 0 {
+				m.BlueAlliance = s.BlueAlliance
+			}
+			if m.RedScore < 0 && m.BlueScore < 0 {
+				m.RedScore, m.BlueScore = s.RedScore, s.BlueScore
+			}
+			if m.YoutubeURL == "" {
+				m.YoutubeURL = s.YoutubeURL
+			}
+		}
+		merged = append(merged, m)
+		seen[m.Key] = true
+	}
+
+	for _, m := range secondary {
+		if !seen[m.Key] {
+			merged = append(merged, m)
+		}
+	}
+
+	return merged
+}
@@ -0,0 +1,177 @@
+package merge
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Pigmice2733/scouting-backend/internal/store/event"
+	"github.com/Pigmice2733/scouting-backend/internal/store/match"
+	"github.com/Pigmice2733/scouting-backend/internal/tba"
+	"github.com/stretchr/testify/assert"
+)
+
+// consumer is a tba.Consumer that returns fixed data and errors, or the error
+// of a done context.
+type consumer struct {
+	events    []event.BasicEvent
+	eventsErr error
+	matches   []match.Match
+	matchErr  error
+	photo     string
+	photoErr  error
+	rankings  []tba.Ranking
+}
+
+func (c *consumer) GetEvents(ctx context.Context, year int) ([]event.BasicEvent, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.events, c.eventsErr
+}
+
+func (c *consumer) GetMatches(ctx context.Context, eventKey string) ([]match.Match, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.matches, c.matchErr
+}
+
+func (c *consumer) GetPhotoURL(ctx context.Context, team string, year int) (string, error) {
+	return c.photo, c.photoErr
+}
+
+type ranker struct {
+	consumer
+}
+
+func (r *ranker) GetRankings(ctx context.Context, eventKey string) ([]tba.Ranking, error) {
+	return r.rankings, nil
+}
+
+func TestEvents(t *testing.T) {
+	lat := 45.3
+	date := time.Date(2018, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	merged := Events(
+		[]event.BasicEvent{{Key: "2018orwil", Name: "Wilsonville", Lat: &lat}},
+		[]event.BasicEvent{
+			{Key: "2018girlsgen", Name: "Girls Generation", Date: date},
+			{Key: "2018orwil", Name: "PNW District Wilsonville Event", ShortName: "Wilsonville", Date: date, EndDate: date},
+		},
+	)
+
+	assert.Equal(t, []event.BasicEvent{
+		{Key: "2018orwil", Name: "Wilsonville", ShortName: "Wilsonville", Lat: &lat, Date: date, EndDate: date},
+		{Key: "2018girlsgen", Name: "Girls Generation", Date: date},
+	}, merged)
+}
+
+func TestMatches(t *testing.T) {
+	predicted := time.Date(2018, 3, 3, 9, 0, 0, 0, time.UTC)
+
+	merged := Matches(
+		[]match.Match{
+			{BasicMatch: match.BasicMatch{Key: "qm1"}, RedScore: -1, BlueScore: -1, RedAlliance: []string{"frc1"}},
+			{BasicMatch: match.BasicMatch{Key: "qm2"}, RedScore: 10, BlueScore: 20, YoutubeURL: "yt"},
+		},
+		[]match.Match{
+			{BasicMatch: match.BasicMatch{Key: "qm1", PredictedTime: &predicted}, RedScore: 5, BlueScore: 6, RedAlliance: []string{"frc2"}, BlueAlliance: []string{"frc3"}},
+			{BasicMatch: match.BasicMatch{Key: "qm2"}, RedScore: 11, BlueScore: 21},
+			{BasicMatch: match.BasicMatch{Key: "qm3"}, RedScore: -1, BlueScore: -1},
+		},
+	)
+
+	assert.Equal(t, []match.Match{
+		{BasicMatch: match.BasicMatch{Key: "qm1", PredictedTime: &predicted}, RedScore: 5, BlueScore: 6, RedAlliance: []string{"frc1"}, BlueAlliance: []string{"frc3"}},
+		{BasicMatch: match.BasicMatch{Key: "qm2"}, RedScore: 10, BlueScore: 20, YoutubeURL: "yt"},
+		{BasicMatch: match.BasicMatch{Key: "qm3"}, RedScore: -1, BlueScore: -1},
+	}, merged)
+}
+
+func TestGetEvents(t *testing.T) {
+	primary := &consumer{events: []event.BasicEvent{{Key: "2018orwil"}}}
+	secondary := &consumer{events: []event.BasicEvent{{Key: "2018girlsgen"}}}
+	c := New(primary, secondary)
+
+	events, err := c.GetEvents(context.Background(), 2018)
+	assert.Nil(t, err)
+	assert.Equal(t, []event.BasicEvent{{Key: "2018orwil"}, {Key: "2018girlsgen"}}, events)
+
+	// the last events of the primary consumer are kept when it isn't modified
+	primary.events, primary.eventsErr = nil, tba.ErrNotModified
+	secondary.events = []event.BasicEvent{{Key: "2018orore"}}
+	events, err = c.GetEvents(context.Background(), 2018)
+	assert.Nil(t, err)
+	assert.Equal(t, []event.BasicEvent{{Key: "2018orwil"}, {Key: "2018orore"}}, events)
+
+	// or when it fails
+	primary.eventsErr = fmt.Errorf("tba is down")
+	events, err = c.GetEvents(context.Background(), 2018)
+	assert.Nil(t, err)
+	assert.Equal(t, []event.BasicEvent{{Key: "2018orwil"}, {Key: "2018orore"}}, events)
+
+	secondary.eventsErr = tba.ErrNotModified
+	_, err = c.GetEvents(context.Background(), 2018)
+	assert.Equal(t, tba.ErrNotModified, err)
+
+	secondary.eventsErr = fmt.Errorf("frc is down")
+	_, err = c.GetEvents(context.Background(), 2018)
+	assert.Equal(t, primary.eventsErr, err)
+}
+
+func TestGetMatches(t *testing.T) {
+	primary := &consumer{matchErr: fmt.Errorf("not found")}
+	secondary := &consumer{matches: []match.Match{{BasicMatch: match.BasicMatch{Key: "qm1"}}}}
+	c := New(primary, secondary)
+
+	matches, err := c.GetMatches(context.Background(), "2018girlsgen")
+	assert.Nil(t, err)
+	assert.Equal(t, secondary.matches, matches)
+
+	secondary.matchErr = tba.ErrNotModified
+	_, err = c.GetMatches(context.Background(), "2018girlsgen")
+	assert.Equal(t, tba.ErrNotModified, err)
+}
+
+func TestCancel(t *testing.T) {
+	c := New(&consumer{events: []event.BasicEvent{{Key: "2018orwil"}}}, &consumer{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := c.GetEvents(ctx, 2018)
+	assert.Equal(t, context.Canceled, err)
+	_, err = c.GetMatches(ctx, "2018orwil")
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestGetPhotoURL(t *testing.T) {
+	primary := &consumer{photo: "tba"}
+	secondary := &consumer{photo: "frc"}
+	c := New(primary, secondary)
+
+	url, err := c.GetPhotoURL(context.Background(), "frc2733", 2018)
+	assert.Nil(t, err)
+	assert.Equal(t, "tba", url)
+
+	primary.photo = ""
+	url, err = c.GetPhotoURL(context.Background(), "frc2733", 2018)
+	assert.Nil(t, err)
+	assert.Equal(t, "frc", url)
+
+	primary.photoErr = tba.ErrNotModified
+	_, err = c.GetPhotoURL(context.Background(), "frc2733", 2018)
+	assert.Equal(t, tba.ErrNotModified, err)
+}
+
+func TestGetRankings(t *testing.T) {
+	_, err := New(&consumer{}, &consumer{}).GetRankings(context.Background(), "2018orwil")
+	assert.Equal(t, tba.ErrNoRankings, err)
+
+	rankings := []tba.Ranking{{Rank: 1, Team: "frc2733"}}
+	r, err := New(&consumer{}, &ranker{consumer{rankings: rankings}}).GetRankings(context.Background(), "2018orwil")
+	assert.Nil(t, err)
+	assert.Equal(t, rankings, r)
+}
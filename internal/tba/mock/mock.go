@@ -1,6 +1,7 @@
 package mock
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/Pigmice2733/scouting-backend/internal/store/event"
@@ -24,7 +25,7 @@ type DB struct {
 }
 
 // GetEvents gets all events in the mock db for TBA by year.
-func (db DB) GetEvents(year int) ([]event.BasicEvent, error) {
+func (db DB) GetEvents(ctx context.Context, year int) ([]event.BasicEvent, error) {
 	if events, ok := db.Events[year]; ok {
 		return events, nil
 	}
@@ -32,7 +33,7 @@ func (db DB) GetEvents(year int) ([]event.BasicEvent, error) {
 }
 
 // GetMatches gets all matches in the mock db for TBA by eventKey.
-func (db DB) GetMatches(eventKey string) ([]match.Match, error) {
+func (db DB) GetMatches(ctx context.Context, eventKey string) ([]match.Match, error) {
 	if matches, ok := db.Matches[eventKey]; ok {
 		return matches, nil
 	}
@@ -40,7 +41,7 @@ func (db DB) GetMatches(eventKey string) ([]match.Match, error) {
 }
 
 // GetPhotoURL gets the photo URL in the mock db for TBA by year and team.
-func (db DB) GetPhotoURL(team string, year int) (string, error) {
+func (db DB) GetPhotoURL(ctx context.Context, team string, year int) (string, error) {
 	if teams, ok := db.Photos[year]; ok {
 		if url, ok := teams[team]; ok {
 			return url, nil
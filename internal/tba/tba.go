@@ -1,18 +1,53 @@
 package tba
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/Pigmice2733/scouting-backend/internal/store/event"
 	"github.com/Pigmice2733/scouting-backend/internal/store/match"
+	"github.com/Pigmice2733/scouting-backend/internal/tba/client"
 )
 
 // ErrNotModified is returned if the tba data has not been modified since last retrieved.
 var ErrNotModified = fmt.Errorf("tba data not modified")
 
+// ErrNoRankings is returned if rankings can't be retrieved by a consumer.
+var ErrNoRankings = fmt.Errorf("rankings not available")
+
 // Consumer provides an interface for getting information from TBA api.
 type Consumer interface {
-	GetEvents(year int) ([]event.BasicEvent, error)
-	GetMatches(eventKey string) ([]match.Match, error)
-	GetPhotoURL(team string, year int) (url string, err error)
+	GetEvents(ctx context.Context, year int) ([]event.BasicEvent, error)
+	GetMatches(ctx context.Context, eventKey string) ([]match.Match, error)
+	GetPhotoURL(ctx context.Context, team string, year int) (url string, err error)
+}
+
+// Forgetter is implemented by consumers that remember when data was last
+// retrieved, so that forgotten data is retrieved again even if it has not been
+// modified.
+type Forgetter interface {
+	ForgetEvents(year int)
+	ForgetMatches(eventKey string)
+}
+
+// Ranking is the qualification ranking of a team at an event.
+type Ranking struct {
+	Rank          int     `json:"rank"`
+	Team          string  `json:"team"`
+	RankingScore  float64 `json:"rankingScore"`
+	Wins          int     `json:"wins"`
+	Losses        int     `json:"losses"`
+	Ties          int     `json:"ties"`
+	MatchesPlayed int     `json:"matchesPlayed"`
+}
+
+// Ranker is implemented by consumers that can get the rankings of an event.
+type Ranker interface {
+	GetRankings(ctx context.Context, eventKey string) ([]Ranking, error)
+}
+
+// Monitor is implemented by consumers that keep stats on the health of the
+// upstream APIs they request, keyed by the name of the upstream.
+type Monitor interface {
+	Upstreams() map[string]client.Stats
 }
@@ -1,4 +1,4 @@
 #!/bin/bash
 
 cd ../internal/store/postgres/migrations
-go-bindata -pkg migrations .
+go-bindata -pkg migrations -ignore bindata.go .